@@ -0,0 +1,16 @@
+data "n8ncloud_credential_schema" "github" {
+  type = "githubApi"
+}
+
+variable "github_credential_data" {
+  type      = map(string)
+  sensitive = true
+}
+
+# Fail early when a required field of the credential data is missing
+check "github_credential_data" {
+  assert {
+    condition     = alltrue([for field in data.n8ncloud_credential_schema.github.required_fields : contains(keys(var.github_credential_data), field)])
+    error_message = "The GitHub credential data is missing required fields."
+  }
+}
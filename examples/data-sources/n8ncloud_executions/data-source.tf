@@ -0,0 +1,15 @@
+# Alert on the failures of the nightly sync over the last day
+data "n8ncloud_executions" "nightly_sync_failures" {
+  include_data = true
+
+  filter {
+    workflow_id   = "2tUt1wbLX592XDdX"
+    status        = "error"
+    started_after = timeadd(plantimestamp(), "-24h")
+    limit         = 10
+  }
+}
+
+output "nightly_sync_errors" {
+  value = [for e in data.n8ncloud_executions.nightly_sync_failures.executions : "${e.last_node_executed}: ${e.error_message}"]
+}
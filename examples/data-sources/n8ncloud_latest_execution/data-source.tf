@@ -0,0 +1,8 @@
+# Check that the nightly sync last succeeded
+data "n8ncloud_latest_execution" "nightly_sync" {
+  workflow_id = "2tUt1wbLX592XDdX"
+}
+
+output "nightly_sync_status" {
+  value = data.n8ncloud_latest_execution.nightly_sync.status
+}
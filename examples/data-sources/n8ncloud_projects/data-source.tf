@@ -0,0 +1,9 @@
+# Look up a project by name
+data "n8ncloud_projects" "sales" {
+  name = "Sales"
+}
+
+resource "n8ncloud_workflow_transfer" "orders" {
+  workflow_id            = n8ncloud_workflow.orders.id
+  destination_project_id = data.n8ncloud_projects.sales.projects[0].id
+}
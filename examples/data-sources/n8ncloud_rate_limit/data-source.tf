@@ -0,0 +1,6 @@
+# Report the remaining API request budget
+data "n8ncloud_rate_limit" "current" {}
+
+output "remaining_requests" {
+  value = data.n8ncloud_rate_limit.current.remaining
+}
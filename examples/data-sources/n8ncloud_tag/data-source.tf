@@ -0,0 +1,8 @@
+# Look up a tag by name
+data "n8ncloud_tag" "production" {
+  name = "production"
+}
+
+output "production_tag_id" {
+  value = data.n8ncloud_tag.production.id
+}
@@ -0,0 +1,9 @@
+# Resolve tag names to IDs, creating any tag that does not exist yet
+data "n8ncloud_tag_ids" "workflow_tags" {
+  names          = ["production", "billing"]
+  create_missing = true
+}
+
+output "production_tag_id" {
+  value = data.n8ncloud_tag_ids.workflow_tags.ids["production"]
+}
@@ -0,0 +1,10 @@
+# Report user counts for seat tracking
+data "n8ncloud_user_stats" "current" {}
+
+output "user_counts" {
+  value = {
+    total   = data.n8ncloud_user_stats.current.total
+    pending = data.n8ncloud_user_stats.current.pending
+    admins  = lookup(data.n8ncloud_user_stats.current.by_role, "global:admin", 0)
+  }
+}
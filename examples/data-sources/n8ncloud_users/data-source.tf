@@ -0,0 +1,15 @@
+# List the admins of the instance for an access audit
+data "n8ncloud_users" "admins" {
+  role = "global:admin"
+}
+
+output "admin_emails" {
+  value = [for user in data.n8ncloud_users.admins.users : user.email]
+}
+
+# Script imports for users not managed by Terraform yet
+data "n8ncloud_users" "all" {}
+
+output "import_commands" {
+  value = [for user in data.n8ncloud_users.all.users : "terraform import 'n8ncloud_user.users[\"${user.email}\"]' ${user.import_id}"]
+}
@@ -0,0 +1,8 @@
+# List the variables of the app before adding more
+data "n8ncloud_variables" "app" {
+  key_prefix = "APP_"
+}
+
+output "app_variable_keys" {
+  value = data.n8ncloud_variables.app.variables[*].key
+}
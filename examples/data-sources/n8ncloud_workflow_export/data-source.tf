@@ -0,0 +1,16 @@
+# Back up a workflow to a file
+data "n8ncloud_workflow_export" "billing" {
+  workflow_id = "2tUt1wbLX592XDdX"
+}
+
+resource "local_file" "billing_backup" {
+  filename = "${path.module}/backups/billing.json"
+  content  = data.n8ncloud_workflow_export.billing.json
+}
+
+# Look the workflow up by name, narrowed to a project in case the name is
+# reused elsewhere
+data "n8ncloud_workflow_export" "onboarding" {
+  name       = "Onboarding"
+  project_id = "VmwOO9HeTEj20kxM"
+}
@@ -0,0 +1,13 @@
+# Preview how the tags of a workflow would change
+data "n8ncloud_workflow_tag_diff" "billing" {
+  workflow_id = "2tUt1wbLX592XDdX"
+  tag_names   = ["production", "billing"]
+}
+
+output "tags_to_add" {
+  value = data.n8ncloud_workflow_tag_diff.billing.to_add
+}
+
+output "tags_to_remove" {
+  value = data.n8ncloud_workflow_tag_diff.billing.to_remove
+}
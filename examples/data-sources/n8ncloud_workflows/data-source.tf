@@ -0,0 +1,9 @@
+# List the active production workflows
+data "n8ncloud_workflows" "production" {
+  active = true
+  tags   = ["production"]
+}
+
+output "production_workflow_names" {
+  value = data.n8ncloud_workflows.production.workflows[*].name
+}
@@ -0,0 +1,8 @@
+# Find the workflows carrying a tag before retagging them
+data "n8ncloud_workflows_by_tag" "legacy" {
+  tag_name = "legacy"
+}
+
+output "legacy_workflow_ids" {
+  value = [for workflow in data.n8ncloud_workflows_by_tag.legacy.workflows : workflow.id]
+}
@@ -0,0 +1,10 @@
+# Create a credential for the duration of a single run, without storing the
+# secret in state
+ephemeral "n8ncloud_credential" "deploy" {
+  name = "Deploy token"
+  type = "httpHeaderAuth"
+  data = jsonencode({
+    name  = "Authorization"
+    value = "Bearer ${var.deploy_token}"
+  })
+}
@@ -0,0 +1,4 @@
+# Build an n8n expression that compares a field with a Terraform value
+locals {
+  team_filter = "={{ $json.team === ${provider::n8ncloud::escape_expression(var.team)} }}"
+}
@@ -0,0 +1,4 @@
+# Extract the invitation IDs to send them through another channel
+output "invitee_id" {
+  value = provider::n8ncloud::invite_token(n8ncloud_user.developer.invite_accept_url).invitee_id
+}
@@ -0,0 +1,4 @@
+# Tags to add when moving a workflow from one tag set to another
+output "tags_to_add" {
+  value = provider::n8ncloud::list_added(["production", "legacy"], ["production", "finance"])
+}
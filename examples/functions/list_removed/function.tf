@@ -0,0 +1,4 @@
+# Tags to remove when moving a workflow from one tag set to another
+output "tags_to_remove" {
+  value = provider::n8ncloud::list_removed(["production", "legacy"], ["production", "finance"])
+}
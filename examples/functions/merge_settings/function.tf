@@ -0,0 +1,7 @@
+# Merge module defaults with per-workflow overrides
+output "settings" {
+  value = provider::n8ncloud::merge_settings(
+    jsonencode({ timezone = "UTC", saveManualExecutions = true }),
+    jsonencode({ timezone = "America/New_York" }),
+  )
+}
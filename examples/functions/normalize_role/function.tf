@@ -0,0 +1,5 @@
+# Sanitize a role read from an external source
+resource "n8ncloud_user" "imported" {
+  email = "imported@example.com"
+  role  = provider::n8ncloud::normalize_role(var.directory_role)
+}
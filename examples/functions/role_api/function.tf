@@ -0,0 +1,5 @@
+# Construct a user's role from a friendly label
+resource "n8ncloud_user" "analyst" {
+  email = "analyst@example.com"
+  role  = provider::n8ncloud::role_api("Member")
+}
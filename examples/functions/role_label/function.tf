@@ -0,0 +1,4 @@
+# Display a user's role with its friendly label
+output "developer_role" {
+  value = provider::n8ncloud::role_label(n8ncloud_user.developer.role)
+}
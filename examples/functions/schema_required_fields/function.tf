@@ -0,0 +1,13 @@
+# List the fields a credential type requires
+output "required_fields" {
+  value = provider::n8ncloud::schema_required_fields(
+    jsonencode({
+      type = "object"
+      properties = {
+        apiKey = { type = "string" }
+        domain = { type = "string" }
+      }
+      required = ["apiKey", "domain"]
+    })
+  )
+}
@@ -0,0 +1,4 @@
+# Fail the plan when an exported workflow has dangling connections
+output "workflow_problems" {
+  value = provider::n8ncloud::validate_workflow(file("${path.module}/workflow.json"), true)
+}
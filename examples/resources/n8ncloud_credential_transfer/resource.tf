@@ -0,0 +1,5 @@
+# Move a shared credential along with the workflows that use it
+resource "n8ncloud_credential_transfer" "github" {
+  credential_id          = "jUF2ylXHqd8GQyBa"
+  destination_project_id = "4VjeUUGsbNmJUnUq"
+}
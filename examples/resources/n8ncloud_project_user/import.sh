@@ -0,0 +1,2 @@
+# Project memberships can be imported by project ID and user ID, separated by a colon
+terraform import n8ncloud_project_user.ada VmwOO9HeTEj20kxM:91765f0d-3b29-45df-adb9-35b23937eb92
@@ -0,0 +1,11 @@
+resource "n8ncloud_user" "ada" {
+  email = "ada@example.com"
+  role  = "global:member"
+}
+
+# Give the user editor access to a project
+resource "n8ncloud_project_user" "ada" {
+  project_id = "VmwOO9HeTEj20kxM"
+  user_id    = n8ncloud_user.ada.id
+  role       = "project:editor"
+}
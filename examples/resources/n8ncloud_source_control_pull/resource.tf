@@ -0,0 +1,20 @@
+# Pull the workflows of the deployed commit into the production instance
+variable "commit_sha" {
+  type = string
+}
+
+resource "n8ncloud_source_control_pull" "deploy" {
+  force = true
+
+  variables = {
+    environment = "production"
+  }
+
+  triggers = {
+    commit = var.commit_sha
+  }
+}
+
+output "deployed_workflows" {
+  value = n8ncloud_source_control_pull.deploy.workflow_ids
+}
@@ -0,0 +1,5 @@
+# Tags can be imported by their ID
+terraform import n8ncloud_tag.production 2tUt1wbLX592XDdX
+
+# Or by their name
+terraform import n8ncloud_tag.production production
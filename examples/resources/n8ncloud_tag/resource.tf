@@ -0,0 +1,10 @@
+resource "n8ncloud_tag" "production" {
+  name = "production"
+}
+
+resource "n8ncloud_workflow" "orders" {
+  name        = "Process orders"
+  nodes       = file("${path.module}/workflows/orders/nodes.json")
+  connections = file("${path.module}/workflows/orders/connections.json")
+  tag_ids     = [n8ncloud_tag.production.id]
+}
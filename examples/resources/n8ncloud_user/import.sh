@@ -0,0 +1,5 @@
+# Users can be imported by their email
+terraform import n8ncloud_user.developer developer@example.com
+
+# Or by their ID, as shown in the n8n UI
+terraform import n8ncloud_user.developer 9f1c0d2e-4b5a-4c3d-8e7f-6a5b4c3d2e1f
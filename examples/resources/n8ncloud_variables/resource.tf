@@ -0,0 +1,13 @@
+# Manage all of the instance's variables in one resource
+resource "n8ncloud_variables" "this" {
+  variables = {
+    API_BASE_URL = "https://api.example.com"
+    ENVIRONMENT  = "production"
+    SLACK_TOKEN  = var.slack_token
+  }
+}
+
+variable "slack_token" {
+  type      = string
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# Workflows can be imported by their ID
+terraform import n8ncloud_workflow.orders 2tUt1wbLX592XDdX
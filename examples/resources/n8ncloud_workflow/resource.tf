@@ -0,0 +1,51 @@
+# Manage a workflow with a webhook trigger
+resource "n8ncloud_workflow" "orders" {
+  name   = "Process orders"
+  active = true
+
+  nodes = jsonencode([
+    {
+      name        = "Webhook"
+      type        = "n8n-nodes-base.webhook"
+      typeVersion = 2
+      position    = [0, 0]
+      parameters = {
+        path       = "orders"
+        httpMethod = "POST"
+      }
+    },
+    {
+      name        = "Set"
+      type        = "n8n-nodes-base.set"
+      typeVersion = 3
+      position    = [220, 0]
+      parameters  = {}
+    },
+  ])
+
+  connections = jsonencode({
+    Webhook = {
+      main = [[{ node = "Set", type = "main", index = 0 }]]
+    }
+  })
+
+  settings = jsonencode({
+    executionOrder = "v1"
+  })
+
+  error_workflow_id = n8ncloud_workflow.errors.id
+}
+
+# Or manage a workflow exported from the editor
+locals {
+  report_errors = jsondecode(file("${path.module}/report-errors.json"))
+}
+
+resource "n8ncloud_workflow" "errors" {
+  name        = "Report errors"
+  nodes       = jsonencode(local.report_errors.nodes)
+  connections = jsonencode(local.report_errors.connections)
+
+  # Nodes moved in the editor are not reported as drift
+  ignore_node_fields = ["position"]
+}
@@ -0,0 +1,2 @@
+# Activations can be imported by the workflow ID
+terraform import n8ncloud_workflow_activation.orders 2tUt1wbLX592XDdX
@@ -0,0 +1,6 @@
+# Keep a workflow edited in the n8n UI active
+resource "n8ncloud_workflow_activation" "orders" {
+  workflow_id           = "2tUt1wbLX592XDdX"
+  active                = true
+  deactivate_on_destroy = true
+}
@@ -0,0 +1,2 @@
+# Workflow tags can be imported by the workflow ID
+terraform import n8ncloud_workflow_tags.orders 2tUt1wbLX592XDdX
@@ -0,0 +1,9 @@
+# Tag a workflow edited in the n8n UI
+resource "n8ncloud_tag" "production" {
+  name = "production"
+}
+
+resource "n8ncloud_workflow_tags" "orders" {
+  workflow_id = "2tUt1wbLX592XDdX"
+  tag_ids     = [n8ncloud_tag.production.id]
+}
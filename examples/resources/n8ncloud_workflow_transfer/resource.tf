@@ -0,0 +1,5 @@
+# Move a workflow to the project of the team that now owns it
+resource "n8ncloud_workflow_transfer" "orders" {
+  workflow_id            = n8ncloud_workflow.orders.id
+  destination_project_id = "4VjeUUGsbNmJUnUq"
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// userFieldAliases maps the JSON field names of a User to names other n8n
+// versions have used for the same field. Field names already match
+// case-insensitively, so only names that differ otherwise are listed.
+var userFieldAliases = map[string][]string{
+	"firstName": {"first_name"},
+	"lastName":  {"last_name"},
+	"isPending": {"pending"},
+	"createdAt": {"created_at"},
+	"updatedAt": {"updated_at"},
+}
+
+// withFieldAliases returns the JSON object data with each aliased field
+// renamed to its canonical name, unless the canonical field is present too.
+// data is returned as-is if it is not an object or uses no alias.
+func withFieldAliases(data []byte, aliases map[string][]string) ([]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) || !containsAlias(data, aliases) {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	renamed := false
+	for canonical, names := range aliases {
+		if _, ok := fields[canonical]; ok {
+			continue
+		}
+
+		for _, name := range names {
+			if value, ok := fields[name]; ok {
+				fields[canonical] = value
+				delete(fields, name)
+				renamed = true
+				break
+			}
+		}
+	}
+
+	if !renamed {
+		return data, nil
+	}
+
+	return json.Marshal(fields)
+}
+
+// containsAlias reports whether any alias appears in data as a quoted
+// string, to skip decoding objects that use none, such as every item of a
+// long user list.
+func containsAlias(data []byte, aliases map[string][]string) bool {
+	for _, names := range aliases {
+		for _, name := range names {
+			if bytes.Contains(data, []byte(`"`+name+`"`)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the field names of
+// older and newer n8n versions listed in userFieldAliases.
+func (u *User) UnmarshalJSON(data []byte) error {
+	data, err := withFieldAliases(data, userFieldAliases)
+	if err != nil {
+		return err
+	}
+
+	// The conversion drops the method set, so this does not recurse.
+	type user User
+	return json.Unmarshal(data, (*user)(u))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUserUnmarshalJSON_fieldAliases(t *testing.T) {
+	current := `{"id":"1","email":"ada@example.com","firstName":"Ada","lastName":"Lovelace","isPending":true,"role":"global:admin","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-02-01T00:00:00Z"}`
+	aliased := `{"id":"1","email":"ada@example.com","first_name":"Ada","last_name":"Lovelace","pending":true,"role":"global:admin","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-02-01T00:00:00Z"}`
+
+	var want, got User
+	if err := json.Unmarshal([]byte(current), &want); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := json.Unmarshal([]byte(aliased), &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !want.IsPending || want.FirstName == nil || want.CreatedAt.IsZero() {
+		t.Fatalf("expected the current field names to decode, got %+v", want)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected aliased fields to decode to %+v, got %+v", want, got)
+	}
+}
+
+func TestUserUnmarshalJSON_canonicalFieldWins(t *testing.T) {
+	var user User
+	if err := json.Unmarshal([]byte(`{"id":"1","isPending":false,"pending":true}`), &user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if user.IsPending {
+		t.Error("expected isPending to take precedence over its alias")
+	}
+}
+
+func TestUserUnmarshalJSON_notAnObject(t *testing.T) {
+	var user User
+	if err := json.Unmarshal([]byte(`"1"`), &user); err == nil {
+		t.Error("expected an error for a response that is not an object")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// apiKeySource holds the API key a client authenticates with. The key can be
+// replaced by reload while requests are in flight, so it is guarded by a
+// mutex.
+type apiKeySource struct {
+	reload func() (string, error)
+
+	mu  sync.RWMutex
+	key string
+}
+
+// get returns the current API key.
+func (s *apiKeySource) get() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.key
+}
+
+// refresh reloads the API key and reports whether it differs from used, the
+// key a rejected request was sent with. A key that was already replaced by a
+// concurrent refresh counts as changed.
+func (s *apiKeySource) refresh(used string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key != used {
+		return true, nil
+	}
+
+	key, err := s.reload()
+	if err != nil {
+		return false, err
+	}
+	if key == "" || key == s.key {
+		return false, nil
+	}
+
+	s.key = key
+	return true, nil
+}
+
+// isUnauthorized reports whether err is an APIError for a 401 response.
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned when a request is short-circuited because the
+// instance failed too many consecutive requests.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops sending requests after a number of consecutive
+// failures. Once the cooldown has elapsed a single trial request is let
+// through (half-open); its outcome closes or reopens the circuit.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	trialing bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request may be sent. It returns an error wrapping
+// ErrCircuitOpen while the circuit is open or a half-open trial is in flight.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		remaining := b.cooldown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("%w after %d consecutive failures; retrying in %s", ErrCircuitOpen, b.failures, remaining.Round(time.Second))
+		}
+		b.state = circuitHalfOpen
+	}
+
+	if b.state == circuitHalfOpen {
+		if b.trialing {
+			return fmt.Errorf("%w; waiting for a trial request to complete", ErrCircuitOpen)
+		}
+		b.trialing = true
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request that allow let
+// through.
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialing = false
+
+	if !failed {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_transitions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	// Closed: failures below the threshold keep the circuit closed.
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit to allow request, got: %s", err)
+	}
+	b.record(true)
+	if b.state != circuitClosed {
+		t.Fatalf("expected circuit to stay closed after 1 failure, got state %d", b.state)
+	}
+
+	// Reaching the threshold opens the circuit.
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected closed circuit to allow request, got: %s", err)
+	}
+	b.record(true)
+	if b.state != circuitOpen {
+		t.Fatalf("expected circuit to open after 2 failures, got state %d", b.state)
+	}
+
+	// Open: requests are rejected until the cooldown elapses.
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got: %v", err)
+	}
+
+	// Half-open: a single trial request is let through.
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected half-open circuit to allow a trial request, got: %s", err)
+	}
+	if b.state != circuitHalfOpen {
+		t.Fatalf("expected half-open circuit, got state %d", b.state)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected concurrent request during trial to be rejected, got: %v", err)
+	}
+
+	// A failed trial reopens the circuit.
+	b.record(true)
+	if b.state != circuitOpen {
+		t.Fatalf("expected failed trial to reopen the circuit, got state %d", b.state)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen after failed trial, got: %v", err)
+	}
+
+	// A successful trial closes the circuit.
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected half-open circuit to allow a trial request, got: %s", err)
+	}
+	b.record(false)
+	if b.state != circuitClosed || b.failures != 0 {
+		t.Fatalf("expected successful trial to close the circuit, got state %d with %d failures", b.state, b.failures)
+	}
+}
+
+func TestCircuitBreaker_successResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute)
+
+	b.record(true)
+	b.record(false)
+	b.record(true)
+
+	if b.state != circuitClosed {
+		t.Errorf("expected non-consecutive failures to keep the circuit closed, got state %d", b.state)
+	}
+}
+
+func TestDoRequest_circuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClientWithConfig(t, &Config{CircuitBreakerThreshold: 2}, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	for i := 0; i < 2; i++ {
+		var apiErr *APIError
+		if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); !errors.As(err, &apiErr) {
+			t.Fatalf("expected API error, got: %v", err)
+		}
+	}
+
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got: %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected the open circuit to short-circuit the request, server saw %d requests", got)
+	}
+}
+
+func TestDoRequest_circuitBreakerIgnoresClientErrors(t *testing.T) {
+	c := newTestClientWithConfig(t, &Config{CircuitBreakerThreshold: 1}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.doRequest(context.Background(), http.MethodGet, "/users/missing", nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected 4xx responses not to open the circuit")
+		}
+	}
+}
@@ -6,30 +6,238 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
-	defaultTimeout = 30 * time.Second
-	userAgent      = "terraform-provider-n8ncloud"
+	defaultTimeout              = 30 * time.Second
+	defaultSlowRequestThreshold = 5 * time.Second
+	defaultAccept               = "application/json"
+	jsonContentType             = "application/json"
+	userAgentProduct            = "terraform-provider-n8ncloud"
+
+	// defaultBasePath is the path of the n8n public API under the instance
+	// URL.
+	defaultBasePath = "/api/v1"
+
+	// maxPageSize is the largest page size list endpoints accept.
+	maxPageSize = 250
+
+	// workspaceHeader carries the workspace ID on every request when one is
+	// configured.
+	workspaceHeader = "X-N8N-Workspace-ID"
+
+	// methodOverrideHeader carries the intended method of a request sent as
+	// POST when MethodOverride is set.
+	methodOverrideHeader = "X-HTTP-Method-Override"
 )
 
-// Client is the n8n API client.
+// ErrDryRun is returned instead of sending a request that would modify the
+// instance when the client is in dry-run mode.
+var ErrDryRun = errors.New("dry run is enabled")
+
+// ErrDeadlineExceeded is returned for requests that were aborted or not sent
+// because the client's Deadline has passed.
+var ErrDeadlineExceeded = errors.New("global deadline exceeded")
+
+// ErrRequestTimeout is returned for request attempts that were aborted
+// because they took longer than the client's Timeout.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// Client is the n8n API client. A Client is shared by all resources and data
+// sources, which Terraform runs concurrently, so it is safe for concurrent
+// use: its configuration is immutable after NewClient, and any shared state
+// that changes, such as the circuit breaker, a reloaded API key or the
+// detected instance version, is guarded by a mutex or stored atomically.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL              string
+	basePath             string
+	apiKey               *apiKeySource
+	httpClient           *http.Client
+	timeout              time.Duration
+	slowRequestThreshold time.Duration
+	accept               string
+	workspaceID          string
+	pageSize             int
+	methodOverride       bool
+	dryRun               bool
+	sendNullForEmpty     bool
+	userAgent            string
+
+	// headers are the extra headers sent with every request, keyed by
+	// canonical name. They are never modified after creation; WithHeaders
+	// copies them.
+	headers http.Header
+
+	// deadline is the time after which no request may run. It is zero when
+	// requests are only limited by their own timeout.
+	deadline time.Time
+
+	// requestSlots limits the number of in-flight requests when
+	// MaxConcurrentRequests is set. It is nil when requests are unlimited.
+	requestSlots chan struct{}
+
+	// rateLimiter spaces out requests when RateLimit is set. It is nil when
+	// requests are not rate limited.
+	rateLimiter *rateLimiter
+
+	// retry decides which failed requests are retried and how long to wait
+	// between attempts.
+	retry *retryPolicy
+
+	// circuitBreaker short-circuits requests after repeated failures. It is
+	// nil when CircuitBreakerThreshold is not set.
+	circuitBreaker *circuitBreaker
+
+	// version is the instance version found by DetectVersion, shared with
+	// clients derived by WithAPIKey. It holds nil until it is detected.
+	version *atomic.Pointer[Version]
+
+	// readCache memoizes GET responses when ReadCacheTTL is set. It is nil
+	// when reads are not cached.
+	readCache *readCache
+
+	// bypassReadCache is set on clients derived by WithAPIKey and
+	// WithHeaders, whose responses may differ from the configured
+	// client's. They do not read from or add to the cache, but their
+	// writes still invalidate it.
+	bypassReadCache bool
 }
 
 // Config holds the configuration for the client.
 type Config struct {
 	BaseURL string
-	APIKey  string
+	// BasePath is the path of the API under BaseURL, e.g. for a gateway
+	// that exposes the public API elsewhere. Defaults to /api/v1.
+	BasePath string
+	APIKey   string
+	// Version is the provider version reported in the User-Agent header,
+	// e.g. 1.2.0, dev or test, so API traffic can be traced to a build.
+	// Empty omits it.
+	Version string
+	// Timeout bounds each attempt of a request, including reading the
+	// response. Defaults to 30 seconds. Requests are also aborted when the
+	// context they are made with ends.
 	Timeout time.Duration
+	// ReloadAPIKey, when set, is called to read a fresh API key after a
+	// request is rejected with 401, e.g. from a file a secrets manager
+	// rotates. The request is retried once if the key changed.
+	ReloadAPIKey func() (string, error)
+	// SlowRequestThreshold is the duration after which a request is logged
+	// as slow. Defaults to 5 seconds; a negative value disables the warning.
+	SlowRequestThreshold time.Duration
+	// Accept overrides the Accept header sent with every request, e.g. to
+	// request a specific API response version. Defaults to application/json.
+	Accept string
+	// DisableCompression stops the client from requesting gzip-compressed
+	// responses, for intermediaries that mishandle them.
+	DisableCompression bool
+	// ForceHTTP1 disables HTTP/2, for proxies and gateways that mishandle it.
+	ForceHTTP1 bool
+	// RootCAs replaces the system certificate pool used to verify the
+	// instance's TLS certificate, e.g. for instances behind an internal CA.
+	// Nil means the system pool.
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables verification of the instance's TLS
+	// certificate, e.g. for a test instance with a self-signed certificate.
+	// Requests can then be intercepted, API key included.
+	InsecureSkipVerify bool
+	// ProxyURL is the proxy every request is sent through, e.g.
+	// http://proxy.internal:3128. Nil uses the proxy given by the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. The
+	// request timeout includes connecting through the proxy.
+	ProxyURL *url.URL
+	// MethodOverride sends PATCH and DELETE requests as POST with the
+	// intended method in the X-HTTP-Method-Override header, for proxies that
+	// block those methods.
+	MethodOverride bool
+	// DryRun stops the client from sending requests other than GET, which
+	// fail with ErrDryRun instead, so nothing on the instance is modified.
+	DryRun bool
+	// SendNullForEmpty sends empty optional string fields of request bodies,
+	// such as the names of a new user, as null instead of omitting them, for
+	// instances that treat the two differently.
+	SendNullForEmpty bool
+	// Deadline, when set, caps every request made by the client: requests
+	// still running at the deadline are aborted and later ones fail fast,
+	// both with ErrDeadlineExceeded.
+	Deadline time.Time
+	// Headers are extra headers sent with every request, e.g. for a
+	// gateway that routes on them. They cannot replace the headers the
+	// client manages, such as X-N8N-API-KEY, Accept or Content-Type.
+	Headers map[string]string
+	// MaxConcurrentRequests limits how many requests the client sends at
+	// once, shared by all resources using the client. Zero means unlimited.
+	MaxConcurrentRequests int
+	// RateLimit limits how many requests per second the client sends, e.g.
+	// to stay below the instance's rate limit. Zero means unlimited. When
+	// set, a 429 response with a Retry-After header holds back every
+	// request sharing the limiter, not only the one being retried.
+	RateLimit float64
+	// MaxIdleConnsPerHost is how many idle connections to the instance are
+	// kept open for reuse. Defaults to 10, or MaxConcurrentRequests when it
+	// is higher, so parallel requests reuse connections instead of opening
+	// a new one each.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps the connections open to the instance, including
+	// those in use. Zero means unlimited. Requests waiting for a connection
+	// count against their timeout, so MaxConcurrentRequests and RateLimit
+	// are the preferred ways to limit load on the instance.
+	MaxConnsPerHost int
+	// PageSize is the number of items requested per page from list
+	// endpoints. Values are clamped to the 1-250 range the API accepts;
+	// zero requests the maximum to reduce round-trips.
+	PageSize int
+	// WorkspaceID scopes every request to a workspace by sending it in the
+	// X-N8N-Workspace-ID header, for multi-tenant deployments that route
+	// requests by workspace. Empty means the single-workspace behavior.
+	WorkspaceID string
+	// RetryOnStatus lists HTTP status codes to retry in addition to the
+	// default 429, 502, 503 and 504, e.g. 409 for deployments that return it
+	// transiently.
+	RetryOnStatus []int
+	// MaxRetries is how many times a request failing transiently is
+	// retried. Defaults to 3; a negative value disables retries.
+	MaxRetries int
+	// RetryWaitMin is the backoff before the first retry, doubling with
+	// each further retry. Defaults to 1 second.
+	RetryWaitMin time.Duration
+	// RetryWaitMax caps the backoff between retries. Defaults to 30 seconds.
+	RetryWaitMax time.Duration
+	// CircuitBreakerThreshold is the number of consecutive failed requests
+	// (transport errors or 5xx responses) after which further requests fail
+	// fast for CircuitBreakerCooldown. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCooldown is how long the circuit stays open before a
+	// trial request is sent. Defaults to 30 seconds.
+	CircuitBreakerCooldown time.Duration
+	// ReadCacheTTL, when set, is how long successful GET responses are
+	// reused for requests to the same path, e.g. for many lookups of the
+	// same user within one plan. Any other request drops the cached
+	// responses of the resource type it touches. Zero disables the cache.
+	ReadCacheTTL time.Duration
+}
+
+// buildUserAgent returns the User-Agent header for requests made by the given
+// provider version.
+func buildUserAgent(version string) string {
+	if version == "" {
+		return userAgentProduct + " (terraform-plugin-framework)"
+	}
+
+	return fmt.Sprintf("%s/%s (terraform-plugin-framework)", userAgentProduct, version)
 }
 
 // NewClient creates a new n8n API client.
@@ -46,56 +254,602 @@ func NewClient(config *Config) (*Client, error) {
 		timeout = defaultTimeout
 	}
 
+	slowRequestThreshold := config.SlowRequestThreshold
+	if slowRequestThreshold == 0 {
+		slowRequestThreshold = defaultSlowRequestThreshold
+	}
+
+	accept := config.Accept
+	if accept == "" {
+		accept = defaultAccept
+	}
+
+	pageSize := config.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	var requestSlots chan struct{}
+	if config.MaxConcurrentRequests > 0 {
+		requestSlots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	var breaker *circuitBreaker
+	if config.CircuitBreakerThreshold > 0 {
+		breaker = newCircuitBreaker(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown)
+	}
+
 	return &Client{
-		baseURL: config.BaseURL,
-		apiKey:  config.APIKey,
+		baseURL:  strings.TrimRight(config.BaseURL, "/"),
+		basePath: normalizeBasePath(config.BasePath),
+		apiKey:   &apiKeySource{key: config.APIKey, reload: config.ReloadAPIKey},
+		// The timeout is applied to the context of each attempt instead of
+		// the HTTP client, so that cancelling the caller's context, e.g. when
+		// Terraform is interrupted, is what aborts requests.
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Transport: newTransport(config),
 		},
+		timeout:              timeout,
+		slowRequestThreshold: slowRequestThreshold,
+		accept:               accept,
+		workspaceID:          config.WorkspaceID,
+		pageSize:             pageSize,
+		methodOverride:       config.MethodOverride,
+		dryRun:               config.DryRun,
+		sendNullForEmpty:     config.SendNullForEmpty,
+		userAgent:            buildUserAgent(config.Version),
+		headers:              mergeHeaders(nil, config.Headers),
+		deadline:             config.Deadline,
+		requestSlots:         requestSlots,
+		rateLimiter:          newRateLimiter(config.RateLimit),
+		retry:                newRetryPolicy(config),
+		circuitBreaker:       breaker,
+		version:              &atomic.Pointer[Version]{},
+		readCache:            newReadCache(config.ReadCacheTTL),
 	}, nil
 }
 
-// doRequest performs an HTTP request.
+// normalizeBasePath returns basePath with a single leading slash and no
+// trailing slash, or the default base path if it is empty. A base path of
+// "/" addresses the API at the root of the base URL.
+func normalizeBasePath(basePath string) string {
+	basePath = strings.TrimSpace(basePath)
+	if basePath == "" {
+		return defaultBasePath
+	}
+
+	trimmed := strings.Trim(basePath, "/")
+	if trimmed == "" {
+		return ""
+	}
+
+	return "/" + trimmed
+}
+
+// WithAPIKey returns a client that authenticates with apiKey instead of the
+// configured key. It shares the HTTP client, concurrency limit, rate limit
+// and circuit breaker of c, but does not reload its key or reuse cached
+// reads.
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	scoped := *c
+	scoped.apiKey = &apiKeySource{key: apiKey}
+	scoped.bypassReadCache = true
+	return &scoped
+}
+
+// WithHeaders returns a client that also sends headers with every request,
+// replacing the configured headers of the same name. It shares the HTTP
+// client, concurrency limit, rate limit and circuit breaker of c, but does
+// not reuse cached reads.
+func (c *Client) WithHeaders(headers map[string]string) *Client {
+	scoped := *c
+	scoped.headers = mergeHeaders(c.headers, headers)
+	scoped.bypassReadCache = true
+	return &scoped
+}
+
+// managedHeaders are the headers the client sets on every request it sends.
+var managedHeaders = []string{
+	"X-N8N-API-KEY",
+	"Content-Type",
+	"Accept",
+	"User-Agent",
+	workspaceHeader,
+	methodOverrideHeader,
+}
+
+// IsManagedHeader reports whether name is one of the headers the client
+// sets itself, which Config.Headers and WithHeaders cannot replace. Header
+// names are case-insensitive.
+func IsManagedHeader(name string) bool {
+	for _, managed := range managedHeaders {
+		if strings.EqualFold(name, managed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeHeaders returns a copy of base with the values in headers set on top,
+// or nil if both are empty.
+func mergeHeaders(base http.Header, headers map[string]string) http.Header {
+	if len(base) == 0 && len(headers) == 0 {
+		return nil
+	}
+
+	merged := base.Clone()
+	if merged == nil {
+		merged = make(http.Header, len(headers))
+	}
+	for name, value := range headers {
+		merged.Set(name, value)
+	}
+
+	return merged
+}
+
+// pathWithQuery builds a request path from path and the query parameters in
+// params, merged with extra. Parameters in params take precedence over extra
+// so passthrough values cannot override the ones the client manages. All
+// keys and values are URL-encoded.
+func pathWithQuery(path string, params url.Values, extra map[string]string) string {
+	query := url.Values{}
+	for key, value := range extra {
+		query.Set(key, value)
+	}
+	for key, values := range params {
+		query[key] = values
+	}
+
+	if len(query) == 0 {
+		return path
+	}
+
+	return path + "?" + query.Encode()
+}
+
+// listParams returns the query parameters for requesting the page of a list
+// endpoint that starts at cursor, or the first page if cursor is empty. The
+// page size of opts, which may be nil, takes precedence over the client's.
+func (c *Client) listParams(cursor string, opts *ListOptions) url.Values {
+	pageSize := c.pageSize
+	if opts != nil && opts.PageSize > 0 {
+		pageSize = min(opts.PageSize, maxPageSize)
+	}
+
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(pageSize))
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	return params
+}
+
+// response holds the parts of an HTTP response callers may need beyond the
+// body.
+type response struct {
+	body   []byte
+	header http.Header
+}
+
+// doRequest performs an HTTP request and returns the response body.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
-	url := fmt.Sprintf("%s/api/v1%s", c.baseURL, path)
+	resp, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
 
-	var reqBody io.Reader
+	return resp.body, nil
+}
+
+// do performs an HTTP request and returns the response body and headers,
+// retrying transient failures according to the client's retry policy. GET
+// responses are served from and added to the read cache, if any.
+func (c *Client) do(ctx context.Context, method, path string, body interface{}) (*response, error) {
+	if method == http.MethodGet {
+		if cached := c.cachedRead(ctx, path); cached != nil {
+			return cached, nil
+		}
+	} else {
+		defer c.readCache.invalidate(path)
+	}
+
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	var resp *response
+	err := c.withRetry(ctx, method, path, func(ctx context.Context) error {
+		var err error
+		resp, err = c.doOnce(ctx, method, path, jsonBody, jsonContentType)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("X-N8N-API-KEY", c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", userAgent)
+	if method == http.MethodGet && !c.bypassReadCache {
+		c.readCache.put(path, resp)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	return resp, nil
+}
+
+// cachedRead returns the cached response for a GET request to path, or nil
+// if reads are not cached or there is none.
+func (c *Client) cachedRead(ctx context.Context, path string) *response {
+	if c.bypassReadCache {
+		return nil
+	}
+
+	cached := c.readCache.get(path)
+	if cached != nil {
+		tflog.Debug(ctx, "Reusing cached n8n API response", map[string]interface{}{
+			"path": path,
+		})
+	}
+
+	return cached
+}
+
+// doRequestRaw performs an HTTP request with a body that is sent as-is with
+// the given content type instead of being encoded as JSON, e.g. for
+// multipart uploads, and returns the response body. The body is read once
+// up front so it can be resent when the request is retried.
+func (c *Client) doRequestRaw(ctx context.Context, method, path, contentType string, body io.Reader) ([]byte, error) {
+	if method != http.MethodGet {
+		defer c.readCache.invalidate(path)
+	}
+
+	var rawBody []byte
+	if body != nil {
+		var err error
+		rawBody, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	var resp *response
+	err := c.withRetry(ctx, method, path, func(ctx context.Context) error {
+		var err error
+		resp, err = c.doOnce(ctx, method, path, rawBody, contentType)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.body, nil
+}
+
+// doStream performs a GET request and passes the response body to decode as
+// it is received, instead of buffering it, retrying transient failures
+// according to the client's retry policy. A failure to read the body, e.g.
+// because the connection closed mid-response, is retried like a connection
+// failure, while a body that was read but does not decode is not, since the
+// same body would be returned again. With a read cache, the body is buffered
+// so it can be cached.
+func (c *Client) doStream(ctx context.Context, path string, decode func(io.Reader) error) error {
+	if c.readCache != nil && !c.bypassReadCache {
+		resp, err := c.do(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return err
+		}
+
+		if err := decode(bytes.NewReader(resp.body)); err != nil {
+			return &decodeError{err: err}
+		}
+		return nil
+	}
+
+	return c.withRetry(ctx, http.MethodGet, path, func(ctx context.Context) error {
+		resp, release, err := c.send(ctx, http.MethodGet, path, nil, jsonContentType)
+		if err != nil {
+			return err
+		}
+		defer release()
+		defer resp.Body.Close()
+
+		body := &bodyReader{r: resp.Body}
+		if err := decode(body); err != nil {
+			if body.err != nil {
+				return fmt.Errorf("failed to read response body: %w", body.err)
+			}
+			return &decodeError{err: err}
+		}
+
+		return nil
+	})
+}
+
+// bodyReader reads a response body, recording the first error other than
+// io.EOF, so that a failed read can be told apart from a body that does not
+// decode.
+type bodyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err != nil && err != io.EOF && b.err == nil {
+		b.err = err
+	}
+
+	return n, err
+}
+
+// decodeError is returned by doStream for a response body that was read but
+// could not be decoded. It is never retried, even when it wraps io.EOF or
+// io.ErrUnexpectedEOF because the body ended in the middle of a value.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// withRetry calls attempt until it succeeds, fails with an error the retry
+// policy does not retry, or the retries are exhausted. A request rejected
+// with 401 is retried once more if the API key can be reloaded and changed.
+// Attempts are passed a context that ends at the client's deadline, if any.
+func (c *Client) withRetry(ctx context.Context, method, path string, attempt func(context.Context) error) error {
+	if c.deadline.IsZero() {
+		return c.retryLoop(ctx, method, path, attempt)
+	}
+
+	if !time.Now().Before(c.deadline) {
+		return fmt.Errorf("%w at %s, %s %s was not sent", ErrDeadlineExceeded, c.deadline.Format(time.RFC3339), method, path)
+	}
+
+	ctx, cancel := context.WithDeadlineCause(ctx, c.deadline, ErrDeadlineExceeded)
+	defer cancel()
+
+	err := c.retryLoop(ctx, method, path, attempt)
+	if err != nil && errors.Is(context.Cause(ctx), ErrDeadlineExceeded) {
+		return fmt.Errorf("%w at %s, %s %s was aborted: %s", ErrDeadlineExceeded, c.deadline.Format(time.RFC3339), method, path, err)
+	}
+
+	return err
+}
+
+// retryLoop implements withRetry without the client's deadline.
+func (c *Client) retryLoop(ctx context.Context, method, path string, attempt func(context.Context) error) error {
+	reloaded := false
+	for n := 0; ; n++ {
+		usedKey := c.apiKey.get()
+		err := c.attemptWithTimeout(ctx, attempt)
+		if !reloaded && c.apiKey.reload != nil && isUnauthorized(err) {
+			reloaded = true
+			changed, reloadErr := c.apiKey.refresh(usedKey)
+			if reloadErr != nil {
+				tflog.Warn(ctx, "Unable to reload n8n API key", map[string]interface{}{
+					"error": reloadErr.Error(),
+				})
+			}
+			if changed {
+				tflog.Info(ctx, "Retrying n8n API request with reloaded API key", map[string]interface{}{
+					"method": method,
+					"path":   path,
+				})
+				n--
+				continue
+			}
+		}
+		retry, delay := c.retry.classify(method, statusCode(err), err, n)
+		if !retry || ctx.Err() != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, "Retrying n8n API request", map[string]interface{}{
+			"method":  method,
+			"path":    path,
+			"attempt": n + 1,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to wait before retrying request: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attemptWithTimeout calls attempt with a context that ends after the
+// client's per-attempt timeout. An attempt running into the timeout is
+// reported as such rather than as a cancelled context.
+func (c *Client) attemptWithTimeout(ctx context.Context, attempt func(context.Context) error) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	err := attempt(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %w", ErrRequestTimeout, c.timeout, err)
+	}
+
+	return err
+}
+
+// doOnce performs a single attempt of an HTTP request with the given
+// encoded body, which may be nil, and its content type.
+func (c *Client) doOnce(ctx context.Context, method, path string, body []byte, contentType string) (*response, error) {
+	resp, release, err := c.send(ctx, method, path, body, contentType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform request: %w", err)
+		return nil, err
 	}
+	defer release()
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	logResponseBody(ctx, method, path, resp.StatusCode, respBody)
+
+	return &response{body: respBody, header: resp.Header}, nil
+}
+
+// send sends a single HTTP request with the given encoded body, which may be
+// nil, and its content type, and returns the response of a successful
+// request with its body unread. Error responses are read and returned as an
+// *APIError. The returned function must be called to release the request
+// slot once the body has been read and closed.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, contentType string) (*http.Response, func(), error) {
+	if c.dryRun && method != http.MethodGet {
+		tflog.Info(ctx, "Skipping n8n API request in dry-run mode", map[string]interface{}{
+			"method": method,
+			"path":   path,
+		})
+		return nil, nil, fmt.Errorf("%w, %s %s was not sent", ErrDryRun, method, path)
+	}
+
+	url := c.baseURL + c.basePath + path
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	sentMethod := method
+	if c.methodOverride && (method == http.MethodPatch || method == http.MethodDelete) {
+		sentMethod = http.MethodPost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, sentMethod, url, reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Extra headers go first so the managed headers below take precedence
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("X-N8N-API-KEY", c.apiKey.get())
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", c.accept)
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.workspaceID != "" {
+		req.Header.Set(workspaceHeader, c.workspaceID)
+	}
+	if sentMethod != method {
+		req.Header.Set(methodOverrideHeader, method)
+	}
+
+	if err := c.rateLimiter.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	release, err := c.acquireRequestSlot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.circuitBreaker != nil {
+		if err := c.circuitBreaker.allow(); err != nil {
+			release()
+			return nil, nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	duration := time.Since(start)
+	c.warnIfSlow(ctx, method, path, duration)
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	logRequest(ctx, req, path, body, status, duration)
+	if c.circuitBreaker != nil {
+		c.circuitBreaker.record(err != nil || resp.StatusCode >= 500)
+	}
+	if err != nil {
+		release()
+		return nil, nil, fmt.Errorf("failed to perform request: %w", err)
+	}
 
 	if resp.StatusCode >= 400 {
+		defer release()
+		defer resp.Body.Close()
+
+		// The instance's rate limit applies to every request, so the
+		// shared limiter holds all of them back rather than each one
+		// finding out on its own
+		if resp.StatusCode == http.StatusTooManyRequests {
+			c.rateLimiter.pause(retryAfter(resp.Header, time.Now()))
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		logResponseBody(ctx, method, path, resp.StatusCode, respBody)
+
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err != nil {
-			return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+			return nil, nil, &APIError{
+				StatusCode: resp.StatusCode,
+				Body:       string(respBody),
+				RetryAfter: retryAfter(resp.Header, time.Now()),
+				RequestID:  requestID(resp.Header),
+			}
+		}
+		return nil, nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       errResp.Code,
+			Message:    errResp.Message,
+			Hint:       errResp.Hint,
+			RetryAfter: retryAfter(resp.Header, time.Now()),
+			RequestID:  requestID(resp.Header),
 		}
-		return nil, fmt.Errorf("API error: %s - %s", errResp.Code, errResp.Message)
 	}
 
-	return respBody, nil
+	return resp, release, nil
+}
+
+// acquireRequestSlot blocks until the client may send another request, when
+// the number of concurrent requests is limited. The returned function must be
+// called to release the slot once the response has been read.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return func() { <-c.requestSlots }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to wait for a request slot: %w", ctx.Err())
+	}
+}
+
+// warnIfSlow emits a warning log when a request exceeded the configured
+// slow request threshold.
+func (c *Client) warnIfSlow(ctx context.Context, method, path string, duration time.Duration) {
+	if c.slowRequestThreshold <= 0 || duration < c.slowRequestThreshold {
+		return
+	}
+
+	tflog.Warn(ctx, "Slow n8n API request", map[string]interface{}{
+		"method":    method,
+		"path":      path,
+		"duration":  duration.String(),
+		"threshold": c.slowRequestThreshold.String(),
+	})
 }
@@ -0,0 +1,923 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestPathWithQuery(t *testing.T) {
+	testCases := map[string]struct {
+		params url.Values
+		extra  map[string]string
+		want   string
+	}{
+		"none": {
+			want: "/users",
+		},
+		"params only": {
+			params: url.Values{"includeRole": {"true"}},
+			want:   "/users?includeRole=true",
+		},
+		"special characters": {
+			extra: map[string]string{
+				"name":     "a b&c=d",
+				"filter/x": "ü+%",
+			},
+			want: "/users?filter%2Fx=%C3%BC%2B%25&name=a+b%26c%3Dd",
+		},
+		"params take precedence": {
+			params: url.Values{"includeRole": {"true"}},
+			extra:  map[string]string{"includeRole": "false", "projectId": "p1"},
+			want:   "/users?includeRole=true&projectId=p1",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := pathWithQuery("/users", testCase.params, testCase.extra)
+			if got != testCase.want {
+				t.Errorf("expected %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_basePath(t *testing.T) {
+	testCases := map[string]struct {
+		basePath string
+		want     string
+	}{
+		"default":        {basePath: "", want: "/api/v1/users"},
+		"public api":     {basePath: "/api/v1", want: "/api/v1/users"},
+		"rest":           {basePath: "/rest", want: "/rest/users"},
+		"missing slash":  {basePath: "rest/", want: "/rest/users"},
+		"gateway prefix": {basePath: "/n8n/api/v1/", want: "/n8n/api/v1/users"},
+		"root":           {basePath: "/", want: "/users"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			c := newTestClientWithConfig(t, &Config{BasePath: testCase.basePath}, func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Path
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected request path %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_baseURLPathPrefix(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Path
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// An instance behind a path-prefixed reverse proxy, with a trailing slash
+	c, err := NewClient(&Config{BaseURL: server.URL + "/n8n/", APIKey: "test-api-key"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if want := "/n8n/api/v1/users"; got != want {
+		t.Errorf("expected request path %q, got %q", want, got)
+	}
+}
+
+func TestDoRequest_baseURLTrailingSlash(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.RequestURI)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	for _, baseURL := range []string{server.URL, server.URL + "/", server.URL + "//"} {
+		c, err := NewClient(&Config{BaseURL: baseURL, APIKey: "test-api-key"})
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %s", err)
+		}
+
+		if _, err := c.doRequest(context.Background(), http.MethodGet, "/users?limit=1", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	for _, requestURI := range got {
+		if want := "/api/v1/users?limit=1"; requestURI != want {
+			t.Errorf("expected request URI %q for every base URL, got %q", want, requestURI)
+		}
+	}
+}
+
+func TestDoRequest_acceptHeader(t *testing.T) {
+	testCases := map[string]struct {
+		accept string
+		want   string
+	}{
+		"default": {
+			want: "application/json",
+		},
+		"override": {
+			accept: "application/vnd.n8n.v2+json",
+			want:   "application/vnd.n8n.v2+json",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Accept")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(&Config{
+				BaseURL: server.URL,
+				APIKey:  "test-api-key",
+				Accept:  testCase.accept,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected Accept %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_requestID(t *testing.T) {
+	testCases := map[string]struct {
+		header http.Header
+		body   string
+		want   string
+	}{
+		"request ID": {
+			header: http.Header{"X-Request-Id": {"req-1"}, "Cf-Ray": {"ray-1"}},
+			body:   `{"code":"400","message":"Bad request"}`,
+			want:   "req-1",
+		},
+		"ray ID with raw body": {
+			header: http.Header{"Cf-Ray": {"ray-1"}},
+			body:   "Bad Request",
+			want:   "ray-1",
+		},
+		"none": {
+			body: `{"message":"Bad request"}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for key, values := range testCase.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(testCase.body))
+			})
+
+			_, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an APIError, got %v", err)
+			}
+			if apiErr.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", apiErr.StatusCode)
+			}
+			if apiErr.RequestID != testCase.want {
+				t.Errorf("expected request ID %q, got %q", testCase.want, apiErr.RequestID)
+			}
+		})
+	}
+}
+
+func TestDoRequest_userAgent(t *testing.T) {
+	testCases := map[string]struct {
+		version string
+		want    string
+	}{
+		"unset": {
+			want: "terraform-provider-n8ncloud (terraform-plugin-framework)",
+		},
+		"release": {
+			version: "1.2.0",
+			want:    "terraform-provider-n8ncloud/1.2.0 (terraform-plugin-framework)",
+		},
+		"dev": {
+			version: "dev",
+			want:    "terraform-provider-n8ncloud/dev (terraform-plugin-framework)",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			c := newTestClientWithConfig(t, &Config{Version: testCase.version}, func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected User-Agent %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_disableCompression(t *testing.T) {
+	testCases := map[string]struct {
+		disableCompression bool
+		want               string
+	}{
+		"default": {
+			want: "gzip",
+		},
+		"disabled": {
+			disableCompression: true,
+			want:               "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Accept-Encoding")
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(&Config{
+				BaseURL:            server.URL,
+				APIKey:             "test-api-key",
+				DisableCompression: testCase.disableCompression,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected Accept-Encoding %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_maxConcurrentRequests(t *testing.T) {
+	const limit = 2
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{
+		BaseURL:               server.URL,
+		APIKey:                "test-api-key",
+		MaxConcurrentRequests: limit,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if err := c.DeleteUser(context.Background(), fmt.Sprintf("user-%d", id)); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if maxInFlight > limit {
+		t.Errorf("expected at most %d concurrent requests, got %d", limit, maxInFlight)
+	}
+}
+
+func TestDoRequest_maxConcurrentRequestsContextCanceled(t *testing.T) {
+	c, err := NewClient(&Config{
+		BaseURL:               "http://127.0.0.1:0",
+		APIKey:                "test-api-key",
+		MaxConcurrentRequests: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Occupy the only slot so the next request has to wait.
+	c.requestSlots <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.doRequest(ctx, http.MethodGet, "/users", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got: %v", err)
+	}
+}
+
+func TestDoRequest_workspaceID(t *testing.T) {
+	testCases := map[string]struct {
+		workspaceID string
+		want        []string
+	}{
+		"unset": {},
+		"set": {
+			workspaceID: "workspace-1",
+			want:        []string{"workspace-1"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			c := newTestClientWithConfig(t, &Config{WorkspaceID: testCase.workspaceID}, func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Values(workspaceHeader)
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(got) != len(testCase.want) || (len(got) > 0 && got[0] != testCase.want[0]) {
+				t.Errorf("expected %s header %q, got %q", workspaceHeader, testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestDoRequest_methodOverride(t *testing.T) {
+	testCases := map[string]struct {
+		method         string
+		methodOverride bool
+		wantMethod     string
+		wantOverride   string
+	}{
+		"disabled": {
+			method:     http.MethodDelete,
+			wantMethod: http.MethodDelete,
+		},
+		"patch": {
+			method:         http.MethodPatch,
+			methodOverride: true,
+			wantMethod:     http.MethodPost,
+			wantOverride:   http.MethodPatch,
+		},
+		"delete": {
+			method:         http.MethodDelete,
+			methodOverride: true,
+			wantMethod:     http.MethodPost,
+			wantOverride:   http.MethodDelete,
+		},
+		"get": {
+			method:         http.MethodGet,
+			methodOverride: true,
+			wantMethod:     http.MethodGet,
+		},
+		"post": {
+			method:         http.MethodPost,
+			methodOverride: true,
+			wantMethod:     http.MethodPost,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var gotMethod, gotOverride string
+			c := newTestClientWithConfig(t, &Config{MethodOverride: testCase.methodOverride}, func(w http.ResponseWriter, r *http.Request) {
+				gotMethod = r.Method
+				gotOverride = r.Header.Get(methodOverrideHeader)
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			if _, err := c.doRequest(context.Background(), testCase.method, "/users/1", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if gotMethod != testCase.wantMethod {
+				t.Errorf("expected method %s, got %s", testCase.wantMethod, gotMethod)
+			}
+			if gotOverride != testCase.wantOverride {
+				t.Errorf("expected %s header %q, got %q", methodOverrideHeader, testCase.wantOverride, gotOverride)
+			}
+		})
+	}
+}
+
+func TestDoRequest_dryRun(t *testing.T) {
+	var sent []string
+	c := newTestClientWithConfig(t, &Config{DryRun: true}, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method)
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+	ctx := context.Background()
+
+	if _, err := c.ListUsers(ctx, nil); err != nil {
+		t.Fatalf("expected reads to be sent in dry-run mode, got: %s", err)
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		if _, err := c.doRequest(ctx, method, "/users/1", map[string]string{"role": "global:admin"}); !errors.Is(err, ErrDryRun) {
+			t.Errorf("expected ErrDryRun for %s, got: %v", method, err)
+		}
+	}
+	if _, err := c.CreateUser(ctx, &CreateUserRequest{Email: "ada@example.com"}); !errors.Is(err, ErrDryRun) {
+		t.Errorf("expected ErrDryRun creating a user, got: %v", err)
+	}
+
+	if len(sent) != 1 || sent[0] != http.MethodGet {
+		t.Errorf("expected only the GET request to reach the server, got %v", sent)
+	}
+}
+
+func TestDoRequestRaw(t *testing.T) {
+	var attempts int
+	var gotContentType, gotBody string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		gotContentType = r.Header.Get("Content-Type")
+		gotBody = string(body)
+
+		// Fail the first attempt to check the body is resent on retry
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+	c.retry.baseDelay = time.Millisecond
+
+	body := "--boundary\r\nContent-Disposition: form-data; name=\"file\"\r\n\r\nsecret\r\n--boundary--"
+	respBody, err := c.doRequestRaw(context.Background(), http.MethodPut, "/credentials/1", "multipart/form-data; boundary=boundary", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("expected the request to be retried once, got %d attempts", attempts)
+	}
+	if gotContentType != "multipart/form-data; boundary=boundary" {
+		t.Errorf("expected the given content type, got %q", gotContentType)
+	}
+	if gotBody != body {
+		t.Errorf("expected the body to be sent as-is, got %q", gotBody)
+	}
+	if string(respBody) != `{"id":"1"}` {
+		t.Errorf("unexpected response body %q", respBody)
+	}
+}
+
+func TestDoRequest_jsonContentType(t *testing.T) {
+	var gotContentType string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	if _, err := c.doRequest(context.Background(), http.MethodPost, "/tags", map[string]string{"name": "prod"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if gotContentType != "application/json" {
+		t.Errorf("expected JSON requests to keep the application/json content type, got %q", gotContentType)
+	}
+}
+
+func TestListUsers_pageSize(t *testing.T) {
+	testCases := map[string]struct {
+		pageSize     int
+		callPageSize int
+		want         string
+	}{
+		"default": {
+			want: "250",
+		},
+		"configured": {
+			pageSize: 50,
+			want:     "50",
+		},
+		"clamped": {
+			pageSize: 1000,
+			want:     "250",
+		},
+		"per call": {
+			pageSize:     50,
+			callPageSize: 10,
+			want:         "10",
+		},
+		"per call clamped": {
+			callPageSize: 1000,
+			want:         "250",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			c := newTestClientWithConfig(t, &Config{PageSize: testCase.pageSize}, func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.URL.Query().Get("limit"))
+
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Query().Get("cursor") == "" {
+					_, _ = w.Write([]byte(`{"data":[{"id":"u1"}],"nextCursor":"next"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":"u2"}],"nextCursor":null}`))
+			})
+
+			users, err := c.ListUsers(context.Background(), &ListOptions{PageSize: testCase.callPageSize})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(users) != 2 {
+				t.Errorf("expected users from both pages, got %d", len(users))
+			}
+			if len(got) != 2 || got[0] != testCase.want || got[1] != testCase.want {
+				t.Errorf("expected limit %s on every page, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	var got []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-N8N-API-KEY"))
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	scoped := c.WithAPIKey("scoped-api-key")
+
+	for _, client := range []*Client{scoped, c} {
+		if _, err := client.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if len(got) != 2 || got[0] != "scoped-api-key" || got[1] != "test-api-key" {
+		t.Errorf("expected the override to apply only to the scoped client, got %q", got)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var got []http.Header
+	c := newTestClientWithConfig(t, &Config{Headers: map[string]string{
+		"X-Route":       "provider",
+		"X-Tenant":      "acme",
+		"x-n8n-api-key": "injected",
+	}}, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	scoped := c.WithHeaders(map[string]string{
+		"x-route": "resource",
+		"Accept":  "text/plain",
+	})
+
+	for _, client := range []*Client{scoped, c} {
+		if _, err := client.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	testCases := map[string]struct {
+		header http.Header
+		name   string
+		want   string
+	}{
+		"resource header overrides provider header": {header: got[0], name: "X-Route", want: "resource"},
+		"provider header is kept":                   {header: got[0], name: "X-Tenant", want: "acme"},
+		"managed Accept header wins":                {header: got[0], name: "Accept", want: defaultAccept},
+		"managed API key header wins":               {header: got[0], name: "X-N8N-API-KEY", want: "test-api-key"},
+		"parent client is unchanged":                {header: got[1], name: "X-Route", want: "provider"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if values := testCase.header.Values(testCase.name); len(values) != 1 || values[0] != testCase.want {
+				t.Errorf("expected %s header %q, got %q", testCase.name, testCase.want, values)
+			}
+		})
+	}
+}
+
+func TestDoRequest_reloadAPIKey(t *testing.T) {
+	testCases := map[string]struct {
+		reloadedKey  string
+		wantErr      bool
+		wantRequests []string
+	}{
+		"rotated": {
+			reloadedKey:  "rotated-api-key",
+			wantRequests: []string{"test-api-key", "rotated-api-key"},
+		},
+		"unchanged": {
+			reloadedKey:  "test-api-key",
+			wantErr:      true,
+			wantRequests: []string{"test-api-key"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			reloads := 0
+			c := newTestClientWithConfig(t, &Config{
+				ReloadAPIKey: func() (string, error) {
+					reloads++
+					return tc.reloadedKey, nil
+				},
+			}, func(w http.ResponseWriter, r *http.Request) {
+				key := r.Header.Get("X-N8N-API-KEY")
+				got = append(got, key)
+				if key != "rotated-api-key" {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			})
+
+			_, err := c.doRequest(context.Background(), http.MethodPost, "/users", nil)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error %t, got: %v", tc.wantErr, err)
+			}
+
+			if reloads != 1 {
+				t.Errorf("expected the key to be reloaded once, got %d reloads", reloads)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.wantRequests) {
+				t.Errorf("expected requests with keys %q, got %q", tc.wantRequests, got)
+			}
+
+			// The reloaded key is used for later requests without reloading.
+			if !tc.wantErr {
+				if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if reloads != 1 {
+					t.Errorf("expected no further reloads, got %d reloads", reloads)
+				}
+			}
+		})
+	}
+}
+
+func TestDoRequest_deadline(t *testing.T) {
+	released := make(chan struct{})
+	var requests atomic.Int32
+	c := newTestClientWithConfig(t, &Config{Deadline: time.Now().Add(100 * time.Millisecond)}, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Hang until the client gives up on the request.
+		<-r.Context().Done()
+		close(released)
+	})
+
+	start := time.Now()
+	_, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil)
+	if !errors.Is(err, ErrDeadlineExceeded) {
+		t.Fatalf("expected ErrDeadlineExceeded for the in-flight request, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the deadline to abort the request, it took %s", elapsed)
+	}
+
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the aborted request to be canceled on the server")
+	}
+
+	// Once the deadline has passed, requests are not sent at all.
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); !errors.Is(err, ErrDeadlineExceeded) {
+		t.Fatalf("expected ErrDeadlineExceeded after the deadline, got: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected requests after the deadline to fail fast, server saw %d requests", got)
+	}
+}
+
+func TestDoRequest_timeout(t *testing.T) {
+	c := newTestClientWithConfig(t, &Config{Timeout: 50 * time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the client gives up on the request.
+		<-r.Context().Done()
+	})
+
+	_, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil)
+	if !errors.Is(err, ErrRequestTimeout) {
+		t.Fatalf("expected ErrRequestTimeout, got: %v", err)
+	}
+}
+
+func TestDoRequest_slowRequestWarning(t *testing.T) {
+	testCases := map[string]struct {
+		threshold time.Duration
+		delay     time.Duration
+		wantWarn  bool
+	}{
+		"slow request":      {threshold: 20 * time.Millisecond, delay: 50 * time.Millisecond, wantWarn: true},
+		"fast request":      {threshold: time.Minute},
+		"warning disabled":  {threshold: -1, delay: 50 * time.Millisecond},
+		"default threshold": {delay: 50 * time.Millisecond},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClientWithConfig(t, &Config{SlowRequestThreshold: tc.threshold}, func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tc.delay)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+			})
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			if _, err := c.doRequest(ctx, http.MethodGet, "/users", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatalf("unexpected error decoding logs: %s", err)
+			}
+
+			var warnings []map[string]interface{}
+			for _, entry := range entries {
+				if entry["@message"] == "Slow n8n API request" {
+					warnings = append(warnings, entry)
+				}
+			}
+
+			if !tc.wantWarn {
+				if len(warnings) != 0 {
+					t.Errorf("expected no slow request warning, got: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 {
+				t.Fatalf("expected one slow request warning, got: %v", entries)
+			}
+			warning := warnings[0]
+			if warning["@level"] != "warn" || warning["method"] != http.MethodGet || warning["path"] != "/users" {
+				t.Errorf("expected a warning with the method and path, got: %v", warning)
+			}
+			if warning["threshold"] != tc.threshold.String() {
+				t.Errorf("expected the threshold %s, got: %v", tc.threshold, warning["threshold"])
+			}
+			if _, ok := warning["duration"].(string); !ok {
+				t.Errorf("expected the duration of the request, got: %v", warning["duration"])
+			}
+		})
+	}
+}
+
+func TestDoRequest_contextCanceledInFlight(t *testing.T) {
+	started := make(chan struct{})
+	released := make(chan struct{})
+	c := newTestClientWithConfig(t, &Config{Timeout: time.Minute}, func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		// Hang until the client gives up on the request.
+		<-r.Context().Done()
+		close(released)
+	})
+
+	// Cancel the context once the request is in flight, as the framework
+	// does when Terraform is interrupted
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := c.doRequest(ctx, http.MethodGet, "/users", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected cancellation to abort the request, it took %s", elapsed)
+	}
+
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the aborted request to be canceled on the server")
+	}
+}
+
+// TestClient_concurrentUse exercises the shared state of a client from many
+// goroutines, the way resources use it during apply. Run it with -race.
+func TestClient_concurrentUse(t *testing.T) {
+	c := newTestClientWithConfig(t, &Config{MaxConcurrentRequests: 4, CircuitBreakerThreshold: 100}, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/api/v1/users/1":
+			_, _ = w.Write([]byte(`{"id":"1","email":"ada@example.com"}`))
+		case r.URL.Query().Get("cursor") == "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"}],"nextCursor":"page-2"}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[{"id":"2"}],"nextCursor":null}`))
+		}
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 60)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, err := c.ListUsers(ctx, nil)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c.ListTags(ctx, nil)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := c.WithAPIKey("override-key").GetUser(ctx, "1")
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetCredentialSchema retrieves the JSON schema of the data of a credential
+// type, such as "githubApi".
+func (c *Client) GetCredentialSchema(ctx context.Context, credentialType string) (json.RawMessage, error) {
+	path := fmt.Sprintf("/credentials/schema/%s", credentialType)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(body), nil
+}
+
+// CreateCredential creates a new credential.
+func (c *Client) CreateCredential(ctx context.Context, req *CreateCredentialRequest) (*Credential, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, "/credentials", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var credential Credential
+	if err := json.Unmarshal(body, &credential); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal create credential response: %w", err)
+	}
+
+	return &credential, nil
+}
+
+// DeleteCredential deletes a credential.
+func (c *Client) DeleteCredential(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/credentials/%s", id)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
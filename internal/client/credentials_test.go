@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateCredential(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/credentials" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"GitHub","type":"githubApi","data":{"accessToken":"secret"}}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"c1","name":"GitHub","type":"githubApi","createdAt":"2024-01-01T00:00:00.000Z","updatedAt":"2024-01-01T00:00:00.000Z"}`))
+	})
+
+	credential, err := c.CreateCredential(context.Background(), &CreateCredentialRequest{
+		Name: "GitHub",
+		Type: "githubApi",
+		Data: map[string]interface{}{"accessToken": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if credential.ID != "c1" {
+		t.Errorf("expected credential c1, got %s", credential.ID)
+	}
+}
+
+func TestDeleteCredential(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/v1/credentials/c1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"c1","name":"GitHub","type":"githubApi"}`))
+	})
+
+	if err := c.DeleteCredential(context.Background(), "c1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
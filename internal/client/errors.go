@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// APIError is returned when the n8n API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Hint       string
+
+	// Body holds the raw response body when it could not be decoded as an
+	// ErrorResponse.
+	Body string
+
+	// RetryAfter is the delay the server asked for in a Retry-After header,
+	// or zero if it sent none.
+	RetryAfter time.Duration
+
+	// RequestID identifies the request to n8n support, taken from the first
+	// of requestIDHeaders in the response, or empty if it had none.
+	RequestID string
+}
+
+// requestIDHeaders are the response headers identifying a request, in order of
+// preference. n8n Cloud is served through Cloudflare, whose ray ID support
+// can also look up.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Cf-Ray"}
+
+// requestID returns the ID of the request that header was sent for, or an
+// empty string if it has none.
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func (e *APIError) Error() string {
+	var message string
+	switch {
+	case e.Body != "":
+		message = fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+	case e.Hint != "":
+		message = fmt.Sprintf("API error: %s - %s (hint: %s)", e.Code, e.Message, e.Hint)
+	default:
+		message = fmt.Sprintf("API error: %s - %s", e.Code, e.Message)
+	}
+
+	if e.RequestID != "" {
+		message += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+
+	return message
+}
+
+// NotFoundError is returned when the requested resource does not exist.
+type NotFoundError struct {
+	// Resource describes what was looked up, e.g. `user with email "a@b.c"`.
+	Resource string
+
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Resource)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is or wraps a NotFoundError, or is an
+// APIError for a 404 response.
+func IsNotFound(err error) bool {
+	var notFoundErr *NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return true
+	}
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ValidationError is returned without sending a request when an argument is
+// rejected by the client, e.g. a role the API does not accept.
+type ValidationError struct {
+	// Field names the invalid argument, e.g. "role".
+	Field string
+	// Value is the rejected value.
+	Value string
+	// Message says which values are accepted.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Field, e.Value, e.Message)
+}
+
+// IsValidationError reports whether err is or wraps a ValidationError.
+func IsValidationError(err error) bool {
+	var validationErr *ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// ssoManagedMessages are fragments of the messages n8n returns when users are
+// provisioned by an identity provider and cannot be invited through the API.
+var ssoManagedMessages = []string{
+	"managed by the identity provider",
+	"saml is enabled",
+	"ldap is enabled",
+}
+
+// IsSSOManagedError reports whether err indicates that users on the instance
+// are managed by an SSO identity provider and cannot be created via the API.
+func IsSSOManagedError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	message := strings.ToLower(apiErr.Message + " " + apiErr.Body)
+	for _, fragment := range ssoManagedMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFeatureUnavailableError reports whether err indicates that the instance
+// does not offer the requested feature, because its license does not
+// include it or its version does not have the endpoint.
+func IsFeatureUnavailableError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return true
+	case http.StatusForbidden:
+		return strings.Contains(strings.ToLower(apiErrorText(apiErr)), "license")
+	}
+
+	return false
+}
+
+// sourceControlUnavailableMessages are fragments of the messages n8n returns
+// when source control is not licensed, not enabled or not connected to a
+// repository.
+var sourceControlUnavailableMessages = []string{
+	"not licensed",
+	"not enabled",
+	"not connected",
+	"not configured",
+	"not set up",
+}
+
+// IsSourceControlUnavailableError reports whether err indicates that the
+// instance cannot pull from source control because the feature is not
+// licensed or no repository is connected. The endpoint is missing entirely
+// on instances without source control support.
+func IsSourceControlUnavailableError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return true
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+	default:
+		return false
+	}
+
+	message := strings.ToLower(apiErrorText(apiErr))
+	if strings.Contains(message, "license") {
+		return true
+	}
+	for _, fragment := range sourceControlUnavailableMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userExistsMessages are fragments of the messages n8n returns when a user
+// with the requested email is already registered or invited.
+var userExistsMessages = []string{
+	"already exists",
+	"already registered",
+	"already invited",
+	"already a member",
+}
+
+// IsUserExistsError reports whether err indicates that a user could not be
+// created because a user with the same email already exists.
+func IsUserExistsError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusConflict {
+		return false
+	}
+
+	message := strings.ToLower(apiErrorText(apiErr))
+	for _, fragment := range userExistsMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsConflictError reports whether err is an APIError for a 409 response,
+// which n8n returns when a tag or another uniquely named object with the
+// requested name already exists.
+func IsConflictError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsLastProjectAdminError reports whether err indicates that a project
+// membership change was rejected because it would leave the project without
+// an admin.
+func IsLastProjectAdminError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusForbidden && apiErr.StatusCode != http.StatusConflict {
+		return false
+	}
+
+	message := strings.ToLower(apiErrorText(apiErr))
+	return strings.Contains(message, "admin") && (strings.Contains(message, "last") || strings.Contains(message, "at least one"))
+}
+
+// alreadyInProjectMessages are fragments of the messages n8n returns when
+// an object is transferred to the project that already owns it.
+var alreadyInProjectMessages = []string{
+	"already owning",
+	"already owns",
+	"already in",
+	"already belongs",
+}
+
+// IsAlreadyInProjectError reports whether err indicates that a transfer was
+// rejected because the object is already owned by the destination project.
+func IsAlreadyInProjectError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusConflict {
+		return false
+	}
+
+	message := strings.ToLower(apiErrorText(apiErr))
+	for _, fragment := range alreadyInProjectMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scopePattern matches API key scope names such as "workflow:create".
+var scopePattern = regexp.MustCompile(`\b[a-z][a-zA-Z]*:[a-zA-Z]+\b`)
+
+// IsInsufficientScopeError reports whether err indicates that the API key
+// is valid but lacks a scope the request needs, as opposed to being wrong.
+func IsInsufficientScopeError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(apiErrorText(apiErr)), "scope")
+}
+
+// RequiredScope returns the scope named by an insufficient scope error, or an
+// empty string if err is not one or the API did not name the scope.
+func RequiredScope(err error) string {
+	if !IsInsufficientScopeError(err) {
+		return ""
+	}
+
+	var apiErr *APIError
+	errors.As(err, &apiErr)
+
+	return scopePattern.FindString(apiErrorText(apiErr))
+}
+
+// apiErrorText returns the human-readable parts of an API error.
+func apiErrorText(apiErr *APIError) string {
+	return apiErr.Message + " " + apiErr.Hint + " " + apiErr.Body
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestIsInsufficientScopeError(t *testing.T) {
+	testCases := map[string]struct {
+		status    int
+		body      string
+		wantScope bool
+		want      string
+	}{
+		"named scope": {
+			status:    http.StatusForbidden,
+			body:      `{"message":"API key is missing the required scope user:create"}`,
+			wantScope: true,
+			want:      "user:create",
+		},
+		"scope in hint": {
+			status:    http.StatusForbidden,
+			body:      `{"message":"Forbidden","hint":"Add the workflow:activate scope to the API key"}`,
+			wantScope: true,
+			want:      "workflow:activate",
+		},
+		"unnamed scope": {
+			status:    http.StatusForbidden,
+			body:      `{"message":"Insufficient scope"}`,
+			wantScope: true,
+		},
+		"plain text": {
+			status:    http.StatusForbidden,
+			body:      `missing scope variable:list`,
+			wantScope: true,
+			want:      "variable:list",
+		},
+		"forbidden": {
+			status: http.StatusForbidden,
+			body:   `{"message":"Forbidden"}`,
+		},
+		"unauthorized": {
+			status: http.StatusUnauthorized,
+			body:   `{"message":"invalid scope user:create"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.doRequest(context.Background(), http.MethodPost, "/users", nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsInsufficientScopeError(err); got != tc.wantScope {
+				t.Errorf("expected IsInsufficientScopeError %t, got %t for: %s", tc.wantScope, got, err)
+			}
+			if got := RequiredScope(err); got != tc.want {
+				t.Errorf("expected required scope %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsInsufficientScopeError_otherErrors(t *testing.T) {
+	if IsInsufficientScopeError(errors.New("missing scope user:create")) {
+		t.Error("expected errors other than API errors not to be scope errors")
+	}
+}
+
+func TestIsUserExistsError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"conflict":          {status: http.StatusConflict, body: `{"message":"User with email user@example.com already exists"}`, want: true},
+		"bad request":       {status: http.StatusBadRequest, body: `{"message":"The email user@example.com is already registered"}`, want: true},
+		"plain text":        {status: http.StatusConflict, body: `user already invited`, want: true},
+		"other bad request": {status: http.StatusBadRequest, body: `{"message":"email is invalid"}`},
+		"other status":      {status: http.StatusForbidden, body: `{"message":"already exists"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.CreateUser(context.Background(), &CreateUserRequest{Email: "user@example.com"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsUserExistsError(err); got != tc.want {
+				t.Errorf("expected IsUserExistsError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestIsFeatureUnavailableError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"unlicensed":     {status: http.StatusForbidden, body: `{"message":"Your license does not allow for feat:projectRole:admin"}`, want: true},
+		"no endpoint":    {status: http.StatusNotFound, body: `{"message":"not found"}`, want: true},
+		"missing scope":  {status: http.StatusForbidden, body: `{"message":"missing scope project:list"}`},
+		"unauthorized":   {status: http.StatusUnauthorized, body: `{"message":"unauthorized"}`},
+		"server failure": {status: http.StatusInternalServerError, body: `{"message":"license check failed"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.ListProjects(context.Background(), nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsFeatureUnavailableError(err); got != tc.want {
+				t.Errorf("expected IsFeatureUnavailableError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestIsConflictError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"conflict":    {status: http.StatusConflict, body: `{"message":"Tag already exists"}`, want: true},
+		"bad request": {status: http.StatusBadRequest, body: `{"message":"request/body must have required property 'name'"}`},
+		"not found":   {status: http.StatusNotFound, body: `{"message":"Not Found"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.CreateTag(context.Background(), "production")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsConflictError(err); got != tc.want {
+				t.Errorf("expected IsConflictError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestIsLastProjectAdminError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"last admin":    {status: http.StatusBadRequest, body: `{"message":"Cannot remove the last admin of the project","hint":"Make another member an admin first"}`, want: true},
+		"at least one":  {status: http.StatusForbidden, body: `{"message":"A project needs at least one admin"}`, want: true},
+		"other message": {status: http.StatusBadRequest, body: `{"message":"role is invalid"}`},
+		"not found":     {status: http.StatusNotFound, body: `{"message":"last admin not found"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			err := c.RemoveProjectUser(context.Background(), "p1", "u1")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsLastProjectAdminError(err); got != tc.want {
+				t.Errorf("expected IsLastProjectAdminError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestAPIErrorError(t *testing.T) {
+	testCases := map[string]struct {
+		err  *APIError
+		want string
+	}{
+		"message":    {err: &APIError{StatusCode: http.StatusBadRequest, Code: "400", Message: "Bad request"}, want: "API error: 400 - Bad request"},
+		"hint":       {err: &APIError{StatusCode: http.StatusBadRequest, Code: "400", Message: "Email already exists", Hint: "Import the user instead"}, want: "API error: 400 - Email already exists (hint: Import the user instead)"},
+		"raw body":   {err: &APIError{StatusCode: http.StatusBadGateway, Body: "Bad Gateway"}, want: "HTTP 502: Bad Gateway"},
+		"request ID": {err: &APIError{StatusCode: http.StatusInternalServerError, Code: "500", Message: "Internal error", RequestID: "req-1"}, want: "API error: 500 - Internal error (request ID: req-1)"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ID is an identifier the API returns as a string or, on older versions and
+// for some resources such as executions, as a number.
+type ID string
+
+// UnmarshalJSON implements json.Unmarshaler, accepting strings, numbers and
+// null, which leaves the ID empty.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*id = ""
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '"' {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid ID %s: %w", data, err)
+		}
+		*id = ID(number.String())
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid ID %s: %w", data, err)
+	}
+	*id = ID(value)
+
+	return nil
+}
+
+// maxExecutionDataPageSize is the page size used when listing executions
+// with their data, which can be megabytes per execution.
+const maxExecutionDataPageSize = 10
+
+// ListExecutions retrieves the executions matching opts, newest first,
+// following the pagination cursor until every page has been read or
+// opts.Limit executions have been read. Execution data is only included
+// when opts.IncludeData is set.
+func (c *Client) ListExecutions(ctx context.Context, opts *ListExecutionsOptions) ([]Execution, error) {
+	if opts == nil {
+		opts = &ListExecutionsOptions{}
+	}
+
+	listOpts := opts.ListOptions
+	if listOpts.PageSize <= 0 {
+		listOpts.PageSize = c.pageSize
+	}
+	if opts.IncludeData && !opts.SummaryOnly {
+		listOpts.PageSize = min(listOpts.PageSize, maxExecutionDataPageSize)
+	}
+	if opts.Limit > 0 {
+		// Do not ask for more executions than are needed
+		listOpts.PageSize = min(listOpts.PageSize, opts.Limit)
+	}
+
+	executions, err := listUpTo[Execution](ctx, c, opts.Limit, listOpts.MaxPageBytes, func(cursor string) string {
+		params := c.listParams(cursor, &listOpts)
+		setExecutionFilters(params, opts)
+
+		return pathWithQuery("/executions", params, opts.ExtraQuery)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.SummaryOnly {
+		for i := range executions {
+			executions[i].Data = nil
+		}
+	}
+
+	return executions, nil
+}
+
+// GetLatestExecution retrieves the most recent execution of a workflow,
+// reading a single page of one execution. It returns a *NotFoundError if the
+// workflow has never run.
+func (c *Client) GetLatestExecution(ctx context.Context, workflowID string) (*Execution, error) {
+	params := url.Values{}
+	params.Set("limit", "1")
+	setExecutionFilters(params, &ListExecutionsOptions{WorkflowID: workflowID})
+
+	var executions []Execution
+	if _, err := listPage(ctx, c, pathWithQuery("/executions", params, nil), 0, &executions); err != nil {
+		return nil, err
+	}
+
+	latest := latestExecution(executions)
+	if latest == nil {
+		return nil, &NotFoundError{Resource: fmt.Sprintf("execution of workflow %q", workflowID)}
+	}
+
+	return latest, nil
+}
+
+// latestExecution returns the execution that started last, or nil if there
+// are none. The API lists executions newest first, but the page is not
+// trusted to be sorted or limited to one item.
+func latestExecution(executions []Execution) *Execution {
+	var latest *Execution
+	for i := range executions {
+		if latest == nil || executions[i].StartedAt.After(latest.StartedAt.Time) {
+			latest = &executions[i]
+		}
+	}
+
+	return latest
+}
+
+// setExecutionFilters sets the query parameters for the filters in opts.
+func setExecutionFilters(params url.Values, opts *ListExecutionsOptions) {
+	if opts.WorkflowID != "" {
+		params.Set("workflowId", opts.WorkflowID)
+	}
+	if opts.Status != "" {
+		params.Set("status", opts.Status)
+	}
+	if opts.ProjectID != "" {
+		params.Set("projectId", opts.ProjectID)
+	}
+	switch {
+	case opts.SummaryOnly:
+		// Overrides an includeData parameter in the extra query
+		params.Set("includeData", "false")
+	case opts.IncludeData:
+		params.Set("includeData", "true")
+	}
+}
+
+// executionData is the part of the execution data ExecutionErrorDetails
+// reads.
+type executionData struct {
+	ResultData struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		LastNodeExecuted string `json:"lastNodeExecuted"`
+	} `json:"resultData"`
+}
+
+// ExecutionErrorDetails returns the error message of a failed execution and
+// the name of the node it failed in, read from the execution data. Both are
+// empty if the execution did not fail, its data was not included, or the
+// data does not have the expected shape, which differs between versions.
+func ExecutionErrorDetails(e *Execution) (message, lastNode string) {
+	if ExecutionStatus(e) != "error" || len(e.Data) == 0 {
+		return "", ""
+	}
+
+	var data executionData
+	if err := json.Unmarshal(e.Data, &data); err != nil {
+		return "", ""
+	}
+
+	if data.ResultData.Error != nil {
+		message = data.ResultData.Error.Message
+	}
+
+	return message, data.ResultData.LastNodeExecuted
+}
+
+// ExecutionStatus returns the status of e: the status reported by the API,
+// or on versions that do not report it, one derived from the other fields:
+// success, waiting, running or error.
+func ExecutionStatus(e *Execution) string {
+	switch {
+	case e.Status != "":
+		return e.Status
+	case e.Finished:
+		return "success"
+	case !e.WaitTill.IsZero():
+		return "waiting"
+	case e.StoppedAt.IsZero():
+		return "running"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  ID
+	}{
+		"string": {input: `"1000"`, want: "1000"},
+		"number": {input: `1000`, want: "1000"},
+		"null":   {input: `null`, want: ""},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got ID
+			if err := json.Unmarshal([]byte(tc.input), &got); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetLatestExecution(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		// Several executions out of order, as an instance ignoring the
+		// limit or sorting differently would return them.
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":1000,"workflowId":"wf1","finished":true,"mode":"trigger","startedAt":"2024-01-01T10:00:00Z","stoppedAt":"2024-01-01T10:00:05Z"},
+			{"id":1002,"workflowId":"wf1","finished":false,"mode":"webhook","status":"error","startedAt":"2024-01-03T10:00:00Z","stoppedAt":"2024-01-03T10:00:01Z"},
+			{"id":1001,"workflowId":"wf1","finished":true,"mode":"manual","startedAt":"2024-01-02T10:00:00Z","stoppedAt":"2024-01-02T10:00:02Z"}
+		],"nextCursor":"next"}`))
+	})
+
+	execution, err := c.GetLatestExecution(context.Background(), "wf1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if query != "limit=1&workflowId=wf1" {
+		t.Errorf("expected a single execution of the workflow to be requested, got query %q", query)
+	}
+	if execution.ID != "1002" || execution.Mode != "webhook" {
+		t.Errorf("expected the execution started last, got %+v", execution)
+	}
+	if want := time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC); !execution.StartedAt.Equal(want) {
+		t.Errorf("expected started at %s, got %s", want, execution.StartedAt)
+	}
+}
+
+func TestGetLatestExecution_neverRun(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	if _, err := c.GetLatestExecution(context.Background(), "wf1"); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestExecutionStatus(t *testing.T) {
+	stopped := Time{Time: time.Date(2024, 1, 1, 0, 0, 5, 0, time.UTC)}
+
+	testCases := map[string]struct {
+		execution Execution
+		want      string
+	}{
+		"reported": {execution: Execution{Status: "canceled", StoppedAt: stopped}, want: "canceled"},
+		"finished": {execution: Execution{Finished: true, StoppedAt: stopped}, want: "success"},
+		"waiting":  {execution: Execution{WaitTill: stopped}, want: "waiting"},
+		"running":  {execution: Execution{}, want: "running"},
+		"failed":   {execution: Execution{StoppedAt: stopped}, want: "error"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := ExecutionStatus(&tc.execution); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestListExecutions_limit(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":3,"mode":"trigger"},{"id":2,"mode":"trigger"}],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"mode":"trigger"},{"id":0,"mode":"trigger"}],"nextCursor":"page3"}`))
+	})
+
+	executions, err := c.ListExecutions(context.Background(), &ListExecutionsOptions{WorkflowID: "wf1", Status: "error", Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(executions) != 3 || executions[2].ID != "1" {
+		t.Errorf("expected the first 3 executions across pages, got %+v", executions)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 pages to be read, got %v", queries)
+	}
+	if queries[0] != "limit=3&status=error&workflowId=wf1" {
+		t.Errorf("expected no more executions than the limit to be requested, got query %q", queries[0])
+	}
+}
+
+func TestListExecutions_includeData(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	if _, err := c.ListExecutions(context.Background(), &ListExecutionsOptions{IncludeData: true}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if query != "includeData=true&limit=10" {
+		t.Errorf("expected small pages with data, got query %q", query)
+	}
+}
+
+func TestListExecutions_summaryOnly(t *testing.T) {
+	var query string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"data":[{"id":1,"status":"error","data":{"resultData":{"lastNodeExecuted":"HTTP Request"}}}],"nextCursor":null}`))
+	})
+
+	executions, err := c.ListExecutions(context.Background(), &ListExecutionsOptions{
+		ListOptions: ListOptions{ExtraQuery: map[string]string{"includeData": "true"}},
+		IncludeData: true,
+		SummaryOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if query != "includeData=false&limit=250" {
+		t.Errorf("expected the data not to be requested, got query %q", query)
+	}
+	if len(executions) != 1 || executions[0].Data != nil {
+		t.Errorf("expected the data returned anyway to be dropped, got %+v", executions)
+	}
+}
+
+func TestListExecutions_maxPageBytes(t *testing.T) {
+	page := []byte(`{"data":[{"id":1,"data":{"resultData":{"runData":{"Fetch Orders":[{"data":"` + strings.Repeat("x", 1000) + `"}]}}}}],"nextCursor":null}`)
+
+	testCases := map[string]struct {
+		maxPageBytes int64
+		wantErr      bool
+	}{
+		"unlimited":       {},
+		"exactly at size": {maxPageBytes: int64(len(page))},
+		"page too large":  {maxPageBytes: int64(len(page)) - 1, wantErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			c := newTestClientWithConfig(t, &Config{RetryWaitMin: time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				_, _ = w.Write(page)
+			})
+
+			executions, err := c.ListExecutions(context.Background(), &ListExecutionsOptions{
+				ListOptions: ListOptions{MaxPageBytes: tc.maxPageBytes},
+				IncludeData: true,
+			})
+
+			if tc.wantErr {
+				if !errors.Is(err, ErrResponseTooLarge) {
+					t.Fatalf("expected ErrResponseTooLarge, got: %v", err)
+				}
+				if requests != 1 {
+					t.Errorf("expected a page that is too large not to be retried, got %d requests", requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(executions) != 1 || len(executions[0].Data) == 0 {
+				t.Errorf("expected the execution with its data, got %+v", executions)
+			}
+		})
+	}
+}
+
+func TestExecutionErrorDetails(t *testing.T) {
+	testCases := map[string]struct {
+		execution   Execution
+		wantMessage string
+		wantNode    string
+	}{
+		"failed": {
+			execution:   Execution{Status: "error", Data: json.RawMessage(`{"resultData":{"error":{"message":"Request failed with status code 500"},"lastNodeExecuted":"HTTP Request"}}`)},
+			wantMessage: "Request failed with status code 500",
+			wantNode:    "HTTP Request",
+		},
+		"succeeded": {
+			execution: Execution{Status: "success", Data: json.RawMessage(`{"resultData":{"lastNodeExecuted":"Respond"}}`)},
+		},
+		"no data": {
+			execution: Execution{Status: "error"},
+		},
+		"unexpected shape": {
+			execution: Execution{Status: "error", Data: json.RawMessage(`{"resultData":[]}`)},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			message, node := ExecutionErrorDetails(&tc.execution)
+			if message != tc.wantMessage || node != tc.wantNode {
+				t.Errorf("expected %q in %q, got %q in %q", tc.wantMessage, tc.wantNode, message, node)
+			}
+		})
+	}
+}
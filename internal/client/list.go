@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ErrResponseTooLarge is returned for list pages whose body is larger than
+// ListOptions.MaxPageBytes.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// PageFetcher fetches the page of a list starting at cursor, or the first
+// page if cursor is empty, and returns its items and the cursor of the next
+// page, which is empty on the last page.
+type PageFetcher[T any] func(ctx context.Context, cursor string) ([]T, string, error)
+
+// Paginate calls fetch for each page of a cursor-paginated list, following
+// the cursor until the last page, and returns the accumulated items. When
+// limit is positive, it stops once limit items have been read and returns at
+// most limit items. The context is checked between pages, so a canceled
+// request or an exceeded deadline ends the listing without fetching
+// further pages.
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T], limit int) ([]T, error) {
+	var items []T
+	cursor := ""
+
+	for {
+		page, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+		if next == "" {
+			return items, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cursor = next
+	}
+}
+
+// listAll reads every page of a list endpoint through Paginate. pagePath
+// returns the request path of the page starting at cursor, or of the first
+// page if cursor is empty. Each page is logged at debug level with its item
+// count and duration, to help pinpoint slow pages.
+func listAll[T any](ctx context.Context, c *Client, pagePath func(cursor string) string) ([]T, error) {
+	return listUpTo[T](ctx, c, 0, 0, pagePath)
+}
+
+// listUpTo is like listAll, but stops reading pages once limit items have
+// been read when limit is positive, and returns at most limit items. Pages
+// larger than maxPageBytes fail with ErrResponseTooLarge when it is positive.
+func listUpTo[T any](ctx context.Context, c *Client, limit int, maxPageBytes int64, pagePath func(cursor string) string) ([]T, error) {
+	page := 0
+
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]T, string, error) {
+		page++
+		path := pagePath(cursor)
+
+		var items []T
+		start := time.Now()
+		next, err := listPage(ctx, c, path, maxPageBytes, &items)
+		if err != nil {
+			return nil, "", err
+		}
+
+		tflog.Debug(ctx, "Read n8n API list page", map[string]interface{}{
+			"path":     path,
+			"page":     page,
+			"items":    len(items),
+			"duration": time.Since(start).String(),
+		})
+
+		return items, next, nil
+	}, limit)
+}
+
+// listPage requests the page of a list endpoint at path and appends its
+// items to items. The response is decoded as it is streamed, so only the
+// decoded items are held in memory rather than the whole body. A body larger
+// than maxBytes fails with ErrResponseTooLarge, without being retried, when
+// maxBytes is positive. It returns the cursor of the next page, or an empty
+// string on the last page.
+func listPage[T any](ctx context.Context, c *Client, path string, maxBytes int64, items *[]T) (string, error) {
+	var page []T
+	var cursor string
+
+	err := c.doStream(ctx, path, func(r io.Reader) error {
+		page = nil
+		if maxBytes > 0 {
+			r = &maxBytesReader{r: r, limit: maxBytes}
+		}
+
+		var err error
+		cursor, err = decodeListPage(r, &page)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	*items = append(*items, page...)
+
+	return cursor, nil
+}
+
+// maxBytesReader reads from r until more than limit bytes have been read,
+// then fails with ErrResponseTooLarge.
+type maxBytesReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.read > m.limit {
+		return 0, m.tooLarge()
+	}
+
+	// Reading one byte past the limit tells a body of exactly limit bytes
+	// apart from a larger one
+	if remaining := m.limit - m.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := m.r.Read(p)
+	m.read += int64(n)
+	if m.read > m.limit {
+		// The byte past the limit is withheld, so that the decoder cannot
+		// complete a value with it and has to read again
+		return n - 1, m.tooLarge()
+	}
+
+	return n, err
+}
+
+func (m *maxBytesReader) tooLarge() error {
+	return fmt.Errorf("%w: the page is larger than %d bytes", ErrResponseTooLarge, m.limit)
+}
+
+// decodeListPage decodes a page of a list endpoint, shaped as
+// {"data": [...], "nextCursor": "..."}, from r, reading the data array one
+// item at a time and appending each to items. Other fields are skipped. It
+// returns the cursor of the next page, or an empty string on the last page.
+func decodeListPage[T any](r io.Reader, items *[]T) (string, error) {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", err
+	}
+
+	var cursor string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to read list response: %w", err)
+		}
+
+		switch token {
+		case "data":
+			if err := decodeListItems(dec, items); err != nil {
+				return "", err
+			}
+		case "nextCursor":
+			var next *string
+			if err := dec.Decode(&next); err != nil {
+				return "", fmt.Errorf("failed to decode next cursor: %w", err)
+			}
+			if next != nil {
+				cursor = *next
+			}
+		default:
+			var skipped json.RawMessage
+			if err := dec.Decode(&skipped); err != nil {
+				return "", fmt.Errorf("failed to read list response: %w", err)
+			}
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return "", err
+	}
+
+	return cursor, nil
+}
+
+// decodeListItems decodes the data array of a list page, which may be null,
+// one item at a time.
+func decodeListItems[T any](dec *json.Decoder, items *[]T) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read list response: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("failed to read list response: expected data to be an array, got %v", token)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode list item %d: %w", len(*items), err)
+		}
+		*items = append(*items, item)
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token from dec and returns an error unless it
+// is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("failed to read list response: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("failed to read list response: expected %q, got %v", delim, token)
+	}
+
+	return nil
+}
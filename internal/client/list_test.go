@@ -0,0 +1,359 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// largeUsersPage returns a users list page with n users, shaped like the
+// responses of the n8n API.
+func largeUsersPage(t testing.TB, n int) []byte {
+	t.Helper()
+
+	users := make([]map[string]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		users = append(users, map[string]interface{}{
+			"id":        fmt.Sprintf("user-%d", i),
+			"email":     fmt.Sprintf("user-%d@example.com", i),
+			"firstName": "Ada",
+			"lastName":  "Lovelace",
+			"isPending": i%2 == 0,
+			"role":      "global:member",
+			"createdAt": "2024-01-01T00:00:00.000Z",
+			"updatedAt": "2024-01-02T00:00:00.000Z",
+		})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"data":       users,
+		"nextCursor": "next-page",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error encoding fixture: %s", err)
+	}
+
+	return body
+}
+
+func TestDecodeListPage_matchesUnmarshal(t *testing.T) {
+	body := largeUsersPage(t, 5000)
+
+	var want UsersResponse
+	if err := json.Unmarshal(body, &want); err != nil {
+		t.Fatalf("unexpected error unmarshaling fixture: %s", err)
+	}
+
+	var got []User
+	cursor, err := decodeListPage(bytes.NewReader(body), &got)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cursor != *want.NextCursor {
+		t.Errorf("expected cursor %q, got %q", *want.NextCursor, cursor)
+	}
+	if !reflect.DeepEqual(got, want.Data) {
+		t.Errorf("expected streamed users to match the buffered decoding")
+	}
+}
+
+func TestDecodeListPage(t *testing.T) {
+	testCases := map[string]struct {
+		body       string
+		wantIDs    []string
+		wantCursor string
+		wantErr    bool
+	}{
+		"last page": {
+			body:    `{"data":[{"id":"1"},{"id":"2"}],"nextCursor":null}`,
+			wantIDs: []string{"1", "2"},
+		},
+		"cursor before data": {
+			body:       `{"nextCursor":"abc","data":[{"id":"1"}]}`,
+			wantIDs:    []string{"1"},
+			wantCursor: "abc",
+		},
+		"unknown fields": {
+			body:    `{"count":2,"data":[{"id":"1"}],"meta":{"nested":[1,2]}}`,
+			wantIDs: []string{"1"},
+		},
+		"null data": {
+			body: `{"data":null,"nextCursor":null}`,
+		},
+		"data not an array": {
+			body:    `{"data":{"id":"1"}}`,
+			wantErr: true,
+		},
+		"invalid item": {
+			body:    `{"data":[{"id":1}]}`,
+			wantErr: true,
+		},
+		"not an object": {
+			body:    `[]`,
+			wantErr: true,
+		},
+		"truncated": {
+			body:    `{"data":[{"id":"1"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var tags []Tag
+			cursor, err := decodeListPage(strings.NewReader(testCase.body), &tags)
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var ids []string
+			for _, tag := range tags {
+				ids = append(ids, tag.ID)
+			}
+			if !reflect.DeepEqual(ids, testCase.wantIDs) {
+				t.Errorf("expected ids %v, got %v", testCase.wantIDs, ids)
+			}
+			if cursor != testCase.wantCursor {
+				t.Errorf("expected cursor %q, got %q", testCase.wantCursor, cursor)
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeListPage_stream(b *testing.B) {
+	body := largeUsersPage(b, 5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var users []User
+		if _, err := decodeListPage(bytes.NewReader(body), &users); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func BenchmarkDecodeListPage_buffered(b *testing.B) {
+	body := largeUsersPage(b, 5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buffered, err := io.ReadAll(bytes.NewReader(body))
+		if err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+
+		var resp UsersResponse
+		if err := json.Unmarshal(buffered, &resp); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func TestListAll_logsPages(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}],"nextCursor":"page-2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"3"}],"nextCursor":null}`))
+	})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	tags, err := c.ListTags(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("expected 3 tags across both pages, got %d", len(tags))
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	var pages []map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Read n8n API list page" {
+			pages = append(pages, entry)
+		}
+	}
+
+	if len(pages) != 2 {
+		t.Fatalf("expected one log entry per page, got %d: %v", len(pages), entries)
+	}
+	for i, entry := range pages {
+		if entry["@level"] != "debug" {
+			t.Errorf("expected page %d to be logged at debug level, got %v", i+1, entry["@level"])
+		}
+		if entry["page"] != float64(i+1) {
+			t.Errorf("expected page number %d, got %v", i+1, entry["page"])
+		}
+		if _, ok := entry["duration"].(string); !ok {
+			t.Errorf("expected page %d to report its duration, got %v", i+1, entry["duration"])
+		}
+	}
+	if pages[0]["items"] != float64(2) || pages[1]["items"] != float64(1) {
+		t.Errorf("expected item counts 2 and 1, got %v and %v", pages[0]["items"], pages[1]["items"])
+	}
+}
+
+func TestListPage_decodeErrorRetries(t *testing.T) {
+	testCases := map[string]struct {
+		firstResponse func(w http.ResponseWriter)
+		wantAttempts  int
+		wantErr       bool
+	}{
+		"connection closed mid-response": {
+			firstResponse: func(w http.ResponseWriter) {
+				// The body ends before the declared length
+				w.Header().Set("Content-Length", "100")
+				_, _ = w.Write([]byte(`{"data":[{"id":"1"}`))
+			},
+			wantAttempts: 2,
+		},
+		"truncated body": {
+			firstResponse: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"data":[{"id":"1"}`))
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		"invalid item": {
+			firstResponse: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"data":[{"id":1}]}`))
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			c := newTestClientWithConfig(t, &Config{RetryWaitMin: time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.Header().Set("Content-Type", "application/json")
+				if attempts == 1 {
+					tc.firstResponse(w)
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":"1"}],"nextCursor":null}`))
+			})
+
+			var tags []Tag
+			_, err := listPage(context.Background(), c, "/tags", 0, &tags)
+
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error %t, got: %v", tc.wantErr, err)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.wantAttempts, attempts)
+			}
+			if !tc.wantErr && len(tags) != 1 {
+				t.Errorf("expected the tag of the retried page, got %v", tags)
+			}
+		})
+	}
+}
+
+// fakePages returns a PageFetcher serving pages in order, keyed by cursor,
+// and records the cursors it was called with.
+func fakePages(pages map[string][]int, next map[string]string, cursors *[]string) PageFetcher[int] {
+	return func(ctx context.Context, cursor string) ([]int, string, error) {
+		*cursors = append(*cursors, cursor)
+		items, ok := pages[cursor]
+		if !ok {
+			return nil, "", fmt.Errorf("unexpected cursor %q", cursor)
+		}
+
+		return items, next[cursor], nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "b": {3, 4}, "c": {5}}
+	next := map[string]string{"": "b", "b": "c"}
+
+	testCases := map[string]struct {
+		limit       int
+		want        []int
+		wantCursors []string
+	}{
+		"all pages":          {limit: 0, want: []int{1, 2, 3, 4, 5}, wantCursors: []string{"", "b", "c"}},
+		"limit within page":  {limit: 3, want: []int{1, 2, 3}, wantCursors: []string{"", "b"}},
+		"limit at page end":  {limit: 2, want: []int{1, 2}, wantCursors: []string{""}},
+		"limit beyond items": {limit: 10, want: []int{1, 2, 3, 4, 5}, wantCursors: []string{"", "b", "c"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var cursors []string
+			got, err := Paginate(context.Background(), fakePages(pages, next, &cursors), tc.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected items %v, got %v", tc.want, got)
+			}
+			if !reflect.DeepEqual(cursors, tc.wantCursors) {
+				t.Errorf("expected pages %q to be fetched, got %q", tc.wantCursors, cursors)
+			}
+		})
+	}
+}
+
+func TestPaginate_error(t *testing.T) {
+	var cursors []string
+	pages := map[string][]int{"": {1}}
+	next := map[string]string{"": "missing"}
+
+	if _, err := Paginate(context.Background(), fakePages(pages, next, &cursors), 0); err == nil {
+		t.Fatal("expected the error of a failed page to be returned")
+	}
+}
+
+func TestPaginate_canceledBetweenPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	fetch := func(ctx context.Context, cursor string) ([]int, string, error) {
+		calls++
+		cancel()
+		return []int{calls}, "more", nil
+	}
+
+	if _, err := Paginate(ctx, fetch, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no page to be fetched after cancellation, got %d fetches", calls)
+	}
+}
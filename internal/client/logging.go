@@ -0,0 +1,147 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secret values in logged requests and responses.
+const redacted = "REDACTED"
+
+// sensitiveBodyFields are the JSON fields whose values are redacted from
+// logged bodies wherever they appear.
+var sensitiveBodyFields = map[string]bool{
+	"password":     true,
+	"apiKey":       true,
+	"accessToken":  true,
+	"refreshToken": true,
+	"token":        true,
+	"secret":       true,
+}
+
+// sensitiveHeaderFragments mark header names whose values are redacted from
+// logs, besides the API key header, e.g. a gateway's client secret passed
+// through request_headers.
+var sensitiveHeaderFragments = []string{"key", "secret", "token", "auth", "cookie", "password"}
+
+// logRequest logs a completed HTTP request at debug level, and its headers
+// and body at trace level with secrets redacted. statusCode is zero when no
+// response was received.
+func logRequest(ctx context.Context, req *http.Request, path string, body []byte, statusCode int, duration time.Duration) {
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"url":      req.URL.Redacted(),
+		"duration": duration.String(),
+	}
+	if statusCode != 0 {
+		fields["status_code"] = statusCode
+	}
+	tflog.Debug(ctx, "Sent n8n API request", fields)
+
+	tflog.Trace(ctx, "n8n API request details", map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.Redacted(),
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(path, body),
+	})
+}
+
+// logResponseBody logs the body of a response to a request for path at
+// trace level, with secrets redacted.
+func logResponseBody(ctx context.Context, method, path string, statusCode int, body []byte) {
+	tflog.Trace(ctx, "n8n API response body", map[string]interface{}{
+		"method":      method,
+		"path":        path,
+		"status_code": statusCode,
+		"body":        redactBody(path, body),
+	})
+}
+
+// redactHeaders returns header as a map of header names to their
+// comma-separated values, with the values of secret headers redacted.
+func redactHeaders(header http.Header) map[string]string {
+	redactedHeader := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if isSensitiveHeader(name) {
+			value = redacted
+		}
+		redactedHeader[name] = value
+	}
+
+	return redactedHeader
+}
+
+// isSensitiveHeader reports whether the value of the header name may be a
+// secret.
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range sensitiveHeaderFragments {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactBody returns body, a request or response body for path, as a string
+// for logging with the values of sensitive fields replaced. Credential
+// bodies also have their data field redacted, since it holds the secrets.
+// Bodies that are not JSON are replaced by their size, as they cannot be
+// redacted field by field.
+func redactBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Sprintf("(%d bytes, not logged)", len(body))
+	}
+
+	credentials := strings.HasPrefix(path, "/credentials")
+	redactedBody, err := json.Marshal(redactValue(value, credentials))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes, not logged)", len(body))
+	}
+
+	return string(redactedBody)
+}
+
+// redactValue replaces the values of sensitive fields in a decoded JSON
+// value in place, recursing into objects and arrays, and returns it.
+func redactValue(value interface{}, credentials bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if sensitiveBodyFields[key] || (credentials && key == "data" && !isList(field)) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field, credentials)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i], credentials)
+		}
+	}
+
+	return value
+}
+
+// isList reports whether value is a decoded JSON array, such as the data of
+// a list response, which holds items rather than credential secrets.
+func isList(value interface{}) bool {
+	_, ok := value.([]interface{})
+	return ok
+}
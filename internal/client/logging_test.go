@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	testCases := map[string]struct {
+		path string
+		body string
+		want string
+	}{
+		"empty": {
+			path: "/users",
+		},
+		"user": {
+			path: "/users",
+			body: `{"email":"user@example.com","password":"hunter2"}`,
+			want: `{"email":"user@example.com","password":"REDACTED"}`,
+		},
+		"list data kept": {
+			path: "/users?limit=250",
+			body: `{"data":[{"id":"1"}],"nextCursor":null}`,
+			want: `{"data":[{"id":"1"}],"nextCursor":null}`,
+		},
+		"credential data": {
+			path: "/credentials",
+			body: `{"name":"Slack","type":"slackApi","data":{"accessToken":"xoxb-1"}}`,
+			want: `{"data":"REDACTED","name":"Slack","type":"slackApi"}`,
+		},
+		"credential list": {
+			path: "/credentials?limit=250",
+			body: `{"data":[{"id":"1","data":{"apiKey":"k"}}]}`,
+			want: `{"data":[{"data":"REDACTED","id":"1"}]}`,
+		},
+		"nested secret": {
+			path: "/workflows/1",
+			body: `{"nodes":[{"parameters":{"token":"t","url":"https://example.com"}}]}`,
+			want: `{"nodes":[{"parameters":{"token":"REDACTED","url":"https://example.com"}}]}`,
+		},
+		"not json": {
+			path: "/credentials/1",
+			body: "--boundary\r\nsecret",
+			want: "(18 bytes, not logged)",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := redactBody(tc.path, []byte(tc.body)); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-N8N-API-KEY", "secret-key")
+	header.Set("CF-Access-Client-Secret", "gateway-secret")
+	header.Set("Authorization", "Bearer token")
+	header.Set("Accept", "application/json")
+
+	got := redactHeaders(header)
+
+	for _, name := range []string{"X-N8n-Api-Key", "Cf-Access-Client-Secret", "Authorization"} {
+		if got[name] != redacted {
+			t.Errorf("expected %s to be redacted, got %q", name, got[name])
+		}
+	}
+	if got["Accept"] != "application/json" {
+		t.Errorf("expected Accept to be logged as-is, got %q", got["Accept"])
+	}
+}
+
+func TestDoRequest_logsRequests(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1","password":"server-secret"}`))
+	})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := c.doRequest(ctx, http.MethodPost, "/users", map[string]string{"password": "client-secret"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if logs := output.String(); strings.Contains(logs, "test-api-key") || strings.Contains(logs, "client-secret") || strings.Contains(logs, "server-secret") {
+		t.Errorf("expected secrets to be redacted from the logs, got: %s", logs)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unexpected error decoding logs: %s", err)
+	}
+
+	levels := map[string]interface{}{}
+	var sent map[string]interface{}
+	for _, entry := range entries {
+		message, _ := entry["@message"].(string)
+		levels[message] = entry["@level"]
+		if message == "Sent n8n API request" {
+			sent = entry
+		}
+	}
+
+	if sent == nil || sent["@level"] != "debug" {
+		t.Fatalf("expected the request to be logged at debug level, got: %v", entries)
+	}
+	if sent["method"] != http.MethodPost || sent["status_code"] != float64(http.StatusCreated) || !strings.HasSuffix(sent["url"].(string), "/api/v1/users") {
+		t.Errorf("expected the method, URL and status code to be logged, got: %v", sent)
+	}
+	if levels["n8n API request details"] != "trace" || levels["n8n API response body"] != "trace" {
+		t.Errorf("expected the bodies to be logged at trace level, got: %v", levels)
+	}
+}
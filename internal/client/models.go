@@ -4,23 +4,28 @@
 package client
 
 import (
-	"time"
+	"encoding/json"
 )
 
 // User represents an n8n cloud user.
 type User struct {
-	ID              string    `json:"id"`
-	Email           string    `json:"email"`
-	FirstName       *string   `json:"firstName,omitempty"`
-	LastName        *string   `json:"lastName,omitempty"`
-	IsPending       bool      `json:"isPending"`
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
-	Role            string    `json:"role,omitempty"` // Role as string: "global:admin" or "global:member"
-	InviteAcceptUrl string    `json:"inviteAcceptUrl,omitempty"`
-}
-
-// CreateUserRequest represents the request to create a new user.
+	ID              string  `json:"id"`
+	Email           string  `json:"email"`
+	FirstName       *string `json:"firstName,omitempty"`
+	LastName        *string `json:"lastName,omitempty"`
+	IsPending       bool    `json:"isPending"`
+	CreatedAt       Time    `json:"createdAt"`
+	UpdatedAt       Time    `json:"updatedAt"`
+	Role            string  `json:"role,omitempty"` // Role as string: "global:admin" or "global:member"
+	InviteAcceptUrl string  `json:"inviteAcceptUrl,omitempty"`
+
+	// Raw holds the unmodified response body the user was decoded from.
+	Raw json.RawMessage `json:"-"`
+}
+
+// CreateUserRequest represents the request to create a new user. Empty
+// name fields are omitted, or sent as null when the client is configured
+// with SendNullForEmpty.
 type CreateUserRequest struct {
 	Email     string `json:"email"`
 	Role      string `json:"role,omitempty"`
@@ -28,17 +33,268 @@ type CreateUserRequest struct {
 	LastName  string `json:"lastName,omitempty"`
 }
 
+// createUserPayload is the body sent for a CreateUserRequest when empty
+// name fields are sent as null.
+type createUserPayload struct {
+	Email     string  `json:"email"`
+	Role      string  `json:"role,omitempty"`
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+}
+
 // UpdateUserRoleRequest represents the request to update a user's role.
 type UpdateUserRoleRequest struct {
 	NewRoleName string `json:"newRoleName"`
 }
 
+// ListOptions holds the options shared by list endpoints.
+type ListOptions struct {
+	// PageSize overrides the client's page size for this listing, up to
+	// 250. Zero uses the client's page size.
+	PageSize int
+	// ExtraQuery holds additional query parameters sent as-is, for API
+	// parameters the client does not model yet.
+	ExtraQuery map[string]string
+	// MaxPageBytes fails the listing with ErrResponseTooLarge when the body
+	// of a page is larger, instead of reading it into memory. It is only
+	// honored by ListExecutions, whose pages can carry execution data. Zero
+	// leaves pages unlimited.
+	MaxPageBytes int64
+}
+
 // UsersResponse represents the response from the list users endpoint.
 type UsersResponse struct {
 	Data       []User  `json:"data"`
 	NextCursor *string `json:"nextCursor"`
 }
 
+// Tag represents an n8n tag.
+type Tag struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt Time   `json:"createdAt"`
+	UpdatedAt Time   `json:"updatedAt"`
+}
+
+// CreateTagRequest represents the request to create a new tag.
+type CreateTagRequest struct {
+	Name string `json:"name"`
+}
+
+// Credential represents an n8n credential. The API never returns the
+// credential data.
+type Credential struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	CreatedAt Time   `json:"createdAt"`
+	UpdatedAt Time   `json:"updatedAt"`
+}
+
+// CreateCredentialRequest represents the request to create a new
+// credential.
+type CreateCredentialRequest struct {
+	Name string                 `json:"name"`
+	Type string                 `json:"type"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// SourceControlPullRequest represents the request to pull from the git
+// repository connected to the instance.
+type SourceControlPullRequest struct {
+	// Force overwrites local changes that conflict with the repository.
+	Force bool `json:"force,omitempty"`
+	// Variables sets the values of variables, overriding those in the
+	// repository.
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// SourceControlPullResult represents what a source control pull imported.
+type SourceControlPullResult struct {
+	Workflows   []SourceControlImportedItem `json:"workflows"`
+	Credentials []SourceControlImportedItem `json:"credentials"`
+}
+
+// SourceControlImportedItem is a workflow or credential imported or updated
+// by a source control pull.
+type SourceControlImportedItem struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// UpdateTagRequest represents the request to rename a tag.
+type UpdateTagRequest struct {
+	Name string `json:"name"`
+}
+
+// TagsResponse represents the response from the list tags endpoint.
+type TagsResponse struct {
+	Data       []Tag   `json:"data"`
+	NextCursor *string `json:"nextCursor"`
+}
+
+// Workflow represents an n8n workflow.
+type Workflow struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	CreatedAt Time   `json:"createdAt"`
+	UpdatedAt Time   `json:"updatedAt"`
+	Tags      []Tag  `json:"tags,omitempty"`
+	// VersionID changes on every edit of the workflow.
+	VersionID string `json:"versionId,omitempty"`
+	// IsArchived is only reported by versions that archive workflows.
+	IsArchived bool `json:"isArchived,omitempty"`
+
+	Nodes       json.RawMessage `json:"nodes,omitempty"`
+	Connections json.RawMessage `json:"connections,omitempty"`
+	Settings    json.RawMessage `json:"settings,omitempty"`
+
+	// Raw holds the unmodified response body the workflow was decoded from,
+	// when it was fetched individually.
+	Raw json.RawMessage `json:"-"`
+}
+
+// WorkflowRequest represents the request to create or update a workflow.
+// The API replaces the whole workflow, so every field is required.
+type WorkflowRequest struct {
+	Name        string          `json:"name"`
+	Nodes       json.RawMessage `json:"nodes"`
+	Connections json.RawMessage `json:"connections"`
+	Settings    json.RawMessage `json:"settings"`
+}
+
+// WorkflowTagRequest references a tag to attach to a workflow.
+type WorkflowTagRequest struct {
+	ID string `json:"id"`
+}
+
+// ListWorkflowsOptions holds the filters for listing workflows.
+type ListWorkflowsOptions struct {
+	ListOptions
+
+	// Tags restricts the list to workflows carrying all of the given tag
+	// names.
+	Tags []string
+	// Active restricts the list to active or inactive workflows when set.
+	Active *bool
+	// ProjectID restricts the list to the workflows of a project.
+	ProjectID string
+	// Name restricts the list to workflows matching the name. The API may
+	// match partially, so callers needing an exact match must compare the
+	// names of the results.
+	Name string
+}
+
+// WorkflowsResponse represents the response from the list workflows
+// endpoint.
+type WorkflowsResponse struct {
+	Data       []Workflow `json:"data"`
+	NextCursor *string    `json:"nextCursor"`
+}
+
+// Execution represents a run of an n8n workflow.
+type Execution struct {
+	ID         ID     `json:"id"`
+	WorkflowID ID     `json:"workflowId"`
+	Finished   bool   `json:"finished"`
+	Mode       string `json:"mode"`
+	// Status is only reported by newer API versions; see ExecutionStatus.
+	Status    string `json:"status,omitempty"`
+	StartedAt Time   `json:"startedAt"`
+	StoppedAt Time   `json:"stoppedAt"`
+	WaitTill  Time   `json:"waitTill"`
+	// RetryOf is the ID of the execution this one retried, if any.
+	RetryOf ID `json:"retryOf"`
+	// RetrySuccessID is the ID of the retry of this execution that
+	// succeeded, if any.
+	RetrySuccessID ID `json:"retrySuccessId"`
+	// Data is the execution data, only included when requested; see
+	// ExecutionErrorDetails.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ListExecutionsOptions holds the filters for listing executions.
+type ListExecutionsOptions struct {
+	ListOptions
+
+	// WorkflowID restricts the list to the executions of a workflow.
+	WorkflowID string
+	// Status restricts the list to executions with the given status, one
+	// of error, success or waiting.
+	Status string
+	// ProjectID restricts the list to the executions of a project's
+	// workflows.
+	ProjectID string
+	// Limit is the maximum number of executions to return, across pages.
+	// Zero returns every execution.
+	Limit int
+	// IncludeData requests the execution data. Execution data can be
+	// large, so pages are then limited to maxExecutionDataPageSize
+	// executions.
+	IncludeData bool
+	// SummaryOnly never requests the execution data, even when IncludeData
+	// or ExtraQuery ask for it, and drops any data the API returns anyway.
+	SummaryOnly bool
+}
+
+// Project represents an n8n project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// TransferRequest represents the request body for moving a workflow or a
+// credential to another project.
+type TransferRequest struct {
+	DestinationProjectID string `json:"destinationProjectId"`
+}
+
+// ProjectRelation assigns a user a role in a project.
+type ProjectRelation struct {
+	UserID string `json:"userId"`
+	Role   string `json:"role"`
+}
+
+// AddProjectUsersRequest represents the request body for adding users to a
+// project.
+type AddProjectUsersRequest struct {
+	Relations []ProjectRelation `json:"relations"`
+}
+
+// ChangeProjectUserRoleRequest represents the request body for changing the
+// role of a user in a project.
+type ChangeProjectUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ProjectsResponse represents the response from the list projects endpoint.
+type ProjectsResponse struct {
+	Data       []Project `json:"data"`
+	NextCursor *string   `json:"nextCursor"`
+}
+
+// Variable represents an n8n variable.
+type Variable struct {
+	ID    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// VariableRequest represents the request to create or update a variable.
+type VariableRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// VariablesResponse represents the response from the list variables endpoint.
+type VariablesResponse struct {
+	Data       []Variable `json:"data"`
+	NextCursor *string    `json:"nextCursor"`
+}
+
 // ErrorResponse represents an error response from the API.
 type ErrorResponse struct {
 	Code    string `json:"code"`
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListProjects retrieves all projects from the n8n instance, following the
+// pagination cursor until every page has been read.
+func (c *Client) ListProjects(ctx context.Context, opts *ListOptions) ([]Project, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	return listAll[Project](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, opts)
+
+		return pathWithQuery("/projects", params, opts.ExtraQuery)
+	})
+}
+
+// GetProject retrieves a project by ID. The API has no endpoint for a
+// single project, so projects are listed and matched on their ID.
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	projects, err := c.ListProjects(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range projects {
+		if projects[i].ID == id {
+			return &projects[i], nil
+		}
+	}
+
+	return nil, &NotFoundError{Resource: fmt.Sprintf("project %q", id)}
+}
+
+// ListProjectUsers retrieves the users who are members of a project.
+func (c *Client) ListProjectUsers(ctx context.Context, projectID string) ([]User, error) {
+	return listAll[User](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, nil)
+		params.Set("projectId", projectID)
+
+		return pathWithQuery("/users", params, nil)
+	})
+}
+
+// AddProjectUser adds a user to a project with the given project role, such
+// as "project:editor".
+func (c *Client) AddProjectUser(ctx context.Context, projectID, userID, role string) error {
+	path := fmt.Sprintf("/projects/%s/users", projectID)
+	req := &AddProjectUsersRequest{
+		Relations: []ProjectRelation{{UserID: userID, Role: role}},
+	}
+
+	_, err := c.doRequest(ctx, http.MethodPost, path, req)
+	return err
+}
+
+// ChangeProjectUserRole changes the role of a user in a project.
+func (c *Client) ChangeProjectUserRole(ctx context.Context, projectID, userID, role string) error {
+	path := fmt.Sprintf("/projects/%s/users/%s", projectID, userID)
+	_, err := c.doRequest(ctx, http.MethodPatch, path, &ChangeProjectUserRoleRequest{Role: role})
+	return err
+}
+
+// RemoveProjectUser removes a user from a project.
+func (c *Client) RemoveProjectUser(ctx context.Context, projectID, userID string) error {
+	path := fmt.Sprintf("/projects/%s/users/%s", projectID, userID)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestGetProject(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"Team A","type":"team"}],"nextCursor":"next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"p2","name":"Team B","type":"team"}],"nextCursor":null}`))
+	})
+
+	project, err := c.GetProject(context.Background(), "p2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Name != "Team B" {
+		t.Errorf("expected project Team B from the second page, got %s", project.Name)
+	}
+
+	if _, err := c.GetProject(context.Background(), "missing"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestAddProjectUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/projects/p1/users" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"relations":[{"userId":"u1","role":"project:editor"}]}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if err := c.AddProjectUser(context.Background(), "p1", "u1", "project:editor"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RateLimit holds the rate limit state reported by the instance in the
+// X-RateLimit-* response headers. Fields are nil when the instance did not
+// send the corresponding header or its value was not a number.
+type RateLimit struct {
+	Limit     *int64
+	Remaining *int64
+	Reset     *int64
+}
+
+// rateLimitFromHeader reads the rate limit state from response headers.
+func rateLimitFromHeader(header http.Header) *RateLimit {
+	return &RateLimit{
+		Limit:     headerInt64(header, "X-RateLimit-Limit"),
+		Remaining: headerInt64(header, "X-RateLimit-Remaining"),
+		Reset:     headerInt64(header, "X-RateLimit-Reset"),
+	}
+}
+
+// headerInt64 parses the named header as an integer, or returns nil.
+func headerInt64(header http.Header, name string) *int64 {
+	value, err := strconv.ParseInt(strings.TrimSpace(header.Get(name)), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &value
+}
+
+// GetRateLimit performs a minimal request and returns the rate limit state
+// the instance reports for it.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	params := url.Values{}
+	params.Set("limit", "1")
+
+	resp, err := c.do(ctx, http.MethodGet, pathWithQuery("/users", params, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return rateLimitFromHeader(resp.header), nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != "1" {
+			t.Errorf("expected a minimal request with limit 1, got %q", got)
+		}
+
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "not-a-number")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	rateLimit, err := c.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rateLimit.Limit == nil || *rateLimit.Limit != 100 {
+		t.Errorf("expected limit 100, got %v", rateLimit.Limit)
+	}
+	if rateLimit.Remaining == nil || *rateLimit.Remaining != 42 {
+		t.Errorf("expected remaining 42, got %v", rateLimit.Remaining)
+	}
+	if rateLimit.Reset != nil {
+		t.Errorf("expected an unparseable reset to be nil, got %d", *rateLimit.Reset)
+	}
+}
+
+func TestGetRateLimit_noHeaders(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+
+	rateLimit, err := c.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rateLimit.Limit != nil || rateLimit.Remaining != nil || rateLimit.Reset != nil {
+		t.Errorf("expected all values to be nil, got %+v", rateLimit)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests evenly so that no more than a configured
+// number are sent per second. It is shared by every client derived from the
+// same configuration, so the limit applies across resources.
+type rateLimiter struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond requests per
+// second, or nil when requestsPerSecond is not positive.
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		now:      time.Now,
+	}
+}
+
+// reserve claims the next request slot and returns how long to wait until
+// it starts.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+
+	return delay
+}
+
+// pause holds back requests for d, e.g. for the delay of a Retry-After
+// header, so that requests from other resources sharing the limiter do not
+// run into the instance's rate limit as well. A nil limiter ignores it.
+func (l *rateLimiter) pause(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resume := l.now().Add(d); l.next.Before(resume) {
+		l.next = resume
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done. A nil limiter
+// never blocks.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for the rate limit: %w", ctx.Err())
+	}
+}
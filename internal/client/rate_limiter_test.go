@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(4)
+	l.now = func() time.Time { return now }
+
+	// Back-to-back requests are spaced a quarter second apart.
+	for i, want := range []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond} {
+		if got := l.reserve(); got != want {
+			t.Errorf("reservation %d: expected a delay of %s, got %s", i, want, got)
+		}
+	}
+
+	// An idle period does not bank a burst of requests.
+	now = now.Add(time.Minute)
+	for i, want := range []time.Duration{0, 250 * time.Millisecond} {
+		if got := l.reserve(); got != want {
+			t.Errorf("reservation %d after idling: expected a delay of %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestRateLimiter_pause(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(4)
+	l.now = func() time.Time { return now }
+
+	l.reserve()
+	l.pause(2 * time.Second)
+
+	// Requests resume after the pause, spaced as before.
+	for i, want := range []time.Duration{2 * time.Second, 2250 * time.Millisecond} {
+		if got := l.reserve(); got != want {
+			t.Errorf("reservation %d: expected a delay of %s, got %s", i, want, got)
+		}
+	}
+
+	// A pause shorter than the queued requests does not move them.
+	l.pause(time.Second)
+	if got := l.reserve(); got != 2500*time.Millisecond {
+		t.Errorf("expected a shorter pause to keep the queue, got a delay of %s", got)
+	}
+}
+
+func TestRateLimiter_disabled(t *testing.T) {
+	if l := newRateLimiter(0); l != nil {
+		t.Fatalf("expected no limiter for a zero rate, got %+v", l)
+	}
+
+	var l *rateLimiter
+	if err := l.wait(context.Background()); err != nil {
+		t.Errorf("expected a nil limiter not to block, got: %s", err)
+	}
+}
+
+func TestRateLimiter_waitCanceled(t *testing.T) {
+	l := newRateLimiter(0.001)
+	l.reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to end with the context, got: %v", err)
+	}
+}
+
+func TestDoRequest_rateLimit(t *testing.T) {
+	c := newTestClientWithConfig(t, &Config{RateLimit: 20}, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	scoped := c.WithAPIKey("scoped-api-key")
+
+	start := time.Now()
+	for _, requester := range []*Client{c, scoped, c} {
+		if _, err := requester.doRequest(context.Background(), http.MethodGet, "/users", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// Three requests at 20 per second take at least two intervals, also
+	// when they are sent through a derived client.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected the requests to be spaced by the shared limiter, took %s", elapsed)
+	}
+}
+
+func TestDoRequest_rateLimitRetryAfter(t *testing.T) {
+	c := newTestClientWithConfig(t, &Config{RateLimit: 20, MaxRetries: -1}, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := c.WithAPIKey("scoped-api-key").doRequest(context.Background(), http.MethodPost, "/users", nil); err == nil {
+		t.Fatal("expected the rate limited request to fail")
+	}
+
+	// The Retry-After delay holds back the next request of any client
+	// sharing the limiter, not only the one that was rejected.
+	if delay := c.rateLimiter.reserve(); delay < 25*time.Second {
+		t.Errorf("expected the next request to wait for the Retry-After delay, got a delay of %s", delay)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// relatedResourceTypes lists, by resource type, the other resource types
+// whose responses a write to it can change, e.g. adding a project member
+// changes the users listed for the project.
+var relatedResourceTypes = map[string][]string{
+	"projects":       {"users"},
+	"source-control": {"workflows", "credentials", "tags", "variables"},
+}
+
+// readCache memoizes successful GET responses by request path for a short
+// time, so repeated reads of the same object within a plan or apply reuse
+// one response. It is shared by every client derived from the same
+// configuration, so a write through any of them invalidates the cached
+// responses of the resource type it touches.
+type readCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+}
+
+// readCacheEntry is a cached response and the time it expires at.
+type readCacheEntry struct {
+	resp    *response
+	expires time.Time
+}
+
+// newReadCache returns a cache keeping responses for ttl, or nil when ttl is
+// not positive.
+func newReadCache(ttl time.Duration) *readCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &readCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]readCacheEntry{},
+	}
+}
+
+// get returns the cached response for path, or nil if there is none or it
+// has expired. A nil cache never has a response.
+func (c *readCache) get(path string) *response {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[path]
+	if !ok {
+		return nil
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, path)
+		return nil
+	}
+
+	return entry.resp
+}
+
+// put caches resp as the response for path. A nil cache keeps nothing.
+func (c *readCache) put(path string, resp *response) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[path] = readCacheEntry{resp: resp, expires: c.now().Add(c.ttl)}
+}
+
+// invalidate drops the cached responses of the resource type path belongs
+// to and of the resource types a write to it can change.
+func (c *readCache) invalidate(path string) {
+	if c == nil {
+		return
+	}
+
+	resourceType := cacheResourceType(path)
+	types := append([]string{resourceType}, relatedResourceTypes[resourceType]...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cached := range c.entries {
+		for _, t := range types {
+			if cacheResourceType(cached) == t {
+				delete(c.entries, cached)
+				break
+			}
+		}
+	}
+}
+
+// cacheResourceType returns the resource type of a request path, its first
+// segment, e.g. "users" for /users/a@b.c?includeRole=true.
+func cacheResourceType(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	resourceType, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+
+	return resourceType
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// countingHandler serves a user, a list of tags and successful writes,
+// counting the requests for each method and path.
+func countingHandler(requests map[string]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/tags":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","name":"production"}],"nextCursor":null}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"u1","email":"a@example.com","role":"global:member"}`))
+		}
+	}
+}
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+	requests := map[string]int{}
+	c := newTestClientWithConfig(t, &Config{ReadCacheTTL: time.Minute}, countingHandler(requests))
+
+	for range 3 {
+		if _, err := c.GetUser(ctx, "u1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := c.ListTags(ctx, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if requests["GET /api/v1/users/u1"] != 1 || requests["GET /api/v1/tags"] != 1 {
+		t.Fatalf("expected repeated reads to be served from the cache, got %v", requests)
+	}
+
+	// A write to users drops the cached users but not the cached tags
+	if err := c.DeleteUser(ctx, "u2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.ListTags(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if requests["GET /api/v1/users/u1"] != 2 {
+		t.Errorf("expected the user to be read again after a write to users, got %v", requests)
+	}
+	if requests["GET /api/v1/tags"] != 1 {
+		t.Errorf("expected the tags to stay cached after a write to users, got %v", requests)
+	}
+}
+
+func TestReadCache_expiry(t *testing.T) {
+	ctx := context.Background()
+	requests := map[string]int{}
+	c := newTestClientWithConfig(t, &Config{ReadCacheTTL: 5 * time.Second}, countingHandler(requests))
+
+	now := time.Now()
+	c.readCache.now = func() time.Time { return now }
+
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	now = now.Add(4 * time.Second)
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	now = now.Add(2 * time.Second)
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if requests["GET /api/v1/users/u1"] != 2 {
+		t.Errorf("expected the cached user to expire after the TTL, got %v", requests)
+	}
+}
+
+func TestReadCache_derivedClients(t *testing.T) {
+	ctx := context.Background()
+	requests := map[string]int{}
+	c := newTestClientWithConfig(t, &Config{ReadCacheTTL: time.Minute}, countingHandler(requests))
+
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// A client with another key may see a different response
+	scoped := c.WithAPIKey("other-key")
+	if _, err := scoped.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests["GET /api/v1/users/u1"] != 2 {
+		t.Errorf("expected a client with another key to bypass the cache, got %v", requests)
+	}
+
+	// Its writes still invalidate the shared cache
+	if err := scoped.DeleteUser(ctx, "u2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetUser(ctx, "u1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if requests["GET /api/v1/users/u1"] != 3 {
+		t.Errorf("expected a write through a derived client to invalidate the cache, got %v", requests)
+	}
+}
+
+func TestReadCache_disabled(t *testing.T) {
+	ctx := context.Background()
+	requests := map[string]int{}
+	c := newTestClient(t, countingHandler(requests))
+
+	for range 2 {
+		if _, err := c.GetUser(ctx, "u1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if requests["GET /api/v1/users/u1"] != 2 {
+		t.Errorf("expected every read to be sent without a cache, got %v", requests)
+	}
+}
+
+func TestCacheResourceType(t *testing.T) {
+	testCases := map[string]string{
+		"/users/a@example.com?includeRole=true": "users",
+		"/users?limit=250":                      "users",
+		"/workflows/wf1/tags":                   "workflows",
+		"/source-control/pull":                  "source-control",
+	}
+
+	for path, want := range testCases {
+		if got := cacheResourceType(path); got != want {
+			t.Errorf("expected resource type %q for %s, got %q", want, path, got)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = time.Second
+	defaultRetryMaxDelay  = 30 * time.Second
+
+	// maxRetryAfter caps the delay a Retry-After header can impose, so a
+	// server asking for hours does not stall an apply.
+	maxRetryAfter = time.Minute
+)
+
+// defaultRetryStatusCodes are the response status codes that indicate a
+// transient failure worth retrying.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// postRetryStatusCodes are the status codes a POST request is retried on.
+// With them the server refused the request before processing it, so
+// repeating it cannot create a duplicate.
+var postRetryStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+// retryPolicy decides which failed requests are retried and how long to
+// wait before each retry.
+type retryPolicy struct {
+	maxRetries  int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	statusCodes map[int]bool
+
+	// jitter randomizes a backoff delay so that clients failing together do
+	// not retry in lockstep. Nil leaves delays unchanged.
+	jitter func(time.Duration) time.Duration
+}
+
+// newRetryPolicy returns the retry policy described by the retry options of
+// config, using the defaults for those that are not set. A nil config
+// returns the default policy.
+func newRetryPolicy(config *Config) *retryPolicy {
+	if config == nil {
+		config = &Config{}
+	}
+
+	statusCodes := make(map[int]bool, len(defaultRetryStatusCodes)+len(config.RetryOnStatus))
+	for _, code := range defaultRetryStatusCodes {
+		statusCodes[code] = true
+	}
+	for _, code := range config.RetryOnStatus {
+		statusCodes[code] = true
+	}
+
+	maxRetries := config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	baseDelay := config.RetryWaitMin
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+
+	maxDelay := config.RetryWaitMax
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	return &retryPolicy{
+		maxRetries:  maxRetries,
+		baseDelay:   baseDelay,
+		maxDelay:    max(maxDelay, baseDelay),
+		statusCodes: statusCodes,
+		jitter:      equalJitter,
+	}
+}
+
+// equalJitter returns a random delay between half of d and d.
+func equalJitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+
+	return d/2 + rand.N(d/2+1)
+}
+
+// classify decides whether a request should be retried after its attempt,
+// which starts at zero, failed with err, and how long to wait before the
+// retry. statusCode is the response status, or zero if no response was
+// received. It only depends on its arguments and the policy, so the retry
+// behavior can be tested without a server.
+//
+// POST requests are only retried on a 429 or 503 response, since repeating
+// one the server may have processed, e.g. after a connection reset
+// mid-write, could create duplicates such as a second invitation. Requests
+// canceled by their context and responses that do not decode are not
+// retried either. A Retry-After delay sent by the server takes precedence
+// over the exponential backoff, up to maxRetryAfter.
+func (p *retryPolicy) classify(method string, statusCode int, err error, attempt int) (bool, time.Duration) {
+	if err == nil || attempt >= p.maxRetries {
+		return false, 0
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, 0
+	}
+
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		return false, 0
+	}
+
+	if method == http.MethodPost && !postRetryStatusCodes[statusCode] {
+		return false, 0
+	}
+
+	if statusCode != 0 {
+		if !p.statusCodes[statusCode] {
+			return false, 0
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			return true, min(apiErr.RetryAfter, maxRetryAfter)
+		}
+
+		return true, p.backoff(attempt)
+	}
+
+	// Connection failures surface as *url.Error from the HTTP client, and a
+	// connection closed while reading the response body as an EOF
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true, p.backoff(attempt)
+	}
+
+	return false, 0
+}
+
+// statusCode returns the response status code err carries, or zero if the
+// request failed without a response.
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+
+	return 0
+}
+
+// retryAfter returns the delay requested by a Retry-After header, given in
+// seconds or as an HTTP date, or zero if it is absent or invalid.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+
+	return 0
+}
+
+// delay returns the longest wait before the retry following attempt, which
+// starts at zero, doubling with each attempt up to the policy's maximum.
+func (p *retryPolicy) delay(attempt int) time.Duration {
+	delay := p.baseDelay
+	for i := 0; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.maxDelay)
+}
+
+// backoff returns the jittered wait before the retry following attempt.
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.delay(attempt)
+	if p.jitter == nil {
+		return delay
+	}
+
+	return p.jitter(delay)
+}
+
+// WorstCaseRequestDuration returns how long a single request can take under
+// the retry policy of config when its attempts are answered with retryable
+// statuses until the last one, which runs into the per-attempt timeout. A
+// timed-out attempt is not retried, so at most one timeout counts, plus the
+// longest backoff before each retry. A zero timeout means the client
+// default. Retry-After delays, capped at one minute each, and slow answers
+// can add to it.
+func WorstCaseRequestDuration(config *Config) time.Duration {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	policy := newRetryPolicy(config)
+
+	total := timeout
+	for attempt := 0; attempt < policy.maxRetries; attempt++ {
+		total += policy.delay(attempt)
+	}
+
+	return total
+}
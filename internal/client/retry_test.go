@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDoRequest_retryOnStatus(t *testing.T) {
+	testCases := map[string]struct {
+		retryOnStatus []int
+		status        int
+		method        string
+		wantRequests  int
+		wantErr       bool
+	}{
+		"default status retried": {
+			status:       http.StatusServiceUnavailable,
+			method:       http.MethodGet,
+			wantRequests: 2,
+		},
+		"configured status retried": {
+			retryOnStatus: []int{http.StatusConflict},
+			status:        http.StatusConflict,
+			method:        http.MethodPatch,
+			wantRequests:  2,
+		},
+		"unconfigured status not retried": {
+			status:       http.StatusConflict,
+			method:       http.MethodPatch,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		"post not retried on ambiguous status": {
+			status:       http.StatusBadGateway,
+			method:       http.MethodPost,
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		"post retried on 503": {
+			status:       http.StatusServiceUnavailable,
+			method:       http.MethodPost,
+			wantRequests: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			c := newTestClientWithConfig(t, &Config{RetryOnStatus: testCase.retryOnStatus}, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					w.WriteHeader(testCase.status)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			})
+			c.retry.baseDelay = time.Millisecond
+
+			_, err := c.doRequest(context.Background(), testCase.method, "/users/1", nil)
+
+			if testCase.wantErr {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || apiErr.StatusCode != testCase.status {
+					t.Errorf("expected HTTP %d error, got: %v", testCase.status, err)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			if requests != testCase.wantRequests {
+				t.Errorf("expected %d requests, got %d", testCase.wantRequests, requests)
+			}
+		})
+	}
+}
+
+func TestDoRequest_retryGivesUp(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c.retry.baseDelay = time.Millisecond
+
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+
+	if want := defaultMaxRetries + 1; requests != want {
+		t.Errorf("expected %d requests, got %d", want, requests)
+	}
+}
+
+func TestDoRequest_maxRetries(t *testing.T) {
+	testCases := map[string]struct {
+		maxRetries   int
+		wantRequests int
+	}{
+		"configured": {maxRetries: 1, wantRequests: 2},
+		"disabled":   {maxRetries: -1, wantRequests: 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			c := newTestClientWithConfig(t, &Config{MaxRetries: tc.maxRetries, RetryWaitMin: time.Millisecond}, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+
+			if _, err := c.doRequest(context.Background(), http.MethodGet, "/users", nil); err == nil {
+				t.Fatal("expected error after exhausting retries")
+			}
+
+			if requests != tc.wantRequests {
+				t.Errorf("expected %d requests, got %d", tc.wantRequests, requests)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	p := newRetryPolicy(nil)
+
+	for attempt, want := range map[int]time.Duration{0: time.Second, 1: 2 * time.Second, 2: 4 * time.Second, 10: 30 * time.Second} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %s, expected %s", attempt, got, want)
+		}
+	}
+
+	p = newRetryPolicy(&Config{RetryWaitMin: 100 * time.Millisecond, RetryWaitMax: 300 * time.Millisecond})
+
+	for attempt, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %s, expected %s", attempt, got, want)
+		}
+	}
+}
+
+func TestEqualJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if got := equalJitter(4 * time.Second); got < 2*time.Second || got > 4*time.Second {
+			t.Fatalf("expected a delay between 2s and 4s, got %s", got)
+		}
+	}
+}
+
+func TestRetryPolicy_classify(t *testing.T) {
+	connectionReset := &url.Error{Op: "Get", URL: "https://example.app.n8n.cloud/api/v1/users", Err: syscall.ECONNRESET}
+	apiError := func(statusCode int, retryAfter time.Duration) error {
+		return &APIError{StatusCode: statusCode, RetryAfter: retryAfter}
+	}
+
+	testCases := map[string]struct {
+		method     string
+		err        error
+		attempt    int
+		extraCodes []int
+		wantRetry  bool
+		wantWait   time.Duration
+	}{
+		"success": {
+			method: http.MethodGet,
+		},
+		"429 with Retry-After": {
+			method:    http.MethodGet,
+			err:       apiError(http.StatusTooManyRequests, 7*time.Second),
+			wantRetry: true,
+			wantWait:  7 * time.Second,
+		},
+		"429 with long Retry-After": {
+			method:    http.MethodGet,
+			err:       apiError(http.StatusTooManyRequests, time.Hour),
+			wantRetry: true,
+			wantWait:  maxRetryAfter,
+		},
+		"429 without Retry-After": {
+			method:    http.MethodGet,
+			err:       apiError(http.StatusTooManyRequests, 0),
+			attempt:   1,
+			wantRetry: true,
+			wantWait:  2 * time.Second,
+		},
+		"503": {
+			method:    http.MethodDelete,
+			err:       apiError(http.StatusServiceUnavailable, 0),
+			wantRetry: true,
+			wantWait:  time.Second,
+		},
+		"503 on POST": {
+			method:    http.MethodPost,
+			err:       apiError(http.StatusServiceUnavailable, 0),
+			wantRetry: true,
+			wantWait:  time.Second,
+		},
+		"429 on POST": {
+			method:    http.MethodPost,
+			err:       apiError(http.StatusTooManyRequests, 3*time.Second),
+			wantRetry: true,
+			wantWait:  3 * time.Second,
+		},
+		"502 on POST": {
+			method: http.MethodPost,
+			err:    apiError(http.StatusBadGateway, 0),
+		},
+		"500 on GET": {
+			method: http.MethodGet,
+			err:    apiError(http.StatusInternalServerError, 0),
+		},
+		"500 on POST": {
+			method: http.MethodPost,
+			err:    apiError(http.StatusInternalServerError, 0),
+		},
+		"500 configured": {
+			method:     http.MethodGet,
+			err:        apiError(http.StatusInternalServerError, 0),
+			extraCodes: []int{http.StatusInternalServerError},
+			wantRetry:  true,
+			wantWait:   time.Second,
+		},
+		"404": {
+			method: http.MethodGet,
+			err:    apiError(http.StatusNotFound, 0),
+		},
+		"retries exhausted": {
+			method:  http.MethodGet,
+			err:     apiError(http.StatusServiceUnavailable, 0),
+			attempt: defaultMaxRetries,
+		},
+		"connection reset": {
+			method:    http.MethodGet,
+			err:       fmt.Errorf("failed to perform request: %w", connectionReset),
+			attempt:   2,
+			wantRetry: true,
+			wantWait:  4 * time.Second,
+		},
+		"connection reset on POST": {
+			method: http.MethodPost,
+			err:    fmt.Errorf("failed to perform request: %w", connectionReset),
+		},
+		"connection closed reading body": {
+			method:    http.MethodGet,
+			err:       fmt.Errorf("failed to read response body: %w", io.ErrUnexpectedEOF),
+			wantRetry: true,
+			wantWait:  time.Second,
+		},
+		"connection closed reading body on POST": {
+			method: http.MethodPost,
+			err:    fmt.Errorf("failed to read response body: %w", io.ErrUnexpectedEOF),
+		},
+		"context canceled": {
+			method: http.MethodGet,
+			err:    &url.Error{Op: "Get", URL: "https://example.app.n8n.cloud/api/v1/users", Err: context.Canceled},
+		},
+		"context deadline exceeded": {
+			method: http.MethodGet,
+			err:    &url.Error{Op: "Get", URL: "https://example.app.n8n.cloud/api/v1/users", Err: context.DeadlineExceeded},
+		},
+		"decode error": {
+			method: http.MethodGet,
+			err:    errors.New("failed to decode list page: unexpected EOF"),
+		},
+		"body ending mid-value": {
+			method: http.MethodGet,
+			err:    &decodeError{err: fmt.Errorf("failed to decode list item 1: %w", io.ErrUnexpectedEOF)},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newRetryPolicy(&Config{RetryOnStatus: tc.extraCodes})
+			p.jitter = nil
+
+			retry, wait := p.classify(tc.method, statusCode(tc.err), tc.err, tc.attempt)
+			if retry != tc.wantRetry || wait != tc.wantWait {
+				t.Errorf("expected (%t, %s), got (%t, %s)", tc.wantRetry, tc.wantWait, retry, wait)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		value string
+		want  time.Duration
+	}{
+		"absent":      {},
+		"seconds":     {value: "30", want: 30 * time.Second},
+		"http date":   {value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		"past date":   {value: now.Add(-time.Minute).Format(http.TimeFormat)},
+		"negative":    {value: "-1"},
+		"unparseable": {value: "soon"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.value != "" {
+				header.Set("Retry-After", tc.value)
+			}
+
+			if got := retryAfter(header, now); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWorstCaseRequestDuration(t *testing.T) {
+	// One timed-out attempt of 5s, plus 1s, 2s and 4s of backoff before the
+	// retries on retryable statuses.
+	if got, want := WorstCaseRequestDuration(&Config{Timeout: 5 * time.Second}), 12*time.Second; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if got, want := WorstCaseRequestDuration(&Config{}), defaultTimeout+7*time.Second; got != want {
+		t.Errorf("expected the default timeout to be used, want %s, got %s", want, got)
+	}
+
+	// One timed-out attempt of 5s, plus 1s of backoff.
+	if got, want := WorstCaseRequestDuration(&Config{Timeout: 5 * time.Second, MaxRetries: 1}), 6*time.Second; got != want {
+		t.Errorf("expected the configured retries to be used, want %s, got %s", want, got)
+	}
+
+	if got, want := WorstCaseRequestDuration(&Config{Timeout: 5 * time.Second, MaxRetries: -1}), 5*time.Second; got != want {
+		t.Errorf("expected a single timeout without retries, want %s, got %s", want, got)
+	}
+}
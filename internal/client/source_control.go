@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// PullSourceControl pulls the workflows, credentials, tags and variables
+// from the git repository connected to the instance and imports them.
+func (c *Client) PullSourceControl(ctx context.Context, req *SourceControlPullRequest) (*SourceControlPullResult, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, "/source-control/pull", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SourceControlPullResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal source control pull response: %w", err)
+	}
+
+	return &result, nil
+}
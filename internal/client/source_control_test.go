@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestPullSourceControl(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/source-control/pull" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"force":true,"variables":{"env":"production"}}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"variables":{"added":["env"],"changed":[]},
+			"credentials":[{"id":"c1","name":"GitHub","type":"githubApi"}],
+			"workflows":[{"id":"wf1","name":"Orders"},{"id":"wf2","name":"Invoices"}],
+			"tags":{"tags":[],"mappings":[]}
+		}`))
+	})
+
+	result, err := c.PullSourceControl(context.Background(), &SourceControlPullRequest{
+		Force:     true,
+		Variables: map[string]string{"env": "production"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Workflows) != 2 || result.Workflows[1].ID != "wf2" {
+		t.Errorf("expected the imported workflows, got %+v", result.Workflows)
+	}
+	if len(result.Credentials) != 1 || result.Credentials[0].ID != "c1" {
+		t.Errorf("expected the imported credentials, got %+v", result.Credentials)
+	}
+}
+
+func TestIsSourceControlUnavailableError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"unlicensed":    {status: http.StatusUnauthorized, body: `{"message":"Source Control feature is not licensed or not enabled"}`, want: true},
+		"not connected": {status: http.StatusBadRequest, body: `{"message":"Source control is not connected to a repository"}`, want: true},
+		"no endpoint":   {status: http.StatusNotFound, body: `{"message":"not found"}`, want: true},
+		"conflict":      {status: http.StatusConflict, body: `{"message":"There are conflicting changes, use force to overwrite"}`},
+		"bad request":   {status: http.StatusBadRequest, body: `{"message":"request/body/force must be boolean"}`},
+		"unauthorized":  {status: http.StatusUnauthorized, body: `{"message":"unauthorized"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.PullSourceControl(context.Background(), &SourceControlPullRequest{})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsSourceControlUnavailableError(err); got != tc.want {
+				t.Errorf("expected IsSourceControlUnavailableError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ListTags retrieves all tags from the n8n instance, following the
+// pagination cursor until every page has been read.
+func (c *Client) ListTags(ctx context.Context, opts *ListOptions) ([]Tag, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	return listAll[Tag](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, opts)
+
+		return pathWithQuery("/tags", params, opts.ExtraQuery)
+	})
+}
+
+// GetTag retrieves a tag by ID.
+func (c *Client) GetTag(ctx context.Context, id string) (*Tag, error) {
+	path := fmt.Sprintf("/tags/%s", id)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := json.Unmarshal(body, &tag); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tag response: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// GetTagByName retrieves a tag by its exact name.
+func (c *Client) GetTagByName(ctx context.Context, name string) (*Tag, error) {
+	tags, err := c.ListTags(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tags {
+		if tags[i].Name == name {
+			return &tags[i], nil
+		}
+	}
+
+	return nil, &NotFoundError{Resource: fmt.Sprintf("tag with name %q", name)}
+}
+
+// CreateTag creates a new tag with the given name.
+func (c *Client) CreateTag(ctx context.Context, name string) (*Tag, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, "/tags", &CreateTagRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := json.Unmarshal(body, &tag); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal create tag response: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// UpdateTag renames a tag.
+func (c *Client) UpdateTag(ctx context.Context, id, name string) (*Tag, error) {
+	path := fmt.Sprintf("/tags/%s", id)
+	body, err := c.doRequest(ctx, http.MethodPut, path, &UpdateTagRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	if err := json.Unmarshal(body, &tag); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal update tag response: %w", err)
+	}
+
+	return &tag, nil
+}
+
+// DeleteTag deletes a tag, detaching it from every workflow.
+func (c *Client) DeleteTag(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/tags/%s", id)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
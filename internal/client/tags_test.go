@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateTag(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/tags/t1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"staging"}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"t1","name":"staging"}`))
+	})
+
+	tag, err := c.UpdateTag(context.Background(), "t1", "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag.Name != "staging" {
+		t.Errorf("expected tag staging, got %s", tag.Name)
+	}
+}
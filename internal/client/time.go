@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Time is a timestamp returned by the API. Some endpoints return RFC3339
+// strings while others return epoch milliseconds, so both forms are accepted
+// when unmarshaling. Epoch milliseconds may be a JSON number or a numeric
+// string. A JSON null leaves the zero time.
+type Time struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '"' {
+		return t.parseEpochMillis(string(data))
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+
+	if value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err == nil {
+		t.Time = parsed
+		return nil
+	}
+
+	if epochErr := t.parseEpochMillis(value); epochErr == nil {
+		return nil
+	}
+
+	return fmt.Errorf("invalid timestamp %q: expected RFC3339 or epoch milliseconds", value)
+}
+
+// MarshalJSON implements json.Marshaler, always encoding as RFC3339.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+func (t *Time) parseEpochMillis(value string) error {
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: expected RFC3339 or epoch milliseconds", value)
+	}
+
+	t.Time = time.UnixMilli(millis).UTC()
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2022, 4, 29, 11, 2, 29, 842000000, time.UTC)
+
+	testCases := map[string]struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		"rfc3339": {
+			input: `"2022-04-29T11:02:29.842Z"`,
+			want:  want,
+		},
+		"epoch millis number": {
+			input: `1651230149842`,
+			want:  want,
+		},
+		"epoch millis string": {
+			input: `"1651230149842"`,
+			want:  want,
+		},
+		"null": {
+			input: `null`,
+		},
+		"empty string": {
+			input: `""`,
+		},
+		"invalid": {
+			input:   `"yesterday"`,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got Time
+			err := json.Unmarshal([]byte(testCase.input), &got)
+
+			if testCase.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(testCase.want) {
+				t.Errorf("expected %s, got %s", testCase.want, got.Time)
+			}
+		})
+	}
+}
+
+func TestUser_timestampFormats(t *testing.T) {
+	var user User
+	body := `{"id":"1","email":"user@example.com","createdAt":"2022-04-29T11:02:29.842Z","updatedAt":1651230149842}`
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !user.CreatedAt.Equal(user.UpdatedAt.Time) {
+		t.Errorf("expected equal timestamps, got %s and %s", user.CreatedAt.Time, user.UpdatedAt.Time)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// transferToProject moves the object with the given ID under resourcePath,
+// such as /workflows, to the project with ID projectID. Transfers require
+// an Enterprise license.
+func (c *Client) transferToProject(ctx context.Context, resourcePath, id, projectID string) error {
+	path := fmt.Sprintf("%s/%s/transfer", resourcePath, id)
+	_, err := c.doRequest(ctx, http.MethodPut, path, &TransferRequest{DestinationProjectID: projectID})
+	return err
+}
+
+// TransferWorkflow moves a workflow to the project with ID projectID.
+func (c *Client) TransferWorkflow(ctx context.Context, id, projectID string) error {
+	return c.transferToProject(ctx, "/workflows", id, projectID)
+}
+
+// TransferCredential moves a credential to the project with ID projectID.
+func (c *Client) TransferCredential(ctx context.Context, id, projectID string) error {
+	return c.transferToProject(ctx, "/credentials", id, projectID)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestTransferWorkflow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/workflows/wf1/transfer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"destinationProjectId":"p2"}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.TransferWorkflow(context.Background(), "wf1", "p2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestTransferCredential(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/credentials/c1/transfer" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"destinationProjectId":"p2"}` {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.TransferCredential(context.Background(), "c1", "p2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestIsAlreadyInProjectError(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   bool
+	}{
+		"already owning": {status: http.StatusBadRequest, body: `{"message":"You can't transfer a workflow into the project that's already owning it."}`, want: true},
+		"conflict":       {status: http.StatusConflict, body: `{"message":"Credential is already in project p2"}`, want: true},
+		"other message":  {status: http.StatusBadRequest, body: `{"message":"destinationProjectId is required"}`},
+		"not found":      {status: http.StatusNotFound, body: `{"message":"Could not find project, maybe it is already in another one"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			err := c.TransferWorkflow(context.Background(), "wf1", "p2")
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got := IsAlreadyInProjectError(err); got != tc.want {
+				t.Errorf("expected IsAlreadyInProjectError %t, got %t for: %s", tc.want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// defaultMaxIdleConnsPerHost is the number of idle connections kept for
+// reuse when MaxIdleConnsPerHost is not set. It matches Terraform's default
+// parallelism, where http.DefaultTransport keeps only two.
+const defaultMaxIdleConnsPerHost = 10
+
+// newTransport builds the HTTP transport used by the client from config,
+// starting from the same defaults as http.DefaultTransport except for the
+// connections kept per host.
+func newTransport(config *Config) *http.Transport {
+	// The configured proxy replaces the HTTP_PROXY, HTTPS_PROXY and
+	// NO_PROXY environment variables
+	proxy := http.ProxyFromEnvironment
+	if config.ProxyURL != nil {
+		proxy = http.ProxyURL(config.ProxyURL)
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost(config),
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		DisableCompression:    config.DisableCompression,
+	}
+
+	if config.RootCAs != nil || config.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            config.RootCAs,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		}
+	}
+
+	// A non-nil, empty TLSNextProto map turns off HTTP/2 negotiation
+	if config.ForceHTTP1 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// maxIdleConnsPerHost returns the number of idle connections to keep per
+// host for config, enough for every concurrent request to reuse one.
+func maxIdleConnsPerHost(config *Config) int {
+	if config.MaxIdleConnsPerHost > 0 {
+		return config.MaxIdleConnsPerHost
+	}
+
+	return max(defaultMaxIdleConnsPerHost, config.MaxConcurrentRequests)
+}
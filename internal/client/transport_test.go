@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestNewTransport_forceHTTP1(t *testing.T) {
+	transport := newTransport(&Config{})
+	if !transport.ForceAttemptHTTP2 || transport.TLSNextProto != nil {
+		t.Errorf("expected HTTP/2 to be enabled by default")
+	}
+
+	transport = newTransport(&Config{ForceHTTP1: true})
+	if transport.ForceAttemptHTTP2 {
+		t.Error("expected ForceAttemptHTTP2 to be false")
+	}
+	if transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("expected an empty, non-nil TLSNextProto map, got %v", transport.TLSNextProto)
+	}
+}
+
+func TestNewTransport_rootCAs(t *testing.T) {
+	if transport := newTransport(&Config{}); transport.TLSClientConfig != nil {
+		t.Errorf("expected the default TLS configuration when no CA certificates are set")
+	}
+
+	pool := x509.NewCertPool()
+	transport := newTransport(&Config{RootCAs: pool})
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs != pool {
+		t.Errorf("expected the configured cert pool to be used to verify the instance")
+	}
+}
+
+func TestNewTransport_insecureSkipVerify(t *testing.T) {
+	transport := newTransport(&Config{InsecureSkipVerify: true})
+	if transport.TLSClientConfig == nil || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("expected certificate verification to be disabled")
+	}
+}
+
+func TestNewTransport_proxyURL(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.internal:3128")
+
+	req, err := http.NewRequest(http.MethodGet, "https://example.app.n8n.cloud/api/v1/users", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.internal:8080"}
+	got, err := newTransport(&Config{ProxyURL: proxyURL}).Proxy(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.String() != "http://proxy.internal:8080" {
+		t.Errorf("expected the configured proxy to take precedence over the environment, got %v", got)
+	}
+}
+
+func TestNewTransport_connectionsPerHost(t *testing.T) {
+	testCases := map[string]struct {
+		config   Config
+		wantIdle int
+		wantMax  int
+	}{
+		"defaults":              {wantIdle: 10},
+		"concurrent requests":   {config: Config{MaxConcurrentRequests: 25}, wantIdle: 25},
+		"configured":            {config: Config{MaxIdleConnsPerHost: 4, MaxConnsPerHost: 8}, wantIdle: 4, wantMax: 8},
+		"configured with slots": {config: Config{MaxIdleConnsPerHost: 4, MaxConcurrentRequests: 25}, wantIdle: 4},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			transport := newTransport(&tc.config)
+			if transport.MaxIdleConnsPerHost != tc.wantIdle {
+				t.Errorf("expected %d idle connections per host, got %d", tc.wantIdle, transport.MaxIdleConnsPerHost)
+			}
+			if transport.MaxConnsPerHost != tc.wantMax {
+				t.Errorf("expected at most %d connections per host, got %d", tc.wantMax, transport.MaxConnsPerHost)
+			}
+		})
+	}
+}
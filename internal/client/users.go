@@ -6,23 +6,49 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 )
 
-// ListUsers retrieves all users from the n8n instance.
-func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
-	body, err := c.doRequest(ctx, http.MethodGet, "/users?includeRole=true", nil)
+// ErrUnexpectedResponse is returned by VerifyConnection when the instance
+// answers with something other than the n8n public API, e.g. an HTML page
+// because the instance URL points elsewhere.
+var ErrUnexpectedResponse = errors.New("response is not from the n8n public API")
+
+// VerifyConnection sends a single low-cost authenticated request, listing at
+// most one user, to check that the instance is reachable and accepts the
+// API key.
+func (c *Client) VerifyConnection(ctx context.Context) error {
+	body, err := c.doRequest(ctx, http.MethodGet, "/users?limit=1", nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var resp UsersResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal users response: %w", err)
+	var users UsersResponse
+	if err := json.Unmarshal(body, &users); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnexpectedResponse, err)
 	}
 
-	return resp.Data, nil
+	return nil
+}
+
+// ListUsers retrieves all users from the n8n instance, following the
+// pagination cursor until every page has been read.
+func (c *Client) ListUsers(ctx context.Context, opts *ListOptions) ([]User, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	return listAll[User](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, opts)
+		params.Set("includeRole", "true")
+
+		return pathWithQuery("/users", params, opts.ExtraQuery)
+	})
 }
 
 // GetUser retrieves a user by ID with role information.
@@ -38,19 +64,73 @@ func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
 	if err := json.Unmarshal(body, &user); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal user response: %w", err)
 	}
+	user.Raw = body
 
 	return &user, nil
 }
 
-// GetUserByEmail retrieves a user by email.
+// GetUserByEmail retrieves a user by email. Users are listed and matched on
+// their email case-insensitively. If the API refuses or does not support
+// listing users, the email is looked up directly, which the API supports as
+// an alternative identifier. Other errors, such as a rejected API key or a
+// timeout, are returned as they are.
 func (c *Client) GetUserByEmail(ctx context.Context, email string) (*User, error) {
-	// The API uses email as the identifier in the URL
-	return c.GetUser(ctx, email)
+	users, err := c.ListUsers(ctx, nil)
+	if listingUnavailable(err) {
+		return c.getUserByEmailDirect(ctx, email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if strings.EqualFold(users[i].Email, email) {
+			return &users[i], nil
+		}
+	}
+
+	return nil, &NotFoundError{Resource: fmt.Sprintf("user with email %q", email)}
+}
+
+// listingUnavailable reports whether err is a 403 or 404 response to listing
+// users, e.g. for an API key without the user:list scope.
+func listingUnavailable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusNotFound
+}
+
+// getUserByEmailDirect looks a user up using the email as the identifier in
+// the URL and verifies the returned user actually has that email.
+func (c *Client) getUserByEmailDirect(ctx context.Context, email string) (*User, error) {
+	user, err := c.GetUser(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, email) {
+		return nil, &NotFoundError{Resource: fmt.Sprintf("user with email %q", email)}
+	}
+
+	return user, nil
 }
 
 // CreateUser creates a new user.
 func (c *Client) CreateUser(ctx context.Context, req *CreateUserRequest) (*User, error) {
-	body, err := c.doRequest(ctx, http.MethodPost, "/users", req)
+	var payload interface{} = req
+	if c.sendNullForEmpty {
+		payload = &createUserPayload{
+			Email:     req.Email,
+			Role:      req.Role,
+			FirstName: nullIfEmpty(req.FirstName),
+			LastName:  nullIfEmpty(req.LastName),
+		}
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, "/users", payload)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +141,32 @@ func (c *Client) CreateUser(ctx context.Context, req *CreateUserRequest) (*User,
 		return nil, fmt.Errorf("failed to unmarshal create user response: %w", err)
 	}
 
+	// A differently shaped response decodes into a zero-value user, which
+	// would be saved to state without an ID. The body is left out of the
+	// error since it may contain the invite URL.
+	if user.ID == "" {
+		return nil, fmt.Errorf("create user response did not include a user ID, which may mean the instance runs an API version this provider does not support")
+	}
+	user.Raw = body
+
 	return &user, nil
 }
 
-// UpdateUserRole updates a user's role.
+// UserRoles lists the roles that can be assigned to users, in the form the
+// API expects.
+var UserRoles = []string{"global:admin", "global:member"}
+
+// UpdateUserRole updates a user's role. Roles other than UserRoles are
+// rejected with a ValidationError before a request is sent.
 func (c *Client) UpdateUserRole(ctx context.Context, id string, newRole string) error {
+	if !slices.Contains(UserRoles, newRole) {
+		return &ValidationError{
+			Field:   "role",
+			Value:   newRole,
+			Message: fmt.Sprintf("must be one of %s", strings.Join(UserRoles, ", ")),
+		}
+	}
+
 	path := fmt.Sprintf("/users/%s/role", id)
 	req := &UpdateUserRoleRequest{
 		NewRoleName: newRole,
@@ -81,3 +182,25 @@ func (c *Client) DeleteUser(ctx context.Context, id string) error {
 	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	return err
 }
+
+// DeleteUserWithTransfer deletes a user and transfers their workflows and
+// credentials to the user with ID transferID, using the transferId parameter
+// of the delete endpoint.
+func (c *Client) DeleteUserWithTransfer(ctx context.Context, id, transferID string) error {
+	params := url.Values{}
+	params.Set("transferId", transferID)
+
+	path := pathWithQuery(fmt.Sprintf("/users/%s", id), params, nil)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// nullIfEmpty returns a pointer to s, or nil if s is empty so that it is
+// encoded as null.
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestClient returns a client pointed at a test server serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	return newTestClientWithConfig(t, &Config{}, handler)
+}
+
+// newTestClientWithConfig creates a client for config pointed at a test
+// server serving handler.
+func newTestClientWithConfig(t *testing.T, config *Config, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.BaseURL = server.URL
+	config.APIKey = "test-api-key"
+
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return c
+}
+
+func TestCreateUser_ssoManaged(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"SAML is enabled, so users are managed by the Identity Provider and cannot be added through invites"}`))
+	})
+
+	_, err := c.CreateUser(context.Background(), &CreateUserRequest{Email: "user@example.com"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !IsSSOManagedError(err) {
+		t.Errorf("expected SSO managed error, got: %s", err)
+	}
+}
+
+func TestCreateUser_missingID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"user":{"id":"1","email":"user@example.com"},"error":""}`))
+	})
+
+	_, err := c.CreateUser(context.Background(), &CreateUserRequest{Email: "user@example.com"})
+	if err == nil {
+		t.Fatal("expected error for a response without a user ID")
+	}
+	if !strings.Contains(err.Error(), "API version") {
+		t.Errorf("expected the error to point to an API version mismatch, got: %s", err)
+	}
+}
+
+func TestCreateUser_payload(t *testing.T) {
+	testCases := map[string]struct {
+		sendNullForEmpty bool
+		req              CreateUserRequest
+		want             string
+	}{
+		"empty names omitted": {
+			req:  CreateUserRequest{Email: "user@example.com", Role: "global:member"},
+			want: `{"email":"user@example.com","role":"global:member"}`,
+		},
+		"names sent": {
+			req:  CreateUserRequest{Email: "user@example.com", Role: "global:member", FirstName: "Ada", LastName: "Lovelace"},
+			want: `{"email":"user@example.com","role":"global:member","firstName":"Ada","lastName":"Lovelace"}`,
+		},
+		"empty names sent as null": {
+			sendNullForEmpty: true,
+			req:              CreateUserRequest{Email: "user@example.com", Role: "global:member"},
+			want:             `{"email":"user@example.com","role":"global:member","firstName":null,"lastName":null}`,
+		},
+		"names sent with null for empty": {
+			sendNullForEmpty: true,
+			req:              CreateUserRequest{Email: "user@example.com", FirstName: "Ada"},
+			want:             `{"email":"user@example.com","firstName":"Ada","lastName":null}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			c := newTestClientWithConfig(t, &Config{SendNullForEmpty: tc.sendNullForEmpty}, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("unexpected error reading request body: %s", err)
+				}
+				got = string(body)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com"}`))
+			})
+
+			if _, err := c.CreateUser(context.Background(), &tc.req); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected payload %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsSSOManagedError_otherErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"email is invalid"}`))
+	})
+
+	_, err := c.CreateUser(context.Background(), &CreateUserRequest{Email: "invalid"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if IsSSOManagedError(err) {
+		t.Errorf("expected non-SSO error, got: %s", err)
+	}
+}
+
+func TestGetUserByEmail_list(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","email":"other@example.com"},{"id":"2","email":"User@Example.com","role":"global:member"}],"nextCursor":null}`))
+	})
+
+	user, err := c.GetUserByEmail(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != "2" {
+		t.Errorf("expected user 2, got %s", user.ID)
+	}
+}
+
+func TestGetUserByEmail_direct(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/users":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+		case "/api/v1/users/user@example.com":
+			_, _ = w.Write([]byte(`{"id":"2","email":"user@example.com"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	user, err := c.GetUserByEmail(context.Background(), "user@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != "2" {
+		t.Errorf("expected user 2, got %s", user.ID)
+	}
+}
+
+func TestGetUserByEmail_listError(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+	})
+
+	_, err := c.GetUserByEmail(context.Background(), "user@example.com")
+	if !isUnauthorized(err) {
+		t.Fatalf("expected the 401 of the listing, got: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/v1/users" {
+		t.Errorf("expected no direct lookup after a rejected API key, got requests to %v", paths)
+	}
+}
+
+func TestGetUserByEmail_notFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","email":"other@example.com"}],"nextCursor":null}`))
+	})
+
+	_, err := c.GetUserByEmail(context.Background(), "missing@example.com")
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+	if got, want := err.Error(), `user with email "missing@example.com" not found`; got != want {
+		t.Errorf("expected error %q, got %q", want, got)
+	}
+}
+
+func TestGetUser_notFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	_, err := c.GetUser(context.Background(), "non-existent-id")
+	if !IsNotFound(err) {
+		t.Errorf("expected a 404 response to be reported as not found, got: %v", err)
+	}
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/users/1/role" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	if err := c.UpdateUserRole(context.Background(), "1", "global:admin"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := c.UpdateUserRole(context.Background(), "1", "admin")
+	if !IsValidationError(err) {
+		t.Errorf("expected a validation error for an unsupported role, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no request for an unsupported role, got %d requests in total", requests)
+	}
+}
+
+func TestVerifyConnection(t *testing.T) {
+	testCases := map[string]struct {
+		body    string
+		wantErr error
+	}{
+		"api":       {body: `{"data":[{"id":"1","email":"owner@example.com"}],"nextCursor":null}`},
+		"html page": {body: `<!DOCTYPE html><html></html>`, wantErr: ErrUnexpectedResponse},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var query string
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.RawQuery
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			err := c.VerifyConnection(context.Background())
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got: %v", tc.wantErr, err)
+			}
+			if query != "limit=1" {
+				t.Errorf("expected a single-item request, got query %q", query)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ListVariables retrieves all variables from the n8n instance, following the
+// pagination cursor until every page has been read.
+func (c *Client) ListVariables(ctx context.Context, opts *ListOptions) ([]Variable, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	return listAll[Variable](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, opts)
+
+		return pathWithQuery("/variables", params, opts.ExtraQuery)
+	})
+}
+
+// CreateVariable creates a new variable. The API does not return the created
+// variable, so callers needing its ID must list the variables.
+func (c *Client) CreateVariable(ctx context.Context, req *VariableRequest) error {
+	_, err := c.doRequest(ctx, http.MethodPost, "/variables", req)
+	return err
+}
+
+// UpdateVariable replaces the key and value of a variable.
+func (c *Client) UpdateVariable(ctx context.Context, id string, req *VariableRequest) error {
+	path := fmt.Sprintf("/variables/%s", id)
+	_, err := c.doRequest(ctx, http.MethodPut, path, req)
+	return err
+}
+
+// DeleteVariable deletes a variable.
+func (c *Client) DeleteVariable(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/variables/%s", id)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
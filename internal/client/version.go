@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// settingsPath is the path of the n8n frontend settings endpoint, which is
+// served outside the public API without authentication and includes the
+// instance version.
+const settingsPath = "/rest/settings"
+
+// workflowArchivalVersion is the first n8n version that archives workflows.
+var workflowArchivalVersion = Version{Major: 1, Minor: 94}
+
+// Version is an n8n instance version.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is minimum or a later version.
+func (v Version) AtLeast(minimum Version) bool {
+	if v.Major != minimum.Major {
+		return v.Major > minimum.Major
+	}
+	if v.Minor != minimum.Minor {
+		return v.Minor > minimum.Minor
+	}
+	return v.Patch >= minimum.Patch
+}
+
+// parseVersion parses a version such as "1.94.0", ignoring a leading "v" and
+// any pre-release or build suffix.
+func parseVersion(s string) (Version, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q: expected major.minor.patch", s)
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q: expected major.minor.patch", s)
+		}
+		numbers[i] = n
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// settingsResponse holds the parts of the frontend settings response the
+// client uses.
+type settingsResponse struct {
+	Data struct {
+		VersionCli string `json:"versionCli"`
+	} `json:"data"`
+}
+
+// DetectVersion fetches the instance version and caches it on the client
+// for Version and the feature checks that depend on it. It is meant to be
+// called once after NewClient. On error the version stays unknown, and
+// features behave as they do without version detection.
+func (c *Client) DetectVersion(ctx context.Context) (Version, error) {
+	version, err := c.fetchVersion(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+
+	c.version.Store(&version)
+	return version, nil
+}
+
+// fetchVersion reads the instance version from the frontend settings. The
+// request is sent once, without credentials, since the endpoint does not
+// need them.
+func (c *Client) fetchVersion(ctx context.Context) (Version, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+settingsPath, nil)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", jsonContentType)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Version{}, &APIError{StatusCode: resp.StatusCode, Body: string(body), RequestID: requestID(resp.Header)}
+	}
+
+	var settings settingsResponse
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return Version{}, fmt.Errorf("failed to unmarshal settings response: %w", err)
+	}
+	if settings.Data.VersionCli == "" {
+		return Version{}, fmt.Errorf("settings response did not include the instance version")
+	}
+
+	return parseVersion(settings.Data.VersionCli)
+}
+
+// Version returns the instance version found by DetectVersion, and false if
+// it was not detected.
+func (c *Client) Version() (Version, bool) {
+	version := c.version.Load()
+	if version == nil {
+		return Version{}, false
+	}
+
+	return *version, true
+}
+
+// SupportsWorkflowArchival reports whether the instance archives workflows.
+// It is false when the version is unknown, keeping the behavior of versions
+// without archival.
+func (c *Client) SupportsWorkflowArchival() bool {
+	version, ok := c.Version()
+	return ok && version.AtLeast(workflowArchivalVersion)
+}
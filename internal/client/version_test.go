@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := map[string]struct {
+		value   string
+		want    Version
+		wantErr bool
+	}{
+		"release":     {value: "1.94.0", want: Version{1, 94, 0}},
+		"prefixed":    {value: "v1.2.3", want: Version{1, 2, 3}},
+		"pre-release": {value: "1.95.0-rc.1", want: Version{1, 95, 0}},
+		"build":       {value: "1.95.1+exp", want: Version{1, 95, 1}},
+		"short":       {value: "1.94", wantErr: true},
+		"not numeric": {value: "1.x.0", wantErr: true},
+		"empty":       {value: "", wantErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseVersion(tc.value)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("expected error %t, got: %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	minimum := Version{1, 94, 0}
+
+	for version, want := range map[Version]bool{
+		{1, 93, 9}:  false,
+		{1, 94, 0}:  true,
+		{1, 94, 1}:  true,
+		{1, 100, 0}: true,
+		{0, 236, 0}: false,
+		{2, 0, 0}:   true,
+	} {
+		if got := version.AtLeast(minimum); got != want {
+			t.Errorf("expected %s.AtLeast(%s) to be %t", version, minimum, want)
+		}
+	}
+}
+
+func TestDetectVersion_gatesWorkflowArchival(t *testing.T) {
+	testCases := map[string]struct {
+		status       int
+		body         string
+		wantDetected bool
+		wantArchival bool
+	}{
+		"with archival": {
+			status:       http.StatusOK,
+			body:         `{"data":{"versionCli":"1.94.0"}}`,
+			wantDetected: true,
+			wantArchival: true,
+		},
+		"without archival": {
+			status:       http.StatusOK,
+			body:         `{"data":{"versionCli":"1.93.1"}}`,
+			wantDetected: true,
+		},
+		"endpoint unavailable": {
+			status: http.StatusNotFound,
+			body:   `Cannot GET /rest/settings`,
+		},
+		"version missing": {
+			status: http.StatusOK,
+			body:   `{"data":{}}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/rest/settings" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				if r.Header.Get("X-N8N-API-KEY") != "" {
+					t.Error("expected the settings request to be sent without the API key")
+				}
+
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			if c.SupportsWorkflowArchival() {
+				t.Fatal("expected archival to be unsupported before the version is detected")
+			}
+
+			_, err := c.DetectVersion(context.Background())
+			if tc.wantDetected != (err == nil) {
+				t.Fatalf("expected detection to succeed %t, got: %v", tc.wantDetected, err)
+			}
+
+			if _, ok := c.Version(); ok != tc.wantDetected {
+				t.Errorf("expected the version to be cached %t", tc.wantDetected)
+			}
+			if got := c.SupportsWorkflowArchival(); got != tc.wantArchival {
+				t.Errorf("expected SupportsWorkflowArchival %t, got %t", tc.wantArchival, got)
+			}
+			if got := c.WithAPIKey("scoped-api-key").SupportsWorkflowArchival(); got != tc.wantArchival {
+				t.Errorf("expected clients with another API key to share the version, got %t", got)
+			}
+			if requests != 1 {
+				t.Errorf("expected the version to be fetched once, got %d requests", requests)
+			}
+		})
+	}
+}
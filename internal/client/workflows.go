@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ListWorkflows retrieves all workflows matching opts, following the
+// pagination cursor until every page has been read. Pinned data is
+// excluded to keep responses small.
+func (c *Client) ListWorkflows(ctx context.Context, opts *ListWorkflowsOptions) ([]Workflow, error) {
+	if opts == nil {
+		opts = &ListWorkflowsOptions{}
+	}
+
+	return listAll[Workflow](ctx, c, func(cursor string) string {
+		params := c.listParams(cursor, &opts.ListOptions)
+		params.Set("excludePinnedData", "true")
+		if len(opts.Tags) > 0 {
+			params.Set("tags", strings.Join(opts.Tags, ","))
+		}
+		if opts.Active != nil {
+			params.Set("active", strconv.FormatBool(*opts.Active))
+		}
+		if opts.ProjectID != "" {
+			params.Set("projectId", opts.ProjectID)
+		}
+		if opts.Name != "" {
+			params.Set("name", opts.Name)
+		}
+
+		return pathWithQuery("/workflows", params, opts.ExtraQuery)
+	})
+}
+
+// GetWorkflow retrieves a workflow by ID, including its nodes, connections
+// and settings in Raw. Pinned data is excluded.
+func (c *Client) GetWorkflow(ctx context.Context, id string) (*Workflow, error) {
+	path := fmt.Sprintf("/workflows/%s?excludePinnedData=true", id)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflow Workflow
+	if err := json.Unmarshal(body, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow response: %w", err)
+	}
+	workflow.Raw = body
+
+	return &workflow, nil
+}
+
+// GetWorkflowTags retrieves the tags attached to a workflow.
+func (c *Client) GetWorkflowTags(ctx context.Context, id string) ([]Tag, error) {
+	path := fmt.Sprintf("/workflows/%s/tags", id)
+	body, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow tags response: %w", err)
+	}
+
+	return tags, nil
+}
+
+// CreateWorkflow creates a new, inactive workflow.
+func (c *Client) CreateWorkflow(ctx context.Context, req *WorkflowRequest) (*Workflow, error) {
+	body, err := c.doRequest(ctx, http.MethodPost, "/workflows", req)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := decodeWorkflow(body, "create workflow")
+	if err != nil {
+		return nil, err
+	}
+
+	if workflow.ID == "" {
+		return nil, fmt.Errorf("create workflow response did not include a workflow ID, which may mean the instance runs an API version this provider does not support")
+	}
+
+	return workflow, nil
+}
+
+// UpdateWorkflow replaces the name, nodes, connections and settings of a
+// workflow. Its active state and tags are changed with their own endpoints.
+func (c *Client) UpdateWorkflow(ctx context.Context, id string, req *WorkflowRequest) (*Workflow, error) {
+	path := fmt.Sprintf("/workflows/%s", id)
+	body, err := c.doRequest(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkflow(body, "update workflow")
+}
+
+// DeleteWorkflow deletes a workflow.
+func (c *Client) DeleteWorkflow(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/workflows/%s", id)
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+// ActivateWorkflow activates a workflow, registering its triggers.
+func (c *Client) ActivateWorkflow(ctx context.Context, id string) (*Workflow, error) {
+	path := fmt.Sprintf("/workflows/%s/activate", id)
+	body, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkflow(body, "activate workflow")
+}
+
+// DeactivateWorkflow deactivates a workflow, unregistering its triggers.
+func (c *Client) DeactivateWorkflow(ctx context.Context, id string) (*Workflow, error) {
+	path := fmt.Sprintf("/workflows/%s/deactivate", id)
+	body, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeWorkflow(body, "deactivate workflow")
+}
+
+// UpdateWorkflowTags replaces the tags attached to a workflow with the tags
+// with the given IDs, returning the tags now attached.
+func (c *Client) UpdateWorkflowTags(ctx context.Context, id string, tagIDs []string) ([]Tag, error) {
+	req := make([]WorkflowTagRequest, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		req = append(req, WorkflowTagRequest{ID: tagID})
+	}
+
+	path := fmt.Sprintf("/workflows/%s/tags", id)
+	body, err := c.doRequest(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow tags response: %w", err)
+	}
+
+	return tags, nil
+}
+
+// decodeWorkflow decodes a workflow response body, keeping the body in Raw.
+func decodeWorkflow(body []byte, action string) (*Workflow, error) {
+	var workflow Workflow
+	if err := json.Unmarshal(body, &workflow); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s response: %w", action, err)
+	}
+	workflow.Raw = body
+
+	return &workflow, nil
+}
@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListWorkflows_tagFilterAndPagination(t *testing.T) {
+	var cursors []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("tags"); got != "production,billing" {
+			t.Errorf("expected tags filter production,billing, got %q", got)
+		}
+		if got := r.URL.Query().Get("active"); got != "true" {
+			t.Errorf("expected active filter true, got %q", got)
+		}
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.Header().Set("Content-Type", "application/json")
+		if cursor == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"wf1","name":"First","active":true}],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"wf2","name":"Second","active":true}],"nextCursor":null}`))
+	})
+
+	active := true
+	workflows, err := c.ListWorkflows(context.Background(), &ListWorkflowsOptions{
+		Tags:   []string{"production", "billing"},
+		Active: &active,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(workflows) != 2 || workflows[0].ID != "wf1" || workflows[1].ID != "wf2" {
+		t.Errorf("expected workflows from both pages, got %+v", workflows)
+	}
+	if len(cursors) != 2 || cursors[1] != "page2" {
+		t.Errorf("expected the second request to use the returned cursor, got %q", cursors)
+	}
+}
+
+func TestGetTagByName_notFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"t1","name":"Production"}],"nextCursor":null}`))
+	})
+
+	tag, err := c.GetTagByName(context.Background(), "Production")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag.ID != "t1" {
+		t.Errorf("expected tag t1, got %s", tag.ID)
+	}
+
+	if _, err := c.GetTagByName(context.Background(), "production"); !IsNotFound(err) {
+		t.Errorf("expected tag names to match exactly and return a not found error, got: %v", err)
+	}
+}
+
+func TestListWorkflows_projectID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("projectId"); got != "p1" {
+			t.Errorf("expected projectId p1, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	if _, err := c.ListWorkflows(context.Background(), &ListWorkflowsOptions{ProjectID: "p1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestGetWorkflow(t *testing.T) {
+	body := `{"id":"wf1","name":"Backup","active":false,"nodes":[],"connections":{},"settings":{}}`
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workflows/wf1" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("excludePinnedData"); got != "true" {
+			t.Errorf("expected pinned data to be excluded, got excludePinnedData=%q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	})
+
+	workflow, err := c.GetWorkflow(context.Background(), "wf1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if workflow.ID != "wf1" || workflow.Name != "Backup" {
+		t.Errorf("unexpected workflow %+v", workflow)
+	}
+	if string(workflow.Raw) != body {
+		t.Errorf("expected the raw response body, got %s", workflow.Raw)
+	}
+}
+
+func TestGetWorkflowTags(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workflows/wf1/tags" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"t1","name":"production"},{"id":"t2","name":"billing"}]`))
+	})
+
+	tags, err := c.GetWorkflowTags(context.Background(), "wf1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tags) != 2 || tags[0].ID != "t1" || tags[1].Name != "billing" {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+}
+
+func TestCreateWorkflow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/workflows" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if want := `{"name":"Backup","nodes":[],"connections":{},"settings":{}}`; string(body) != want {
+			t.Errorf("expected body %s, got %s", want, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"wf1","name":"Backup","active":false,"versionId":"v1","nodes":[],"connections":{},"settings":{}}`))
+	})
+
+	workflow, err := c.CreateWorkflow(context.Background(), &WorkflowRequest{
+		Name:        "Backup",
+		Nodes:       json.RawMessage(`[]`),
+		Connections: json.RawMessage(`{}`),
+		Settings:    json.RawMessage(`{}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if workflow.ID != "wf1" || workflow.VersionID != "v1" || string(workflow.Nodes) != `[]` {
+		t.Errorf("unexpected workflow %+v", workflow)
+	}
+}
+
+func TestCreateWorkflow_missingID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	})
+
+	if _, err := c.CreateWorkflow(context.Background(), &WorkflowRequest{Name: "Backup"}); err == nil {
+		t.Fatal("expected an error for a response without a workflow ID")
+	}
+}
+
+func TestUpdateWorkflowTags(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/workflows/wf1/tags" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if want := `[{"id":"t1"},{"id":"t2"}]`; string(body) != want {
+			t.Errorf("expected body %s, got %s", want, body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"t1","name":"production"},{"id":"t2","name":"billing"}]`))
+	})
+
+	tags, err := c.UpdateWorkflowTags(context.Background(), "wf1", []string{"t1", "t2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(tags) != 2 {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+}
+
+func TestActivateWorkflow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/workflows/wf1/activate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"wf1","active":true}`))
+	})
+
+	workflow, err := c.ActivateWorkflow(context.Background(), "wf1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !workflow.Active {
+		t.Error("expected the activated workflow")
+	}
+}
+
+func TestListWorkflows_activeFilter(t *testing.T) {
+	workflows := map[string]string{
+		"":      `{"data":[{"id":"wf1","active":true},{"id":"wf2","active":false}],"nextCursor":null}`,
+		"true":  `{"data":[{"id":"wf1","active":true}],"nextCursor":null}`,
+		"false": `{"data":[{"id":"wf2","active":false}],"nextCursor":null}`,
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		active, set := r.URL.Query()["active"]
+		key := ""
+		if set {
+			key = active[0]
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(workflows[key]))
+	})
+
+	active, inactive := true, false
+	testCases := map[string]struct {
+		active *bool
+		want   []string
+	}{
+		"unset":    {want: []string{"wf1", "wf2"}},
+		"active":   {active: &active, want: []string{"wf1"}},
+		"inactive": {active: &inactive, want: []string{"wf2"}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := c.ListWorkflows(context.Background(), &ListWorkflowsOptions{Active: tc.active})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			ids := make([]string, 0, len(got))
+			for _, workflow := range got {
+				ids = append(ids, workflow.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected workflows %v, got %v", tc.want, ids)
+			}
+		})
+	}
+}
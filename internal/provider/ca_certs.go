@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// caCertPool returns the system certificate pool extended with the
+// certificates of caCertFile and caCertPEM, or nil when neither is set so
+// the client keeps the default TLS configuration.
+func caCertPool(caCertFile, caCertPEM types.String) (*x509.CertPool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if caCertFile.ValueString() == "" && caCertPEM.ValueString() == "" {
+		return nil, diags
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if file := caCertFile.ValueString(); file != "" {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read CA Certificate File",
+				fmt.Sprintf("The CA certificate file %q could not be read: %s", file, err),
+			)
+		} else if !pool.AppendCertsFromPEM(pem) {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid CA Certificate File",
+				fmt.Sprintf("The CA certificate file %q does not contain any PEM-encoded certificates.", file),
+			)
+		}
+	}
+
+	if value := caCertPEM.ValueString(); value != "" {
+		pem, err := decodeCACertPEM(value)
+		if err == nil && !pool.AppendCertsFromPEM(pem) {
+			err = fmt.Errorf("no certificates could be parsed")
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_pem"),
+				"Invalid CA Certificate PEM",
+				fmt.Sprintf("The ca_cert_pem value must contain PEM-encoded certificates, optionally base64-encoded: %s", err),
+			)
+		}
+	}
+
+	return pool, diags
+}
+
+// decodeCACertPEM returns the PEM in value, which is either PEM itself or
+// PEM encoded as base64, e.g. when stored in a CI variable.
+func decodeCACertPEM(value string) ([]byte, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "-----BEGIN") {
+		return []byte(trimmed), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(trimmed), ""))
+	if err != nil {
+		return nil, fmt.Errorf("value is neither PEM nor valid base64")
+	}
+
+	return decoded, nil
+}
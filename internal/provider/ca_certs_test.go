@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCACertPEM returns a self-signed CA certificate encoded as PEM.
+func testCACertPEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Internal CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCACertPool(t *testing.T) {
+	certPEM := testCACertPEM(t)
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, certPEM, 0o600); err != nil {
+		t.Fatalf("unexpected error writing CA file: %s", err)
+	}
+
+	fromFile, diags := caCertPool(types.StringValue(file), types.StringNull())
+	if diags.HasError() {
+		t.Fatalf("unexpected error from file: %v", diags)
+	}
+
+	fromPEM, diags := caCertPool(types.StringNull(), types.StringValue(string(certPEM)))
+	if diags.HasError() {
+		t.Fatalf("unexpected error from inline PEM: %v", diags)
+	}
+
+	fromBase64, diags := caCertPool(types.StringNull(), types.StringValue(base64.StdEncoding.EncodeToString(certPEM)))
+	if diags.HasError() {
+		t.Fatalf("unexpected error from base64 PEM: %v", diags)
+	}
+
+	if !fromFile.Equal(fromPEM) {
+		t.Error("expected the file and inline PEM to produce equivalent cert pools")
+	}
+	if !fromFile.Equal(fromBase64) {
+		t.Error("expected the file and base64 PEM to produce equivalent cert pools")
+	}
+
+	system, err := x509.SystemCertPool()
+	if err != nil {
+		system = x509.NewCertPool()
+	}
+	if fromFile.Equal(system) {
+		t.Error("expected the CA certificate to be added to the system pool")
+	}
+}
+
+func TestCACertPool_unset(t *testing.T) {
+	pool, diags := caCertPool(types.StringNull(), types.StringValue(""))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if pool != nil {
+		t.Error("expected no cert pool when no CA certificates are configured")
+	}
+}
+
+func TestCACertPool_invalid(t *testing.T) {
+	testCases := map[string]struct {
+		caCertFile types.String
+		caCertPEM  types.String
+		wantPath   path.Path
+	}{
+		"missing file": {
+			caCertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem")),
+			wantPath:   path.Root("ca_cert_file"),
+		},
+		"file without certificates": {
+			caCertFile: types.StringValue(os.DevNull),
+			wantPath:   path.Root("ca_cert_file"),
+		},
+		"invalid PEM": {
+			caCertPEM: types.StringValue("-----BEGIN CERTIFICATE-----\nnot a certificate\n-----END CERTIFICATE-----"),
+			wantPath:  path.Root("ca_cert_pem"),
+		},
+		"not PEM or base64": {
+			caCertPEM: types.StringValue("not a certificate!"),
+			wantPath:  path.Root("ca_cert_pem"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := caCertPool(testCase.caCertFile, testCase.caCertPEM)
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got: %v", diags)
+			}
+			withPath, ok := diags.Errors()[0].(interface{ Path() path.Path })
+			if !ok || !withPath.Path().Equal(testCase.wantPath) {
+				t.Errorf("expected error on %s, got: %v", testCase.wantPath, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// changeAction is the kind of change recorded in a change report.
+type changeAction string
+
+const (
+	changeCreated changeAction = "created"
+	changeUpdated changeAction = "updated"
+	changeDeleted changeAction = "deleted"
+)
+
+// changeReportEntry identifies a resource changed by the provider.
+type changeReportEntry struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// changeReportFile is the JSON document written to change_report_file.
+type changeReportFile struct {
+	Created []changeReportEntry `json:"created"`
+	Updated []changeReportEntry `json:"updated"`
+	Deleted []changeReportEntry `json:"deleted"`
+}
+
+// changeReport accumulates the changes made by resources during an apply
+// and writes them to a file. Terraform applies resources concurrently, so
+// it is guarded by a mutex. A nil *changeReport records nothing, so
+// resources can call it unconditionally.
+type changeReport struct {
+	path string
+
+	mu     sync.Mutex
+	report changeReportFile
+}
+
+// newChangeReport returns a change report written to path, or nil if path
+// is empty.
+func newChangeReport(path string) *changeReport {
+	if path == "" {
+		return nil
+	}
+
+	return &changeReport{
+		path: path,
+		report: changeReportFile{
+			Created: []changeReportEntry{},
+			Updated: []changeReportEntry{},
+			Deleted: []changeReportEntry{},
+		},
+	}
+}
+
+// record adds a change to the report and rewrites the file, since the
+// provider is not told when an apply ends. A failure to write the file is
+// added to diags as a warning, as the change itself succeeded.
+func (r *changeReport) record(diags *diag.Diagnostics, action changeAction, resourceType, id string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := changeReportEntry{Type: resourceType, ID: id}
+	switch action {
+	case changeCreated:
+		r.report.Created = append(r.report.Created, entry)
+	case changeUpdated:
+		r.report.Updated = append(r.report.Updated, entry)
+	case changeDeleted:
+		r.report.Deleted = append(r.report.Deleted, entry)
+	}
+
+	if err := r.write(); err != nil {
+		diags.AddWarning(
+			"Unable to Write Change Report",
+			fmt.Sprintf("The %s %s was %s, but the change report could not be written to %q: %s", resourceType, id, action, r.path, err),
+		)
+	}
+}
+
+// write replaces the file with the current report. The report is written
+// to a temporary file first, so readers never see a partial document.
+func (r *changeReport) write() error {
+	content, err := json.MarshalIndent(r.report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), r.path)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readChangeReport decodes the change report written to path.
+func readChangeReport(t *testing.T, path string) changeReportFile {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read change report: %s", err)
+	}
+
+	var report changeReportFile
+	if err := json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("unable to decode change report %s: %s", content, err)
+	}
+
+	return report
+}
+
+func TestChangeReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.json")
+	report := newChangeReport(path)
+
+	var diags diag.Diagnostics
+	report.record(&diags, changeCreated, "n8ncloud_user", "1")
+	report.record(&diags, changeCreated, "n8ncloud_variables", "variables")
+	report.record(&diags, changeUpdated, "n8ncloud_user", "2")
+	report.record(&diags, changeDeleted, "n8ncloud_user", "3")
+
+	if diags.HasError() || len(diags.Warnings()) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := changeReportFile{
+		Created: []changeReportEntry{{Type: "n8ncloud_user", ID: "1"}, {Type: "n8ncloud_variables", ID: "variables"}},
+		Updated: []changeReportEntry{{Type: "n8ncloud_user", ID: "2"}},
+		Deleted: []changeReportEntry{{Type: "n8ncloud_user", ID: "3"}},
+	}
+	if got := readChangeReport(t, path); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected report %+v, got %+v", want, got)
+	}
+}
+
+func TestChangeReport_unwritable(t *testing.T) {
+	report := newChangeReport(filepath.Join(t.TempDir(), "missing", "changes.json"))
+
+	var diags diag.Diagnostics
+	report.record(&diags, changeCreated, "n8ncloud_user", "1")
+
+	if diags.HasError() {
+		t.Fatalf("expected a write failure not to fail the change, got: %v", diags)
+	}
+	if len(diags.Warnings()) != 1 {
+		t.Errorf("expected a warning for the write failure, got: %v", diags)
+	}
+}
+
+func TestChangeReport_disabled(t *testing.T) {
+	report := newChangeReport("")
+	if report != nil {
+		t.Fatalf("expected no report without a path, got %+v", report)
+	}
+
+	// A nil report must be safe to record to.
+	var diags diag.Diagnostics
+	report.record(&diags, changeCreated, "n8ncloud_user", "1")
+}
+
+func TestUserResourceDelete_changeReport(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	path := filepath.Join(t.TempDir(), "changes.json")
+	r := &UserResource{client: c, changeReport: newChangeReport(path)}
+	userSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "1"),
+		"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+		"role":  tftypes.NewValue(tftypes.String, "global:member"),
+	})
+
+	resp := &fwresource.DeleteResponse{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: tfsdk.State{Schema: userSchema, Raw: prior}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	want := []changeReportEntry{{Type: "n8ncloud_user", ID: "1"}}
+	if got := readChangeReport(t, path).Deleted; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected deleted entries %+v, got %+v", want, got)
+	}
+}
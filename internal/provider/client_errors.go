@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// addClientError adds a diagnostic for an API request that failed while
+// trying to action, e.g. "create user". Requests rejected because the API key
+// lacks a scope get their own diagnostic so they are not mistaken for a
+// wrong key.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	if client.IsInsufficientScopeError(err) {
+		scope := "a scope"
+		if required := client.RequiredScope(err); required != "" {
+			scope = fmt.Sprintf("the %s scope", required)
+		}
+
+		diags.AddError(
+			"Insufficient API Key Scope",
+			fmt.Sprintf("Unable to %s because the API key lacks %s required for this operation. "+
+				"The key itself is valid: create a key with the missing scope or use an instance owner key, "+
+				"either for the provider or, on resources that support it, with the api_key attribute. API error: %s", action, scope, err),
+		)
+		return
+	}
+
+	if client.IsValidationError(err) {
+		diags.AddError("Invalid Request", fmt.Sprintf("Unable to %s: %s", action, err))
+		return
+	}
+
+	if addTimeoutError(diags, action, err) {
+		return
+	}
+
+	if addAPIErrorHint(diags, action, err) {
+		return
+	}
+
+	// The status code and request ID let n8n support find the failed request
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s, the API responded with status %d: %s", action, apiErr.StatusCode, err))
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+}
+
+// addTimeoutError adds a diagnostic for a request that was aborted before
+// the API answered, telling an interrupted run apart from the timeouts that
+// can abort it: the provider timeout of each attempt, the provider
+// global_deadline and the timeouts of the operation. It reports false and adds
+// nothing for other errors.
+func addTimeoutError(diags *diag.Diagnostics, action string, err error) bool {
+	switch {
+	case errors.Is(err, client.ErrRequestTimeout):
+		diags.AddError(
+			"API Request Timed Out",
+			fmt.Sprintf("Unable to %s because the n8n instance did not respond within the provider timeout. Timed-out requests are not retried. "+
+				"The instance may be slow or overloaded; if it regularly needs longer, increase the provider timeout rather than max_retries. Error: %s", action, err),
+		)
+	case errors.Is(err, client.ErrDeadlineExceeded):
+		diags.AddError(
+			"Provider Deadline Exceeded",
+			fmt.Sprintf("Unable to %s because the provider global_deadline has passed, so no more requests are sent. Error: %s", action, err),
+		)
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError(
+			"Operation Timed Out",
+			fmt.Sprintf("Unable to %s within the operation timeout, which includes retries and waiting on the n8n instance. "+
+				"Increase it in the timeouts block of the resource if the instance regularly needs longer. Error: %s", action, err),
+		)
+	case errors.Is(err, context.Canceled):
+		diags.AddError(
+			"Operation Canceled",
+			fmt.Sprintf("Unable to %s because the operation was canceled, e.g. by interrupting Terraform. "+
+				"A request already sent may still have been applied by the n8n instance, so refresh before retrying. Error: %s", action, err),
+		)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// addAPIErrorHint adds a diagnostic for an API error that carries a hint,
+// summarized by the API's error code and message with the hint, which
+// usually says how to fix the problem, as the detail. It reports false and
+// adds nothing if err is not an APIError with a hint.
+func addAPIErrorHint(diags *diag.Diagnostics, action string, err error) bool {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Hint == "" {
+		return false
+	}
+
+	summary := apiErr.Message
+	if summary == "" {
+		summary = "Client Error"
+	}
+	if apiErr.Code != "" {
+		summary = apiErr.Code + ": " + summary
+	}
+
+	detail := fmt.Sprintf("Unable to %s, the API responded with status %d: %s", action, apiErr.StatusCode, apiErr.Hint)
+	if apiErr.RequestID != "" {
+		detail += fmt.Sprintf(" (request ID: %s)", apiErr.RequestID)
+	}
+
+	diags.AddError(summary, detail)
+	return true
+}
+
+// addConnectionError adds a diagnostic for a failed connection check against
+// instanceURL, telling a rejected API key apart from an unreachable host and
+// from a URL that does not point at the n8n API. A key that is valid but
+// cannot list users only gets a warning, since the connection works.
+func addConnectionError(diags *diag.Diagnostics, instanceURL string, err error) {
+	var apiErr *client.APIError
+	var dnsErr *net.DNSError
+	var urlErr *url.Error
+
+	switch {
+	case client.IsInsufficientScopeError(err):
+		diags.AddWarning(
+			"Unable to Fully Verify n8n Cloud Connection",
+			fmt.Sprintf("The instance at %s accepted the API key, but the key cannot list users, which the connection check uses. "+
+				"Resources needing that scope will fail. API error: %s", instanceURL, err),
+		)
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"n8n Cloud Authentication Failed",
+			fmt.Sprintf("n8n Cloud authentication failed: check api_key and instance_url. The instance at %s rejected the API key with status %d, so check that the key is correct, has not expired or been revoked, and belongs to this instance. "+
+				"To configure the provider without checking the credentials, e.g. for an offline plan, set skip_credentials_validation to true.", instanceURL, apiErr.StatusCode),
+		)
+	case errors.Is(err, client.ErrUnexpectedResponse) || client.IsNotFound(err):
+		diags.AddAttributeError(
+			path.Root("instance_url"),
+			"Invalid n8n Cloud Instance URL",
+			fmt.Sprintf("The server at %s responded, but not like the n8n public API. Check that instance_url is the base URL of the instance, without the /api/v1 path, and that the public API is enabled. Error: %s", instanceURL, err),
+		)
+	case errors.As(err, &dnsErr):
+		diags.AddAttributeError(
+			path.Root("instance_url"),
+			"Unknown n8n Cloud Instance Host",
+			fmt.Sprintf("The host of %s could not be resolved. Check instance_url for typos. Error: %s", instanceURL, err),
+		)
+	case errors.As(err, &urlErr):
+		diags.AddError(
+			"Unable to Connect to n8n Cloud Instance",
+			fmt.Sprintf("The instance at %s could not be reached. Check network access, proxies and TLS settings such as ca_cert_file. Error: %s", instanceURL, err),
+		)
+	default:
+		diags.AddError(
+			"Unable to Verify n8n Cloud Connection",
+			fmt.Sprintf("The connection check against %s failed: %s", instanceURL, err),
+		)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAddClientError(t *testing.T) {
+	testCases := map[string]struct {
+		status      int
+		header      http.Header
+		body        string
+		wantSummary string
+		wantDetail  string
+	}{
+		"insufficient scope": {
+			status:      http.StatusForbidden,
+			body:        `{"message":"API key is missing the required scope user:create"}`,
+			wantSummary: "Insufficient API Key Scope",
+			wantDetail:  "lacks the user:create scope",
+		},
+		"wrong key": {
+			status:      http.StatusUnauthorized,
+			body:        `{"message":"unauthorized"}`,
+			wantSummary: "Client Error",
+			wantDetail:  "Unable to create user, the API responded with status 401:",
+		},
+		"request ID": {
+			status:      http.StatusConflict,
+			header:      http.Header{"X-Request-Id": {"req-1"}},
+			body:        `{"code":"409","message":"Conflict"}`,
+			wantSummary: "Client Error",
+			wantDetail:  "Unable to create user, the API responded with status 409: API error: 409 - Conflict (request ID: req-1)",
+		},
+		"hint": {
+			status:      http.StatusBadRequest,
+			body:        `{"code":"400","message":"Email already exists","hint":"Import the existing user instead"}`,
+			wantSummary: "400: Email already exists",
+			wantDetail:  "Unable to create user, the API responded with status 400: Import the existing user instead",
+		},
+		"hint with request ID": {
+			status:      http.StatusBadRequest,
+			header:      http.Header{"Cf-Ray": {"ray-1"}},
+			body:        `{"code":"400","message":"Email already exists","hint":"Import the existing user instead"}`,
+			wantSummary: "400: Email already exists",
+			wantDetail:  "Import the existing user instead (request ID: ray-1)",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for key, values := range tc.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})
+
+			_, err := c.GetRateLimit(context.Background())
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			var diags diag.Diagnostics
+			addClientError(&diags, "create user", err)
+
+			if len(diags) != 1 || diags[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q diagnostic, got: %v", tc.wantSummary, diags)
+			}
+			if !strings.Contains(diags[0].Detail(), tc.wantDetail) {
+				t.Errorf("expected detail to contain %q, got: %s", tc.wantDetail, diags[0].Detail())
+			}
+		})
+	}
+}
+
+func TestAddClientError_validation(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, "update user role", &client.ValidationError{Field: "role", Value: "owner", Message: "must be one of global:admin, global:member"})
+
+	if len(diags) != 1 || diags[0].Summary() != "Invalid Request" {
+		t.Fatalf("expected an Invalid Request diagnostic, got: %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail(), `invalid role "owner"`) {
+		t.Errorf("expected detail to name the invalid role, got: %s", diags[0].Detail())
+	}
+}
+
+func TestAddClientError_timeouts(t *testing.T) {
+	started := make(chan struct{}, 1)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		// Hang until the client gives up on the request.
+		<-r.Context().Done()
+	})
+
+	interrupted := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			<-started
+			cancel()
+		}()
+		return ctx
+	}
+	timedOut := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		t.Cleanup(cancel)
+		// Take the start of the request so that later cases wait for theirs
+		go func() { <-started }()
+		return ctx
+	}
+
+	testCases := map[string]struct {
+		ctx         func(t *testing.T) context.Context
+		wantSummary string
+	}{
+		"canceled mid-request": {ctx: interrupted, wantSummary: "Operation Canceled"},
+		"operation timeout":    {ctx: timedOut, wantSummary: "Operation Timed Out"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.GetRateLimit(tc.ctx(t))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			var diags diag.Diagnostics
+			addClientError(&diags, "read user", err)
+
+			if len(diags) != 1 || diags[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q diagnostic, got: %v", tc.wantSummary, diags)
+			}
+		})
+	}
+}
+
+func TestAddClientError_clientTimeouts(t *testing.T) {
+	testCases := map[string]struct {
+		err         error
+		wantSummary string
+	}{
+		// The attempt timeout wraps the context error of the attempt
+		"request timeout":  {err: fmt.Errorf("%w after 30s: %w", client.ErrRequestTimeout, context.DeadlineExceeded), wantSummary: "API Request Timed Out"},
+		"global deadline":  {err: fmt.Errorf("%w at 2024-01-01T00:00:00Z, GET /users was not sent", client.ErrDeadlineExceeded), wantSummary: "Provider Deadline Exceeded"},
+		"wrapped canceled": {err: fmt.Errorf("failed to perform request: %w", context.Canceled), wantSummary: "Operation Canceled"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(&diags, "read user", tc.err)
+
+			if len(diags) != 1 || diags[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q diagnostic, got: %v", tc.wantSummary, diags)
+			}
+		})
+	}
+}
+
+func TestAddConnectionError(t *testing.T) {
+	testCases := map[string]struct {
+		err         error
+		wantSummary string
+		wantError   bool
+	}{
+		"rejected key": {
+			err:         &client.APIError{StatusCode: http.StatusUnauthorized, Body: `{"message":"unauthorized"}`},
+			wantSummary: "n8n Cloud Authentication Failed",
+			wantError:   true,
+		},
+		"key without users scope": {
+			err:         &client.APIError{StatusCode: http.StatusForbidden, Body: `{"message":"API key is missing the required scope user:list"}`},
+			wantSummary: "Unable to Fully Verify n8n Cloud Connection",
+		},
+		"not the api": {
+			err:         fmt.Errorf("%w: invalid character '<'", client.ErrUnexpectedResponse),
+			wantSummary: "Invalid n8n Cloud Instance URL",
+			wantError:   true,
+		},
+		"wrong path": {
+			err:         &client.APIError{StatusCode: http.StatusNotFound, Body: "Not Found"},
+			wantSummary: "Invalid n8n Cloud Instance URL",
+			wantError:   true,
+		},
+		"unknown host": {
+			err:         &url.Error{Op: "Get", URL: "https://typo.app.n8n.cloud/api/v1/users", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "typo.app.n8n.cloud", IsNotFound: true}}},
+			wantSummary: "Unknown n8n Cloud Instance Host",
+			wantError:   true,
+		},
+		"unreachable": {
+			err:         &url.Error{Op: "Get", URL: "https://example.app.n8n.cloud/api/v1/users", Err: errors.New("connection refused")},
+			wantSummary: "Unable to Connect to n8n Cloud Instance",
+			wantError:   true,
+		},
+		"other": {
+			err:         &client.APIError{StatusCode: http.StatusInternalServerError, Body: "boom"},
+			wantSummary: "Unable to Verify n8n Cloud Connection",
+			wantError:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addConnectionError(&diags, "https://example.app.n8n.cloud", tc.err)
+
+			if len(diags) != 1 || diags[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q diagnostic, got: %v", tc.wantSummary, diags)
+			}
+			if diags.HasError() != tc.wantError {
+				t.Errorf("expected error %t, got diagnostics: %v", tc.wantError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &CredentialEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &CredentialEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &CredentialEphemeralResource{}
+
+// credentialIDPrivateKey is the private data key the ID of the opened
+// credential is kept under until it is closed.
+const credentialIDPrivateKey = "credential_id"
+
+func NewCredentialEphemeralResource() ephemeral.EphemeralResource {
+	return &CredentialEphemeralResource{}
+}
+
+// CredentialEphemeralResource defines the ephemeral resource implementation.
+type CredentialEphemeralResource struct {
+	client *client.Client
+}
+
+// CredentialEphemeralResourceModel describes the ephemeral resource data
+// model.
+type CredentialEphemeralResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Data         types.String `tfsdk:"data"`
+	ValidateData types.Bool   `tfsdk:"validate_data"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+}
+
+func (r *CredentialEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential"
+}
+
+func (r *CredentialEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Credential ephemeral resource for short-lived secrets. A credential is created when Terraform opens the ephemeral resource and deleted when it closes it at the end of the run, so the secret `data` is never stored in state or plan files. " +
+			"Use the `id` within the same run, e.g. for a workflow that runs once with rotating secrets. The credential no longer exists after the run.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the credential",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the credential",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The name of the credential type, such as `githubApi` or `slackOAuth2Api`. The `n8ncloud_credential_schema` data source describes the data each type expects.",
+				Required:            true,
+			},
+			"data": schema.StringAttribute{
+				MarkdownDescription: "The secret data of the credential, as a JSON object string, e.g. from `jsonencode()`",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"validate_data": schema.BoolAttribute{
+				MarkdownDescription: "Whether to check `data` against the schema of the credential type before creating the credential, reporting missing required fields and fields the type does not know. The check reads the schema with an additional request. Defaults to `true`.",
+				Optional:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the credential was created",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *CredentialEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *CredentialEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data CredentialEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentialData, err := decodeJSONObject(data.Data.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("data"),
+			"Invalid Credential Data",
+			fmt.Sprintf("The credential data must be a JSON object: %s", err),
+		)
+		return
+	}
+
+	if data.ValidateData.IsNull() || data.ValidateData.ValueBool() {
+		r.validateData(ctx, &resp.Diagnostics, data.Type.ValueString(), credentialData)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	credential, err := r.client.CreateCredential(ctx, &client.CreateCredentialRequest{
+		Name: data.Name.ValueString(),
+		Type: data.Type.ValueString(),
+		Data: credentialData,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create credential", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Created ephemeral n8n cloud credential", map[string]interface{}{
+		"id": credential.ID,
+	})
+
+	// Keep the ID so that Close can delete the credential. Private data
+	// must be JSON.
+	privateID, err := json.Marshal(credential.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Store Credential ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, credentialIDPrivateKey, privateID)...)
+
+	data.ID = types.StringValue(credential.ID)
+	data.CreatedAt = types.StringValue(credential.CreatedAt.Format(time.RFC3339Nano))
+
+	// Save data into the ephemeral result
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// validateData checks credentialData against the schema of the credential
+// type credentialType, adding a diagnostic for each required field it lacks
+// and, if the schema allows no other fields, for each field the schema does
+// not define.
+func (r *CredentialEphemeralResource) validateData(ctx context.Context, diags *diag.Diagnostics, credentialType string, credentialData map[string]interface{}) {
+	raw, err := r.client.GetCredentialSchema(ctx, credentialType)
+	if client.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"Credential Type Not Found",
+			fmt.Sprintf("The n8n instance has no credential type %q. Credential type names are case-sensitive, such as githubApi.", credentialType),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(diags, "read credential schema", err)
+		return
+	}
+
+	schemaObject, err := decodeJSONObject(string(raw))
+	if err != nil {
+		diags.AddError("Invalid Credential Schema", fmt.Sprintf("The API returned an invalid schema for credential type %q: %s", credentialType, err))
+		return
+	}
+
+	required, err := schemaRequiredFields(schemaObject)
+	if err != nil {
+		diags.AddError("Invalid Credential Schema", fmt.Sprintf("The API returned an invalid schema for credential type %q: %s", credentialType, err))
+		return
+	}
+
+	for _, field := range required {
+		if _, ok := credentialData[field]; !ok {
+			diags.AddAttributeError(
+				path.Root("data"),
+				"Missing Credential Data Field",
+				fmt.Sprintf("Credential type %q requires the field %q in data. Set validate_data to false to skip this check.", credentialType, field),
+			)
+		}
+	}
+
+	properties, _ := schemaObject["properties"].(map[string]interface{})
+	if additional, ok := schemaObject["additionalProperties"].(bool); !ok || additional {
+		return
+	}
+
+	fields := make([]string, 0, len(credentialData))
+	for field := range credentialData {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if _, ok := properties[field]; !ok {
+			diags.AddAttributeError(
+				path.Root("data"),
+				"Unknown Credential Data Field",
+				fmt.Sprintf("Credential type %q has no field %q. Set validate_data to false to skip this check.", credentialType, field),
+			)
+		}
+	}
+}
+
+func (r *CredentialEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateID, diags := req.Private.GetKey(ctx, credentialIDPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || len(privateID) == 0 {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(privateID, &id); err != nil {
+		resp.Diagnostics.AddError("Unable to Read Credential ID", err.Error())
+		return
+	}
+
+	err := r.client.DeleteCredential(ctx, id)
+	if client.IsNotFound(err) {
+		tflog.Debug(ctx, "Ephemeral n8n cloud credential was already deleted", map[string]interface{}{
+			"id": id,
+		})
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete credential", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted ephemeral n8n cloud credential", map[string]interface{}{
+		"id": id,
+	})
+}
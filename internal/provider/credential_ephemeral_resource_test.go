@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCredentialEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"n8ncloud": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":     echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "n8ncloud_credential" "test" {
+  name = "tf-acc-test-ephemeral"
+  type = "httpHeaderAuth"
+  data = jsonencode({
+    name  = "Authorization"
+    value = "Bearer tf-acc-test"
+  })
+}
+
+provider "echo" {
+  data = ephemeral.n8ncloud_credential.test.name
+}
+
+resource "echo" "test" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data"), knownvalue.StringExact("tf-acc-test-ephemeral")),
+				},
+			},
+		},
+	})
+}
+
+// protocolValue returns a value of objectType with the given attributes and
+// every other attribute null.
+func protocolValue(t *testing.T, objectType tftypes.Type, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	object, ok := objectType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected schema type %T", objectType)
+	}
+
+	attributes := make(map[string]tftypes.Value, len(object.AttributeTypes))
+	for name, attributeType := range object.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	value, err := tfprotov6.NewDynamicValue(object, tftypes.NewValue(object, attributes))
+	if err != nil {
+		t.Fatalf("unexpected error creating dynamic value: %s", err)
+	}
+
+	return &value
+}
+
+// configureTestProviderServer returns a protocol server of the provider
+// configured for the instance at instanceURL, and its schema.
+func configureTestProviderServer(t *testing.T, instanceURL string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	providerServer, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %s", err)
+	}
+
+	schemaResp, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error reading schema: %s", err)
+	}
+
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: protocolValue(t, schemaResp.Provider.ValueType(), map[string]tftypes.Value{
+			"api_key":                     tftypes.NewValue(tftypes.String, "test-api-key"),
+			"instance_url":                tftypes.NewValue(tftypes.String, instanceURL),
+			"allow_insecure_http":         tftypes.NewValue(tftypes.Bool, true),
+			"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected error configuring provider: %v %v", err, configureResp.Diagnostics)
+	}
+
+	return providerServer, schemaResp
+}
+
+func TestCredentialEphemeralResource_lifecycle(t *testing.T) {
+	var created map[string]interface{}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/credentials/schema/httpHeaderAuth":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"type":"object","additionalProperties":false,"properties":{"name":{"type":"string"},"value":{"type":"string"}},"required":["name","value"]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/credentials":
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &created); err != nil {
+				t.Errorf("unexpected request body: %s", body)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"c1","name":"deploy","type":"httpHeaderAuth","createdAt":"2024-01-01T00:00:00.000Z","updatedAt":"2024-01-01T00:00:00.000Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/credentials/c1":
+			deleted = append(deleted, "c1")
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	providerServer, schemaResp := configureTestProviderServer(t, server.URL)
+
+	credentialType := schemaResp.EphemeralResourceSchemas["n8ncloud_credential"].ValueType()
+	openResp, err := providerServer.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "n8ncloud_credential",
+		Config: protocolValue(t, credentialType, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "deploy"),
+			"type": tftypes.NewValue(tftypes.String, "httpHeaderAuth"),
+			"data": tftypes.NewValue(tftypes.String, `{"name":"Authorization","value":"Bearer secret"}`),
+		}),
+	})
+	if err != nil || len(openResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected error opening ephemeral resource: %v %v", err, openResp.Diagnostics)
+	}
+
+	result, err := openResp.Result.Unmarshal(credentialType)
+	if err != nil {
+		t.Fatalf("unexpected error reading result: %s", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatalf("unexpected error reading result attributes: %s", err)
+	}
+	if !attributes["id"].Equal(tftypes.NewValue(tftypes.String, "c1")) {
+		t.Errorf("expected id c1, got %s", attributes["id"])
+	}
+
+	data, ok := created["data"].(map[string]interface{})
+	if !ok || data["value"] != "Bearer secret" {
+		t.Errorf("expected the credential data to be sent as an object, got %v", created["data"])
+	}
+
+	closeResp, err := providerServer.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "n8ncloud_credential",
+		Private:  openResp.Private,
+	})
+	if err != nil || len(closeResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected error closing ephemeral resource: %v %v", err, closeResp.Diagnostics)
+	}
+
+	if len(deleted) != 1 {
+		t.Errorf("expected the credential to be deleted once, got %d deletions", len(deleted))
+	}
+}
+
+func TestCredentialEphemeralResourceOpen_validateData(t *testing.T) {
+	testCases := map[string]struct {
+		data         string
+		validateData tftypes.Value
+		wantErrors   []string
+		wantSchema   bool
+	}{
+		"valid": {
+			data:         `{"name":"Authorization","value":"Bearer secret"}`,
+			validateData: tftypes.NewValue(tftypes.Bool, nil),
+			wantSchema:   true,
+		},
+		"missing required field": {
+			data:         `{"name":"Authorization"}`,
+			validateData: tftypes.NewValue(tftypes.Bool, nil),
+			wantErrors:   []string{"Missing Credential Data Field"},
+			wantSchema:   true,
+		},
+		"unknown field": {
+			data:         `{"name":"Authorization","value":"Bearer secret","header":"X-Token"}`,
+			validateData: tftypes.NewValue(tftypes.Bool, true),
+			wantErrors:   []string{"Unknown Credential Data Field"},
+			wantSchema:   true,
+		},
+		"validation disabled": {
+			data:         `{"header":"X-Token"}`,
+			validateData: tftypes.NewValue(tftypes.Bool, false),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var schemaRead, created bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/credentials/schema/httpHeaderAuth":
+					schemaRead = true
+					_, _ = w.Write([]byte(`{"type":"object","additionalProperties":false,"properties":{"name":{"type":"string"},"value":{"type":"string"}},"required":["name","value"]}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/credentials":
+					created = true
+					_, _ = w.Write([]byte(`{"id":"c1","name":"deploy","type":"httpHeaderAuth","createdAt":"2024-01-01T00:00:00.000Z"}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			providerServer, schemaResp := configureTestProviderServer(t, server.URL)
+			credentialType := schemaResp.EphemeralResourceSchemas["n8ncloud_credential"].ValueType()
+			openResp, err := providerServer.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+				TypeName: "n8ncloud_credential",
+				Config: protocolValue(t, credentialType, map[string]tftypes.Value{
+					"name":          tftypes.NewValue(tftypes.String, "deploy"),
+					"type":          tftypes.NewValue(tftypes.String, "httpHeaderAuth"),
+					"data":          tftypes.NewValue(tftypes.String, tc.data),
+					"validate_data": tc.validateData,
+				}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error opening ephemeral resource: %s", err)
+			}
+
+			var summaries []string
+			for _, d := range openResp.Diagnostics {
+				summaries = append(summaries, d.Summary)
+				if d.Attribute == nil || !d.Attribute.Equal(tftypes.NewAttributePath().WithAttributeName("data")) {
+					t.Errorf("expected the error to point to data, got: %v", d)
+				}
+			}
+			if len(summaries) != len(tc.wantErrors) || (len(summaries) > 0 && summaries[0] != tc.wantErrors[0]) {
+				t.Fatalf("expected errors %v, got: %v", tc.wantErrors, summaries)
+			}
+			if schemaRead != tc.wantSchema {
+				t.Errorf("expected the schema to be read: %t, got %t", tc.wantSchema, schemaRead)
+			}
+			if created == (len(tc.wantErrors) > 0) {
+				t.Errorf("expected the credential to be created only without errors, created: %t", created)
+			}
+		})
+	}
+}
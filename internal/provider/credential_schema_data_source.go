@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CredentialSchemaDataSource{}
+
+func NewCredentialSchemaDataSource() datasource.DataSource {
+	return &CredentialSchemaDataSource{}
+}
+
+// CredentialSchemaDataSource defines the data source implementation.
+type CredentialSchemaDataSource struct {
+	client *client.Client
+}
+
+// CredentialSchemaDataSourceModel describes the data source data model.
+type CredentialSchemaDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Type           types.String `tfsdk:"type"`
+	Schema         types.String `tfsdk:"schema"`
+	RequiredFields []string     `tfsdk:"required_fields"`
+}
+
+func (d *CredentialSchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential_schema"
+}
+
+func (d *CredentialSchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Credential schema data source for looking up the shape of the data of a credential type, e.g. to validate credential `data` payloads in a module before creating the credential.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to the credential type",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The name of the credential type, such as `githubApi` or `slackOAuth2Api`",
+				Required:            true,
+			},
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "The JSON schema of the credential data, as a JSON string with object keys in sorted order",
+				Computed:            true,
+			},
+			"required_fields": schema.ListAttribute{
+				MarkdownDescription: "The names of the required properties of the credential data, in the order the schema lists them",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CredentialSchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *CredentialSchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CredentialSchemaDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	raw, err := d.client.GetCredentialSchema(ctx, data.Type.ValueString())
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Credential Type Not Found",
+			fmt.Sprintf("The n8n instance has no credential type %q. Credential type names are case-sensitive, such as githubApi.", data.Type.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read credential schema", err)
+		return
+	}
+
+	schemaObject, err := decodeJSONObject(string(raw))
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Credential Schema", fmt.Sprintf("The API returned an invalid schema for credential type %q: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	schemaJSON, err := encodeJSON(schemaObject)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Credential Schema", fmt.Sprintf("Unable to encode the schema for credential type %q: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	required, err := schemaRequiredFields(schemaObject)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Credential Schema", fmt.Sprintf("The API returned an invalid schema for credential type %q: %s", data.Type.ValueString(), err))
+		return
+	}
+
+	data.ID = data.Type
+	data.Schema = types.StringValue(schemaJSON)
+	data.RequiredFields = required
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCredentialSchemaDataSourceRead(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/api/v1/credentials/schema/freshdeskApi" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"type":"object","required":["apiKey","domain"],"properties":{"domain":{"type":"string"},"apiKey":{"type":"string"}},"additionalProperties":false}`))
+	})
+
+	ctx := context.Background()
+	d := &CredentialSchemaDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(credentialType string) *datasource.ReadResponse {
+		t.Helper()
+
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["type"] = tftypes.NewValue(tftypes.String, credentialType)
+		config := tftypes.NewValue(objectType, attributes)
+
+		req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+		d.Read(ctx, req, resp)
+
+		return resp
+	}
+
+	resp := read("freshdeskApi")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data CredentialSchemaDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	want := `{"additionalProperties":false,"properties":{"apiKey":{"type":"string"},"domain":{"type":"string"}},"required":["apiKey","domain"],"type":"object"}`
+	if data.Schema.ValueString() != want {
+		t.Errorf("expected schema %s, got %s", want, data.Schema.ValueString())
+	}
+	if want := []string{"apiKey", "domain"}; !reflect.DeepEqual(data.RequiredFields, want) {
+		t.Errorf("expected required fields %v, got %v", want, data.RequiredFields)
+	}
+
+	resp = read("unknownApi")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Credential Type Not Found" {
+		t.Errorf("expected a Credential Type Not Found error, got: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CredentialTransferResource{}
+
+func NewCredentialTransferResource() resource.Resource {
+	return &CredentialTransferResource{}
+}
+
+// CredentialTransferResource defines the resource implementation.
+type CredentialTransferResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// CredentialTransferResourceModel describes the resource data model.
+type CredentialTransferResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	CredentialID         types.String `tfsdk:"credential_id"`
+	DestinationProjectID types.String `tfsdk:"destination_project_id"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *CredentialTransferResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential_transfer"
+}
+
+func (r *CredentialTransferResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Credential transfer resource for moving a credential to another project, e.g. a credential shared by workflows that moved to another team's project. Creating the resource transfers the credential and changing `destination_project_id` transfers it again, so the resource records the project that owns the credential. " +
+			"A credential already in the destination project counts as transferred. Destroying the resource leaves the credential in its project. Transfers made outside Terraform and deleted credentials are not detected. Projects require an Enterprise license.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the transfer, set to the credential ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credential_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the credential to transfer. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination_project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project to move the credential to",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *CredentialTransferResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *CredentialTransferResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CredentialTransferResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	if !credentialTransfer.run(ctx, &resp.Diagnostics, r.client, data.CredentialID.ValueString(), data.DestinationProjectID.ValueString()) {
+		return
+	}
+
+	data.ID = data.CredentialID
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_credential_transfer", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Created n8n credential transfer resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CredentialTransferResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The API can neither read a credential nor report the project owning
+	// it, so the state is kept as it is
+}
+
+func (r *CredentialTransferResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state CredentialTransferResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	// Only the timeouts may have changed otherwise
+	if !data.DestinationProjectID.Equal(state.DestinationProjectID) {
+		if !credentialTransfer.run(ctx, &resp.Diagnostics, r.client, data.CredentialID.ValueString(), data.DestinationProjectID.ValueString()) {
+			return
+		}
+
+		r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_credential_transfer", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Updated n8n credential transfer resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CredentialTransferResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CredentialTransferResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no project to move the credential back to, so destroying
+	// the resource leaves it where it is
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_credential_transfer", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n credential transfer resource")
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// runCredentialTransferCreate transfers credentialID to projectID through r
+// and returns the response.
+func runCredentialTransferCreate(t *testing.T, r *CredentialTransferResource, credentialID, projectID string) *fwresource.CreateResponse {
+	t.Helper()
+
+	transferSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"credential_id":          tftypes.NewValue(tftypes.String, credentialID),
+		"destination_project_id": tftypes.NewValue(tftypes.String, projectID),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: transferSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: transferSchema, Raw: plan}}, resp)
+
+	return resp
+}
+
+func TestCredentialTransferResourceCreate(t *testing.T) {
+	testCases := map[string]struct {
+		credentialID string
+		projectID    string
+		wantError    string
+		wantOwner    string
+	}{
+		"transfer":             {credentialID: "c1", projectID: "p2", wantOwner: "p2"},
+		"already in project":   {credentialID: "c1", projectID: "p1", wantOwner: "p1"},
+		"credential not found": {credentialID: "c2", projectID: "p2", wantError: "Credential Not Found"},
+		"both not found":       {credentialID: "c2", projectID: "p3", wantError: "Project Not Found"},
+		"project not found":    {credentialID: "c1", projectID: "p3", wantError: "Project Not Found", wantOwner: "p1"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := &fakeTransferServer{t: t, resourcePath: "/credentials", owners: map[string]string{"c1": "p1"}, projects: []string{"p1", "p2"}}
+			r := &CredentialTransferResource{client: newTestClient(t, server.ServeHTTP)}
+
+			resp := runCredentialTransferCreate(t, r, tc.credentialID, tc.projectID)
+
+			if tc.wantError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tc.wantError {
+					t.Fatalf("expected a %s error, got: %v", tc.wantError, resp.Diagnostics)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if tc.wantOwner != "" && server.owners["c1"] != tc.wantOwner {
+				t.Errorf("expected the credential to be owned by %s, got %s", tc.wantOwner, server.owners["c1"])
+			}
+			for _, request := range server.requests {
+				if strings.HasPrefix(request, "GET /credentials/") {
+					t.Errorf("expected no credential lookup outside the public API, got %s", request)
+				}
+			}
+		})
+	}
+}
+
+func TestCredentialTransferResourceRead(t *testing.T) {
+	server := &fakeTransferServer{t: t, resourcePath: "/credentials", owners: map[string]string{}, projects: []string{"p1"}}
+	r := &CredentialTransferResource{client: newTestClient(t, server.ServeHTTP)}
+
+	transferSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "c1"),
+		"credential_id":          tftypes.NewValue(tftypes.String, "c1"),
+		"destination_project_id": tftypes.NewValue(tftypes.String, "p1"),
+	})
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: transferSchema, Raw: state}}
+	r.Read(context.Background(), fwresource.ReadRequest{State: tfsdk.State{Schema: transferSchema, Raw: state}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.Equal(state) {
+		t.Errorf("expected the state to be kept, got %s", resp.State.Raw)
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("expected no requests, got %v", server.requests)
+	}
+}
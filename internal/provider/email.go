@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// emailPattern matches email addresses loosely: a local part, an @ and a
+// domain with at least one dot, none containing whitespace or another @.
+// The instance validates addresses fully; this only catches typos early.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s.]+$`)
+
+var _ validator.String = emailValidator{}
+
+// emailValidator validates that a string looks like an email address.
+type emailValidator struct{}
+
+func (v emailValidator) Description(ctx context.Context) string {
+	return "value must be an email address such as user@example.com"
+}
+
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !emailPattern.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Email Address",
+			fmt.Sprintf("The value %q is not a valid email address. Use an address such as \"user@example.com\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
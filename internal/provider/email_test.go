@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailValidator(t *testing.T) {
+	tests := map[string]bool{
+		"user@example.com":           false,
+		"first.last+tag@example.com": false,
+		"User@Sub.Example.Co.Uk":     false,
+		"user.example.com":           true,
+		"user@example":               true,
+		"user@@example.com":          true,
+		"user @example.com":          true,
+		"user@example.com.":          true,
+		"@example.com":               true,
+		"":                           true,
+	}
+
+	for email, expectError := range tests {
+		req := validator.StringRequest{
+			Path:        path.Root("email"),
+			ConfigValue: types.StringValue(email),
+		}
+		resp := &validator.StringResponse{}
+
+		emailValidator{}.ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("email %q: expected error %t, got diagnostics: %v", email, expectError, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &EscapeExpressionFunction{}
+
+func NewEscapeExpressionFunction() function.Function {
+	return &EscapeExpressionFunction{}
+}
+
+// EscapeExpressionFunction defines the function implementation.
+type EscapeExpressionFunction struct{}
+
+func (f *EscapeExpressionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "escape_expression"
+}
+
+func (f *EscapeExpressionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Quote a value for use in an n8n expression",
+		MarkdownDescription: "Returns the value as a JavaScript string literal, including the surrounding double quotes, that can be embedded in an n8n `={{ }}` expression, e.g. `\"={{ $json.team === ${provider::n8ncloud::escape_expression(var.team)} }}\"`. Quotes, backslashes and control characters are escaped, and so are braces, so a value containing `}}` cannot end the expression early.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The value to quote",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EscapeExpressionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	escaped, err := escapeExpression(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to escape value: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, escaped))
+}
+
+// expressionBraceReplacer escapes the braces n8n uses to delimit
+// expressions. The replacements are JavaScript escapes, so the string value
+// is unchanged.
+var expressionBraceReplacer = strings.NewReplacer("{", `\u007b`, "}", `\u007d`)
+
+// escapeExpression returns value as a double-quoted JavaScript string
+// literal. JSON string syntax is a subset of JavaScript's, and encoding
+// escapes quotes, backslashes, control characters and the U+2028 and U+2029
+// line separators older engines reject in literals.
+func escapeExpression(value string) (string, error) {
+	encoded, err := encodeJSON(value)
+	if err != nil {
+		return "", err
+	}
+
+	return expressionBraceReplacer.Replace(encoded), nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEscapeExpression(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		want  string
+	}{
+		"plain":           {value: "billing", want: `"billing"`},
+		"empty":           {value: "", want: `""`},
+		"double quotes":   {value: `say "hi"`, want: `"say \"hi\""`},
+		"single quotes":   {value: `it's`, want: `"it's"`},
+		"backslash":       {value: `C:\temp\`, want: `"C:\\temp\\"`},
+		"closing braces":  {value: `x }} {{ $env.SECRET`, want: `"x \u007d\u007d \u007b\u007b $env.SECRET"`},
+		"template":        {value: "${a}`b`", want: `"$\u007ba\u007d` + "`b`" + `"`},
+		"newlines":        {value: "a\nb\r\tc", want: `"a\nb\r\tc"`},
+		"line separators": {value: "a\u2028b\u2029c", want: `"a\u2028b\u2029c"`},
+		"control":         {value: "\x00\x1f", want: `"\u0000\u001f"`},
+		"html":            {value: "<script>&", want: `"<script>&"`},
+		"unicode":         {value: "日本語 🚀", want: `"日本語 🚀"`},
+		"string escape":   {value: `"); process.exit(); ("`, want: `"\"); process.exit(); (\""`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := escapeExpression(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+
+			// Braces must never survive, or the value could end the
+			// expression it is embedded in.
+			if strings.ContainsAny(got, "{}") {
+				t.Errorf("expected braces to be escaped, got %s", got)
+			}
+
+			// The literal must decode back to the original value.
+			var decoded string
+			if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+				t.Fatalf("expected a valid string literal, got %s: %s", got, err)
+			}
+			if decoded != tc.value {
+				t.Errorf("expected the literal to decode to %q, got %q", tc.value, decoded)
+			}
+		})
+	}
+}
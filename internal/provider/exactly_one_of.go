@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+var _ datasource.ConfigValidator = exactlyOneOfValidator{}
+
+// exactlyOneOfValidator validates that exactly one of the given top-level
+// attributes is set. Unknown values count as set, since they will be once
+// known.
+type exactlyOneOfValidator struct {
+	attributes []string
+}
+
+func (v exactlyOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("exactly one of %s must be set", strings.Join(v.attributes, " or "))
+}
+
+func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("exactly one of `%s` must be set", strings.Join(v.attributes, "` or `"))
+}
+
+func (v exactlyOneOfValidator) ValidateDataSource(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var set []string
+	for _, attribute := range v.attributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+
+		if value != nil && !value.IsNull() {
+			set = append(set, attribute)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case len(set) == 0:
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Exactly one of %s must be specified, but none is set.", strings.Join(v.attributes, " or ")),
+		)
+	case len(set) > 1:
+		resp.Diagnostics.AddAttributeError(
+			path.Root(set[1]),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("Exactly one of %s must be specified, but %s are all set. Remove all but one of them.", strings.Join(v.attributes, " or "), strings.Join(set, " and ")),
+		)
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ExecutionsDataSource{}
+
+// executionStatuses lists the statuses the API can filter executions by.
+var executionStatuses = []string{"success", "error", "waiting"}
+
+func NewExecutionsDataSource() datasource.DataSource {
+	return &ExecutionsDataSource{}
+}
+
+// ExecutionsDataSource defines the data source implementation.
+type ExecutionsDataSource struct {
+	client *client.Client
+}
+
+// ExecutionsDataSourceModel describes the data source data model.
+type ExecutionsDataSourceModel struct {
+	ID               types.String           `tfsdk:"id"`
+	IncludeData      types.Bool             `tfsdk:"include_data"`
+	SummaryOnly      types.Bool             `tfsdk:"summary_only"`
+	MaxResponseBytes types.Int64            `tfsdk:"max_response_bytes"`
+	ExtraQuery       map[string]string      `tfsdk:"extra_query"`
+	Executions       []ListedExecutionModel `tfsdk:"executions"`
+
+	Filter *ExecutionsFilterModel `tfsdk:"filter"`
+}
+
+// ExecutionsFilterModel describes the filter block of the data source.
+type ExecutionsFilterModel struct {
+	WorkflowID    types.String `tfsdk:"workflow_id"`
+	Status        types.String `tfsdk:"status"`
+	Mode          types.String `tfsdk:"mode"`
+	StartedAfter  types.String `tfsdk:"started_after"`
+	StartedBefore types.String `tfsdk:"started_before"`
+	Limit         types.Int64  `tfsdk:"limit"`
+}
+
+// ListedExecutionModel describes an execution returned by the data source.
+type ListedExecutionModel struct {
+	ID               types.String `tfsdk:"id"`
+	WorkflowID       types.String `tfsdk:"workflow_id"`
+	Status           types.String `tfsdk:"status"`
+	Finished         types.Bool   `tfsdk:"finished"`
+	Mode             types.String `tfsdk:"mode"`
+	StartedAt        types.String `tfsdk:"started_at"`
+	StoppedAt        types.String `tfsdk:"stopped_at"`
+	WaitTill         types.String `tfsdk:"wait_till"`
+	RetryOf          types.String `tfsdk:"retry_of"`
+	RetrySuccessID   types.String `tfsdk:"retry_success_id"`
+	ErrorMessage     types.String `tfsdk:"error_message"`
+	LastNodeExecuted types.String `tfsdk:"last_node_executed"`
+}
+
+func (d *ExecutionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_executions"
+}
+
+func (d *ExecutionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Executions data source for querying recent workflow executions, e.g. to alert on failed runs. Executions are listed newest first, and pages are read until `limit` executions match the `filter` block. " +
+			"`workflow_id` and `status` are filtered by the API; `mode`, `started_after` and `started_before` are applied to the listed executions, so with them every page may be read.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the data source",
+				Computed:            true,
+			},
+			"include_data": schema.BoolAttribute{
+				MarkdownDescription: "Whether to request the execution data, to report `error_message` and `last_node_executed`. Execution data can be large, so executions are then read in pages of 10. Defaults to false.",
+				Optional:            true,
+			},
+			"summary_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether to never request the execution data, even if `include_data` or `extra_query` ask for it, leaving `error_message` and `last_node_executed` null. Any data the API returns anyway is dropped. Defaults to false.",
+				Optional:            true,
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The maximum size in bytes of each page of executions returned by the API. A larger page fails the read instead of being held in memory, which guards against pages of large execution data with `include_data`. By default, pages are not limited.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"executions": schema.ListNestedAttribute{
+				MarkdownDescription: "The executions matching the filters, newest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the execution",
+							Computed:            true,
+						},
+						"workflow_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the workflow that was executed",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the execution, as for the `n8ncloud_latest_execution` data source",
+							Computed:            true,
+						},
+						"finished": schema.BoolAttribute{
+							MarkdownDescription: "Whether the execution finished successfully",
+							Computed:            true,
+						},
+						"mode": schema.StringAttribute{
+							MarkdownDescription: "How the execution was started, such as `trigger`, `webhook` or `manual`",
+							Computed:            true,
+						},
+						"started_at": schema.StringAttribute{
+							MarkdownDescription: "The start timestamp of the execution, in RFC3339 format",
+							Computed:            true,
+						},
+						"stopped_at": schema.StringAttribute{
+							MarkdownDescription: "The end timestamp of the execution, in RFC3339 format, or null while it is running",
+							Computed:            true,
+						},
+						"wait_till": schema.StringAttribute{
+							MarkdownDescription: "The timestamp a waiting execution resumes at, in RFC3339 format, or null if it is not waiting",
+							Computed:            true,
+						},
+						"retry_of": schema.StringAttribute{
+							MarkdownDescription: "The ID of the execution this execution retried, or null if it is not a retry",
+							Computed:            true,
+						},
+						"retry_success_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the successful retry of this execution, or null if it was not retried successfully",
+							Computed:            true,
+						},
+						"error_message": schema.StringAttribute{
+							MarkdownDescription: "The error message of a failed execution. Only set with `include_data`.",
+							Computed:            true,
+						},
+						"last_node_executed": schema.StringAttribute{
+							MarkdownDescription: "The name of the node a failed execution stopped at. Only set with `include_data`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				MarkdownDescription: "Restricts the executions that are returned. Without it, every execution is returned, which can be slow on busy instances.",
+				Attributes: map[string]schema.Attribute{
+					"workflow_id": schema.StringAttribute{
+						MarkdownDescription: "Only return executions of the workflow with this ID",
+						Optional:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "Only return executions with this status: `success`, `error` or `waiting`",
+						Optional:            true,
+						Validators: []validator.String{
+							executionStatusValidator{},
+						},
+					},
+					"mode": schema.StringAttribute{
+						MarkdownDescription: "Only return executions started in this mode, such as `trigger`, `webhook`, `manual` or `retry`",
+						Optional:            true,
+					},
+					"started_after": schema.StringAttribute{
+						MarkdownDescription: "Only return executions started after this timestamp, in RFC3339 format such as `2024-01-01T00:00:00Z`",
+						Optional:            true,
+						Validators: []validator.String{
+							rfc3339Validator{},
+						},
+					},
+					"started_before": schema.StringAttribute{
+						MarkdownDescription: "Only return executions started before this timestamp, in RFC3339 format such as `2024-01-01T00:00:00Z`",
+						Optional:            true,
+						Validators: []validator.String{
+							rfc3339Validator{},
+						},
+					},
+					"limit": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of executions to return. By default, every matching execution is returned, which can be slow on busy instances.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ExecutionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *ExecutionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExecutionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The filter block is optional, and its attributes are null without it
+	var filter ExecutionsFilterModel
+	if data.Filter != nil {
+		filter = *data.Filter
+	}
+
+	if !filter.Limit.IsNull() && filter.Limit.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filter").AtName("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("The limit must be positive, got %d.", filter.Limit.ValueInt64()),
+		)
+		return
+	}
+
+	if !data.MaxResponseBytes.IsNull() && data.MaxResponseBytes.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_response_bytes"),
+			"Invalid Max Response Bytes",
+			fmt.Sprintf("The maximum response size must be positive, got %d.", data.MaxResponseBytes.ValueInt64()),
+		)
+		return
+	}
+
+	// The validators have checked the timestamps
+	var startedAfter, startedBefore time.Time
+	if !filter.StartedAfter.IsNull() {
+		startedAfter, _ = time.Parse(time.RFC3339, filter.StartedAfter.ValueString())
+	}
+	if !filter.StartedBefore.IsNull() {
+		startedBefore, _ = time.Parse(time.RFC3339, filter.StartedBefore.ValueString())
+	}
+
+	if data.IncludeData.ValueBool() && !data.SummaryOnly.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Execution Data Requested",
+			"include_data requests the full data of every listed execution, which can be megabytes each and slows down every plan. Set a limit in the filter block, and prefer status = \"error\" there to only read the data of failed executions. "+
+				"Set max_response_bytes to fail the read instead of holding oversized pages in memory.",
+		)
+	}
+
+	limit := int(filter.Limit.ValueInt64())
+	opts := &client.ListExecutionsOptions{
+		ListOptions: client.ListOptions{
+			ExtraQuery:   data.ExtraQuery,
+			MaxPageBytes: data.MaxResponseBytes.ValueInt64(),
+		},
+		WorkflowID:  filter.WorkflowID.ValueString(),
+		Status:      filter.Status.ValueString(),
+		IncludeData: data.IncludeData.ValueBool(),
+		SummaryOnly: data.SummaryOnly.ValueBool(),
+	}
+
+	// Filters the API does not support are applied to the listed
+	// executions, so the limit can only be applied by the API without them
+	clientFiltered := !filter.Mode.IsNull() || !startedAfter.IsZero() || !startedBefore.IsZero()
+	if !clientFiltered {
+		opts.Limit = limit
+	}
+
+	executions, err := d.client.ListExecutions(ctx, opts)
+	if errors.Is(err, client.ErrResponseTooLarge) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_response_bytes"),
+			"Execution Page Too Large",
+			fmt.Sprintf("A page of executions was larger than max_response_bytes (%d bytes), so the read was stopped. "+
+				"Raise max_response_bytes, set summary_only or narrow the filter block. Error: %s", data.MaxResponseBytes.ValueInt64(), err),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list executions", err)
+		return
+	}
+
+	data.ID = types.StringValue("executions")
+	data.Executions = make([]ListedExecutionModel, 0, len(executions))
+	for i := range executions {
+		execution := &executions[i]
+		if !executionMatchesFilters(execution, filter.Mode, startedAfter, startedBefore) {
+			continue
+		}
+		if limit > 0 && len(data.Executions) == limit {
+			break
+		}
+
+		errorMessage, lastNode := client.ExecutionErrorDetails(execution)
+		data.Executions = append(data.Executions, ListedExecutionModel{
+			ID:               types.StringValue(string(execution.ID)),
+			WorkflowID:       types.StringValue(string(execution.WorkflowID)),
+			Status:           types.StringValue(client.ExecutionStatus(execution)),
+			Finished:         types.BoolValue(execution.Finished),
+			Mode:             types.StringValue(execution.Mode),
+			StartedAt:        executionTimeValue(execution.StartedAt),
+			StoppedAt:        executionTimeValue(execution.StoppedAt),
+			WaitTill:         executionTimeValue(execution.WaitTill),
+			RetryOf:          optionalStringValue(string(execution.RetryOf)),
+			RetrySuccessID:   optionalStringValue(string(execution.RetrySuccessID)),
+			ErrorMessage:     optionalStringValue(errorMessage),
+			LastNodeExecuted: optionalStringValue(lastNode),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// executionMatchesFilters reports whether execution matches the mode filter,
+// which is ignored when null, and was started within the given times, each
+// of which is ignored when zero.
+func executionMatchesFilters(execution *client.Execution, mode types.String, startedAfter, startedBefore time.Time) bool {
+	if !mode.IsNull() && execution.Mode != mode.ValueString() {
+		return false
+	}
+
+	if !startedAfter.IsZero() && !execution.StartedAt.After(startedAfter) {
+		return false
+	}
+
+	if !startedBefore.IsZero() && !execution.StartedAt.Before(startedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// optionalStringValue returns s, or null if it is empty.
+func optionalStringValue(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(s)
+}
+
+var _ validator.String = executionStatusValidator{}
+
+// executionStatusValidator validates that a string is a status executions
+// can be filtered by.
+type executionStatusValidator struct{}
+
+func (v executionStatusValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of %s", strings.Join(executionStatuses, ", "))
+}
+
+func (v executionStatusValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v executionStatusValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(executionStatuses, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Execution Status",
+			fmt.Sprintf("The status %q is not supported. Valid statuses are: %s.", req.ConfigValue.ValueString(), strings.Join(executionStatuses, ", ")),
+		)
+	}
+}
+
+var _ validator.String = rfc3339Validator{}
+
+// rfc3339Validator validates that a string is an RFC3339 timestamp.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp such as 2024-01-01T00:00:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("The value %q is not an RFC3339 timestamp. Use a value such as \"2024-01-01T00:00:00Z\", e.g. from timeadd(timestamp(), \"-24h\").", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExecutionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "n8ncloud_executions" "test" {
+  filter {
+    limit = 5
+  }
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.n8ncloud_executions.test", "id", "executions"),
+			},
+			{
+				Config: `
+data "n8ncloud_executions" "test" {
+  filter {
+    status = "failed"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Execution Status`),
+			},
+		},
+	})
+}
+
+// readExecutions reads d with the given configuration values and, unless
+// filter is nil, a filter block with the given values, and returns the
+// resulting state and diagnostics.
+func readExecutions(t *testing.T, d *ExecutionsDataSource, values, filter map[string]tftypes.Value) (ExecutionsDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+	if filter != nil {
+		filterType := objectType.AttributeTypes["filter"].(tftypes.Object)
+		filterAttributes := make(map[string]tftypes.Value, len(filterType.AttributeTypes))
+		for name, attributeType := range filterType.AttributeTypes {
+			filterAttributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range filter {
+			filterAttributes[name] = value
+		}
+		attributes["filter"] = tftypes.NewValue(filterType, filterAttributes)
+	}
+	config := tftypes.NewValue(objectType, attributes)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+	d.Read(ctx, req, resp)
+
+	var data ExecutionsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+	}
+
+	return data, resp.Diagnostics
+}
+
+// executionPages serves two pages of executions, newest first.
+func executionPages(queries *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":1004,"workflowId":"wf1","finished":true,"mode":"retry","retryOf":"1001","startedAt":"2024-01-05T10:00:00Z","stoppedAt":"2024-01-05T10:00:01Z"},
+				{"id":1003,"workflowId":"wf1","finished":false,"mode":"webhook","status":"waiting","startedAt":"2024-01-04T10:00:00Z","stoppedAt":null,"waitTill":"2024-01-06T00:00:00Z"}
+			],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":1002,"workflowId":"wf1","finished":true,"mode":"trigger","startedAt":"2024-01-03T10:00:00Z","stoppedAt":"2024-01-03T10:00:01Z"},
+			{"id":1001,"workflowId":"wf1","finished":false,"mode":"trigger","status":"error","retrySuccessId":"1004","startedAt":"2024-01-02T10:00:00Z","stoppedAt":"2024-01-02T10:00:01Z"}
+		],"nextCursor":null}`))
+	}
+}
+
+func TestExecutionsDataSourceRead_limit(t *testing.T) {
+	var queries []string
+	d := &ExecutionsDataSource{client: newTestClient(t, executionPages(&queries))}
+
+	data, diags := readExecutions(t, d, nil, map[string]tftypes.Value{
+		"workflow_id": tftypes.NewValue(tftypes.String, "wf1"),
+		"limit":       tftypes.NewValue(tftypes.Number, 3),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(queries) != 2 || queries[0] != "limit=3&workflowId=wf1" {
+		t.Errorf("expected pages of at most 3 executions of the workflow to be requested, got %v", queries)
+	}
+	if len(data.Executions) != 3 {
+		t.Fatalf("expected 3 executions, got %+v", data.Executions)
+	}
+
+	want := ListedExecutionModel{
+		ID:               types.StringValue("1004"),
+		WorkflowID:       types.StringValue("wf1"),
+		Status:           types.StringValue("success"),
+		Finished:         types.BoolValue(true),
+		Mode:             types.StringValue("retry"),
+		StartedAt:        types.StringValue("2024-01-05T10:00:00Z"),
+		StoppedAt:        types.StringValue("2024-01-05T10:00:01Z"),
+		WaitTill:         types.StringNull(),
+		RetryOf:          types.StringValue("1001"),
+		RetrySuccessID:   types.StringNull(),
+		ErrorMessage:     types.StringNull(),
+		LastNodeExecuted: types.StringNull(),
+	}
+	if data.Executions[0] != want {
+		t.Errorf("expected %+v, got %+v", want, data.Executions[0])
+	}
+	if got := data.Executions[1].WaitTill; got != types.StringValue("2024-01-06T00:00:00Z") {
+		t.Errorf("expected the waiting execution to report when it resumes, got %s", got)
+	}
+}
+
+func TestExecutionsDataSourceRead_clientSideFilters(t *testing.T) {
+	testCases := map[string]struct {
+		filter map[string]tftypes.Value
+		want   []string
+	}{
+		"no filter": {
+			want: []string{"1004", "1003", "1002", "1001"},
+		},
+		"empty filter": {
+			filter: map[string]tftypes.Value{},
+			want:   []string{"1004", "1003", "1002", "1001"},
+		},
+		"mode": {
+			filter: map[string]tftypes.Value{"mode": tftypes.NewValue(tftypes.String, "trigger")},
+			want:   []string{"1002", "1001"},
+		},
+		"mode with limit": {
+			filter: map[string]tftypes.Value{
+				"mode":  tftypes.NewValue(tftypes.String, "trigger"),
+				"limit": tftypes.NewValue(tftypes.Number, 1),
+			},
+			want: []string{"1002"},
+		},
+		"started range": {
+			filter: map[string]tftypes.Value{
+				"started_after":  tftypes.NewValue(tftypes.String, "2024-01-02T12:00:00Z"),
+				"started_before": tftypes.NewValue(tftypes.String, "2024-01-05T00:00:00+00:00"),
+			},
+			want: []string{"1003", "1002"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var queries []string
+			d := &ExecutionsDataSource{client: newTestClient(t, executionPages(&queries))}
+
+			data, diags := readExecutions(t, d, nil, tc.filter)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var got []string
+			for _, execution := range data.Executions {
+				got = append(got, execution.ID.ValueString())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("expected executions %v, got %v", tc.want, got)
+			}
+			if len(queries) != 2 {
+				t.Errorf("expected every page to be read for client-side filters, got %v", queries)
+			}
+		})
+	}
+}
+
+func TestExecutionsDataSourceRead_includeData(t *testing.T) {
+	var query string
+	d := &ExecutionsDataSource{client: newTestClient(t, failedExecutionWithData(&query))}
+
+	data, diags := readExecutions(t, d, map[string]tftypes.Value{
+		"include_data": tftypes.NewValue(tftypes.Bool, true),
+	}, map[string]tftypes.Value{
+		"status": tftypes.NewValue(tftypes.String, "error"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if query != "includeData=true&limit=10&status=error" {
+		t.Errorf("expected failed executions to be requested with data, got query %q", query)
+	}
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Execution Data Requested" {
+		t.Errorf("expected a warning about execution data, got %v", diags)
+	}
+	if len(data.Executions) != 1 {
+		t.Fatalf("expected 1 execution, got %+v", data.Executions)
+	}
+	if got := data.Executions[0].ErrorMessage; got != types.StringValue("The resource you are requesting could not be found") {
+		t.Errorf("unexpected error message %s", got)
+	}
+	if got := data.Executions[0].LastNodeExecuted; got != types.StringValue("Fetch Orders") {
+		t.Errorf("unexpected last node %s", got)
+	}
+}
+
+// failedExecutionWithData serves one failed execution with its data,
+// recording the query of the request.
+func failedExecutionWithData(query *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"1001","workflowId":"wf1","finished":false,"mode":"trigger","status":"error","startedAt":"2024-01-02T10:00:00Z","stoppedAt":"2024-01-02T10:00:01Z",
+			 "data":{"resultData":{"error":{"message":"The resource you are requesting could not be found","name":"NodeApiError"},"lastNodeExecuted":"Fetch Orders","runData":{}}}}
+		],"nextCursor":null}`))
+	}
+}
+
+func TestExecutionsDataSourceRead_summaryOnly(t *testing.T) {
+	var query string
+	d := &ExecutionsDataSource{client: newTestClient(t, failedExecutionWithData(&query))}
+
+	// summary_only wins over include_data
+	data, diags := readExecutions(t, d, map[string]tftypes.Value{
+		"include_data": tftypes.NewValue(tftypes.Bool, true),
+		"summary_only": tftypes.NewValue(tftypes.Bool, true),
+	}, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if query != "includeData=false&limit=250" {
+		t.Errorf("expected executions to be requested without data, got query %q", query)
+	}
+	if diags.WarningsCount() != 0 {
+		t.Errorf("expected no warning without execution data, got %v", diags)
+	}
+	if len(data.Executions) != 1 {
+		t.Fatalf("expected 1 execution, got %+v", data.Executions)
+	}
+	if execution := data.Executions[0]; !execution.ErrorMessage.IsNull() || !execution.LastNodeExecuted.IsNull() {
+		t.Errorf("expected no details from execution data, got %s in %s", execution.ErrorMessage, execution.LastNodeExecuted)
+	}
+}
+
+func TestExecutionsDataSourceRead_maxResponseBytes(t *testing.T) {
+	testCases := map[string]struct {
+		maxResponseBytes int64
+		wantSummary      string
+	}{
+		"page within limit": {maxResponseBytes: 1 << 20},
+		"page too large":    {maxResponseBytes: 100, wantSummary: "Execution Page Too Large"},
+		"not positive":      {maxResponseBytes: 0, wantSummary: "Invalid Max Response Bytes"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var query string
+			d := &ExecutionsDataSource{client: newTestClient(t, failedExecutionWithData(&query))}
+
+			data, diags := readExecutions(t, d, map[string]tftypes.Value{
+				"include_data":       tftypes.NewValue(tftypes.Bool, true),
+				"max_response_bytes": tftypes.NewValue(tftypes.Number, tc.maxResponseBytes),
+			}, nil)
+
+			if tc.wantSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if len(data.Executions) != 1 || data.Executions[0].ErrorMessage.IsNull() {
+					t.Errorf("expected the execution with its error message, got %+v", data.Executions)
+				}
+				return
+			}
+
+			if !diags.HasError() || diags.Errors()[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q error, got: %v", tc.wantSummary, diags)
+			}
+			if got := diags.Errors()[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root("max_response_bytes")) {
+				t.Errorf("expected the error on max_response_bytes, got %s", got)
+			}
+		})
+	}
+}
+
+func TestExecutionsDataSourceRead_invalidLimit(t *testing.T) {
+	d := &ExecutionsDataSource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})}
+
+	_, diags := readExecutions(t, d, nil, map[string]tftypes.Value{
+		"limit": tftypes.NewValue(tftypes.Number, 0),
+	})
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid Limit" {
+		t.Fatalf("expected an Invalid Limit error, got: %v", diags)
+	}
+	if got, want := diags.Errors()[0].(diag.DiagnosticWithPath).Path(), path.Root("filter").AtName("limit"); !got.Equal(want) {
+		t.Errorf("expected the error on %s, got %s", want, got)
+	}
+}
+
+func TestRFC3339Validator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"utc":       {value: types.StringValue("2024-01-01T00:00:00Z")},
+		"offset":    {value: types.StringValue("2024-01-01T09:00:00+09:00")},
+		"null":      {value: types.StringNull()},
+		"unknown":   {value: types.StringUnknown()},
+		"date only": {value: types.StringValue("2024-01-01"), wantError: true},
+		"garbage":   {value: types.StringValue("yesterday"), wantError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			rfc3339Validator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("started_after"),
+				ConfigValue: tc.value,
+			}, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error %t, got: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// normalizeInstanceURL validates that instanceURL is an absolute http or
+// https URL with a host and returns it without trailing slashes, so request
+// URLs never contain a double slash before the API path.
+func normalizeInstanceURL(instanceURL string) (string, error) {
+	parsed, err := url.Parse(instanceURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+	case "":
+		return "", fmt.Errorf("missing scheme, expected a URL such as https://example.app.n8n.cloud")
+	default:
+		return "", fmt.Errorf("unsupported scheme %q, expected http or https", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return "", fmt.Errorf("missing host")
+	}
+
+	return strings.TrimRight(instanceURL, "/"), nil
+}
+
+// isInsecureInstanceURL reports whether instanceURL uses plain http to reach
+// a host other than the local machine, which would send the API key over
+// the network unencrypted. URLs that cannot be parsed are not reported.
+func isInsecureInstanceURL(instanceURL string) bool {
+	parsed, err := url.Parse(instanceURL)
+	if err != nil || !strings.EqualFold(parsed.Scheme, "http") {
+		return false
+	}
+
+	return !isLocalHost(parsed.Hostname())
+}
+
+// isLocalHost reports whether host refers to the local machine.
+func isLocalHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// parseProxyURL parses the URL of an HTTP, HTTPS or SOCKS5 proxy, such as
+// http://proxy.internal:3128.
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q, expected http, https or socks5", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("missing proxy host")
+	}
+
+	return parsed, nil
+}
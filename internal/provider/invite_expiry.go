@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"time"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// defaultInviteTTL is how long an invitation is assumed to stay valid when
+// the provider invite_ttl is not set.
+const defaultInviteTTL = 7 * 24 * time.Hour
+
+// inviteExpired reports whether user's invitation is likely to have expired
+// at now: the user is still pending and was created more than ttl ago. The
+// API does not report invitation expiry, so this is a heuristic. A ttl of
+// zero or less means defaultInviteTTL.
+func inviteExpired(user *client.User, ttl time.Duration, now time.Time) bool {
+	if ttl <= 0 {
+		ttl = defaultInviteTTL
+	}
+
+	if !user.IsPending || user.CreatedAt.IsZero() {
+		return false
+	}
+
+	return now.Sub(user.CreatedAt.Time) > ttl
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestInviteExpired(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ttl := 72 * time.Hour
+
+	testCases := map[string]struct {
+		pending bool
+		created time.Time
+		ttl     time.Duration
+		now     time.Time
+		want    bool
+	}{
+		"pending within ttl":      {pending: true, created: created, ttl: ttl, now: created.Add(ttl - time.Second), want: false},
+		"pending at ttl":          {pending: true, created: created, ttl: ttl, now: created.Add(ttl), want: false},
+		"pending just past ttl":   {pending: true, created: created, ttl: ttl, now: created.Add(ttl + time.Nanosecond), want: true},
+		"accepted past ttl":       {pending: false, created: created, ttl: ttl, now: created.Add(2 * ttl), want: false},
+		"unknown creation time":   {pending: true, ttl: ttl, now: created.Add(2 * ttl), want: false},
+		"default ttl not reached": {pending: true, created: created, now: created.Add(defaultInviteTTL), want: false},
+		"default ttl passed":      {pending: true, created: created, now: created.Add(defaultInviteTTL + time.Second), want: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			user := &client.User{IsPending: tc.pending, CreatedAt: client.Time{Time: tc.created}}
+
+			if got := inviteExpired(user, tc.ttl, tc.now); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &InviteTokenFunction{}
+
+func NewInviteTokenFunction() function.Function {
+	return &InviteTokenFunction{}
+}
+
+// InviteTokenFunction defines the function implementation.
+type InviteTokenFunction struct{}
+
+// inviteTokenModel describes the object returned by the function.
+type inviteTokenModel struct {
+	InviterID string `tfsdk:"inviter_id"`
+	InviteeID string `tfsdk:"invitee_id"`
+}
+
+func (f *InviteTokenFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "invite_token"
+}
+
+func (f *InviteTokenFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Extract the invitation token from an invite accept URL",
+		MarkdownDescription: "Parses the `invite_accept_url` of an `n8ncloud_user` and returns the IDs identifying the invitation, from its `inviterId` and `inviteeId` query parameters, e.g. for sending the invitation through another channel. Fails for URLs that are malformed or lack either parameter.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The invite accept URL",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"inviter_id": types.StringType,
+				"invitee_id": types.StringType,
+			},
+		},
+	}
+}
+
+func (f *InviteTokenFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var inviteURL string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &inviteURL))
+
+	if resp.Error != nil {
+		return
+	}
+
+	token, err := parseInviteToken(inviteURL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid invite accept URL %q: %s", inviteURL, err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, token))
+}
+
+// parseInviteToken returns the inviter and invitee IDs of an n8n invite
+// accept URL, such as https://example.app.n8n.cloud/signup?inviterId=1&inviteeId=2.
+func parseInviteToken(inviteURL string) (inviteTokenModel, error) {
+	parsed, err := url.Parse(inviteURL)
+	if err != nil {
+		return inviteTokenModel{}, err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return inviteTokenModel{}, fmt.Errorf("the URL must be absolute")
+	}
+
+	query := parsed.Query()
+	token := inviteTokenModel{
+		InviterID: query.Get("inviterId"),
+		InviteeID: query.Get("inviteeId"),
+	}
+	if token.InviterID == "" {
+		return inviteTokenModel{}, fmt.Errorf("the inviterId query parameter is missing")
+	}
+	if token.InviteeID == "" {
+		return inviteTokenModel{}, fmt.Errorf("the inviteeId query parameter is missing")
+	}
+
+	return token, nil
+}
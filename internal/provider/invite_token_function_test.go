@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInviteTokenFunction_Run(t *testing.T) {
+	testCases := map[string]struct {
+		url       string
+		want      inviteTokenModel
+		wantError bool
+	}{
+		"valid": {
+			url:  "https://example.app.n8n.cloud/signup?inviterId=owner-1&inviteeId=user-2",
+			want: inviteTokenModel{InviterID: "owner-1", InviteeID: "user-2"},
+		},
+		"extra parameters": {
+			url:  "https://example.app.n8n.cloud/signup?inviteeId=user-2&source=email&inviterId=owner-1",
+			want: inviteTokenModel{InviterID: "owner-1", InviteeID: "user-2"},
+		},
+		"missing inviter": {
+			url:       "https://example.app.n8n.cloud/signup?inviteeId=user-2",
+			wantError: true,
+		},
+		"missing invitee": {
+			url:       "https://example.app.n8n.cloud/signup?inviterId=owner-1",
+			wantError: true,
+		},
+		"relative": {
+			url:       "/signup?inviterId=owner-1&inviteeId=user-2",
+			wantError: true,
+		},
+		"malformed": {
+			url:       "https://example.app.n8n.cloud/%zz",
+			wantError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(testCase.url)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(map[string]attr.Type{
+					"inviter_id": types.StringType,
+					"invitee_id": types.StringType,
+				})),
+			}
+
+			NewInviteTokenFunction().Run(context.Background(), req, resp)
+
+			if testCase.wantError {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got, ok := resp.Result.Value().(types.Object)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+			want := map[string]attr.Value{
+				"inviter_id": types.StringValue(testCase.want.InviterID),
+				"invitee_id": types.StringValue(testCase.want.InviteeID),
+			}
+			for name, value := range want {
+				if !got.Attributes()[name].Equal(value) {
+					t.Errorf("expected %s to be %s, got %s", name, value, got.Attributes()[name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LatestExecutionDataSource{}
+
+func NewLatestExecutionDataSource() datasource.DataSource {
+	return &LatestExecutionDataSource{}
+}
+
+// LatestExecutionDataSource defines the data source implementation.
+type LatestExecutionDataSource struct {
+	client *client.Client
+}
+
+// LatestExecutionDataSourceModel describes the data source data model.
+type LatestExecutionDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	WorkflowID  types.String `tfsdk:"workflow_id"`
+	ExecutionID types.String `tfsdk:"execution_id"`
+	Status      types.String `tfsdk:"status"`
+	Mode        types.String `tfsdk:"mode"`
+	Finished    types.Bool   `tfsdk:"finished"`
+	StartedAt   types.String `tfsdk:"started_at"`
+	StoppedAt   types.String `tfsdk:"stopped_at"`
+}
+
+func (d *LatestExecutionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latest_execution"
+}
+
+func (d *LatestExecutionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Latest execution data source for monitoring the most recent run of a workflow, e.g. to check that a scheduled workflow succeeded. If the workflow has never run, `execution_id` and the other execution attributes are null.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to the workflow ID",
+				Computed:            true,
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow",
+				Required:            true,
+			},
+			"execution_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the latest execution",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the execution, such as `success`, `error`, `waiting` or `running`. On instances that do not report it, it is derived from `finished` and the execution times.",
+				Computed:            true,
+			},
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "How the execution was started, such as `trigger`, `webhook` or `manual`",
+				Computed:            true,
+			},
+			"finished": schema.BoolAttribute{
+				MarkdownDescription: "Whether the execution finished successfully",
+				Computed:            true,
+			},
+			"started_at": schema.StringAttribute{
+				MarkdownDescription: "The start timestamp of the execution, in RFC3339 format",
+				Computed:            true,
+			},
+			"stopped_at": schema.StringAttribute{
+				MarkdownDescription: "The end timestamp of the execution, in RFC3339 format, or null while it is running",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *LatestExecutionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *LatestExecutionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LatestExecutionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.WorkflowID
+
+	execution, err := d.client.GetLatestExecution(ctx, data.WorkflowID.ValueString())
+	switch {
+	case client.IsNotFound(err):
+		// A workflow that never ran is not an error for monitoring
+		data.ExecutionID = types.StringNull()
+		data.Status = types.StringNull()
+		data.Mode = types.StringNull()
+		data.Finished = types.BoolNull()
+		data.StartedAt = types.StringNull()
+		data.StoppedAt = types.StringNull()
+	case err != nil:
+		addClientError(&resp.Diagnostics, "read latest execution", err)
+		return
+	default:
+		data.ExecutionID = types.StringValue(string(execution.ID))
+		data.Status = types.StringValue(client.ExecutionStatus(execution))
+		data.Mode = types.StringValue(execution.Mode)
+		data.Finished = types.BoolValue(execution.Finished)
+		data.StartedAt = executionTimeValue(execution.StartedAt)
+		data.StoppedAt = executionTimeValue(execution.StoppedAt)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// executionTimeValue returns t in RFC3339 format, or null if it is not set.
+func executionTimeValue(t client.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(t.Format(time.RFC3339Nano))
+}
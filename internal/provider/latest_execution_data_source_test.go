@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readLatestExecution reads d for workflowID and returns the resulting
+// state.
+func readLatestExecution(t *testing.T, d *LatestExecutionDataSource, workflowID string) LatestExecutionDataSourceModel {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	attributes["workflow_id"] = tftypes.NewValue(tftypes.String, workflowID)
+	config := tftypes.NewValue(objectType, attributes)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+	d.Read(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data LatestExecutionDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	return data
+}
+
+func TestLatestExecutionDataSourceRead(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"1001","workflowId":"wf1","finished":true,"mode":"trigger","startedAt":"2024-01-02T10:00:00Z","stoppedAt":"2024-01-02T10:00:02Z"},
+			{"id":"1000","workflowId":"wf1","finished":true,"mode":"trigger","startedAt":"2024-01-01T10:00:00Z","stoppedAt":"2024-01-01T10:00:05Z"},
+			{"id":"1002","workflowId":"wf1","finished":false,"mode":"trigger","startedAt":"2024-01-03T10:00:00.5Z","stoppedAt":null}
+		],"nextCursor":null}`))
+	})
+
+	data := readLatestExecution(t, &LatestExecutionDataSource{client: c}, "wf1")
+
+	want := LatestExecutionDataSourceModel{
+		ID:          types.StringValue("wf1"),
+		WorkflowID:  types.StringValue("wf1"),
+		ExecutionID: types.StringValue("1002"),
+		Status:      types.StringValue("running"),
+		Mode:        types.StringValue("trigger"),
+		Finished:    types.BoolValue(false),
+		StartedAt:   types.StringValue("2024-01-03T10:00:00.5Z"),
+		StoppedAt:   types.StringNull(),
+	}
+	if data != want {
+		t.Errorf("expected %+v, got %+v", want, data)
+	}
+}
+
+func TestLatestExecutionDataSourceRead_neverRun(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	data := readLatestExecution(t, &LatestExecutionDataSource{client: c}, "wf1")
+
+	if !data.ExecutionID.IsNull() || !data.Status.IsNull() || !data.Finished.IsNull() {
+		t.Errorf("expected null execution attributes for a workflow that never ran, got %+v", data)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ListAddedFunction{}
+
+func NewListAddedFunction() function.Function {
+	return &ListAddedFunction{}
+}
+
+// ListAddedFunction defines the function implementation.
+type ListAddedFunction struct{}
+
+func (f *ListAddedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "list_added"
+}
+
+func (f *ListAddedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "List the elements added between two string lists",
+		MarkdownDescription: "Returns the elements of `new` that are not in `old`, in the order they appear in `new` and without duplicates, e.g. the tags to add when reconciling a workflow's tags.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "old",
+				MarkdownDescription: "The previous list",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "new",
+				MarkdownDescription: "The desired list",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ListAddedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var oldList, newList []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &oldList, &newList))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, stringListDifference(newList, oldList)))
+}
+
+// stringListDifference returns the elements of a that are not in b, in the
+// order they appear in a and without duplicates.
+func stringListDifference(a, b []string) []string {
+	exclude := make(map[string]bool, len(b)+len(a))
+	for _, element := range b {
+		exclude[element] = true
+	}
+
+	difference := []string{}
+	for _, element := range a {
+		if exclude[element] {
+			continue
+		}
+		exclude[element] = true
+		difference = append(difference, element)
+	}
+
+	return difference
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestListDifferenceFunctions_Run(t *testing.T) {
+	testCases := map[string]struct {
+		old         []string
+		new         []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		"overlapping": {
+			old:         []string{"production", "billing", "legacy"},
+			new:         []string{"billing", "production", "finance"},
+			wantAdded:   []string{"finance"},
+			wantRemoved: []string{"legacy"},
+		},
+		"disjoint": {
+			old:         []string{"a", "b"},
+			new:         []string{"c", "d"},
+			wantAdded:   []string{"c", "d"},
+			wantRemoved: []string{"a", "b"},
+		},
+		"identical": {
+			old:         []string{"a", "b"},
+			new:         []string{"b", "a"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		"duplicates": {
+			old:         []string{"a", "a", "b"},
+			new:         []string{"c", "c"},
+			wantAdded:   []string{"c"},
+			wantRemoved: []string{"a", "b"},
+		},
+		"empty": {
+			old:         []string{},
+			new:         []string{"a"},
+			wantAdded:   []string{"a"},
+			wantRemoved: []string{},
+		},
+	}
+
+	functions := map[string]function.Function{
+		"list_added":   NewListAddedFunction(),
+		"list_removed": NewListRemovedFunction(),
+	}
+
+	for name, testCase := range testCases {
+		for functionName, f := range functions {
+			t.Run(name+"/"+functionName, func(t *testing.T) {
+				req := function.RunRequest{
+					Arguments: function.NewArgumentsData([]attr.Value{
+						stringListValue(t, testCase.old),
+						stringListValue(t, testCase.new),
+					}),
+				}
+				resp := &function.RunResponse{
+					Result: function.NewResultData(types.ListUnknown(types.StringType)),
+				}
+
+				f.Run(context.Background(), req, resp)
+
+				if resp.Error != nil {
+					t.Fatalf("unexpected error: %s", resp.Error)
+				}
+
+				got, ok := resp.Result.Value().(types.List)
+				if !ok {
+					t.Fatalf("unexpected result type %T", resp.Result.Value())
+				}
+
+				elements := []string{}
+				if diags := got.ElementsAs(context.Background(), &elements, false); diags.HasError() {
+					t.Fatalf("unexpected error converting result: %v", diags)
+				}
+
+				want := testCase.wantAdded
+				if functionName == "list_removed" {
+					want = testCase.wantRemoved
+				}
+				if !reflect.DeepEqual(elements, want) {
+					t.Errorf("expected %q, got %q", want, elements)
+				}
+			})
+		}
+	}
+}
+
+func stringListValue(t *testing.T, elements []string) types.List {
+	t.Helper()
+
+	value, diags := types.ListValueFrom(context.Background(), types.StringType, elements)
+	if diags.HasError() {
+		t.Fatalf("unexpected error building list: %v", diags)
+	}
+
+	return value
+}
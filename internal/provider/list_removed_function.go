@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ListRemovedFunction{}
+
+func NewListRemovedFunction() function.Function {
+	return &ListRemovedFunction{}
+}
+
+// ListRemovedFunction defines the function implementation.
+type ListRemovedFunction struct{}
+
+func (f *ListRemovedFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "list_removed"
+}
+
+func (f *ListRemovedFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "List the elements removed between two string lists",
+		MarkdownDescription: "Returns the elements of `old` that are not in `new`, in the order they appear in `old` and without duplicates, e.g. the tags to remove when reconciling a workflow's tags.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "old",
+				MarkdownDescription: "The previous list",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "new",
+				MarkdownDescription: "The desired list",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ListRemovedFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var oldList, newList []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &oldList, &newList))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, stringListDifference(oldList, newList)))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MergeSettingsFunction{}
+
+func NewMergeSettingsFunction() function.Function {
+	return &MergeSettingsFunction{}
+}
+
+// MergeSettingsFunction defines the function implementation.
+type MergeSettingsFunction struct{}
+
+func (f *MergeSettingsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_settings"
+}
+
+func (f *MergeSettingsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Deep-merge two workflow settings JSON objects",
+		MarkdownDescription: "Deep-merges two JSON objects, such as workflow settings composed from several module inputs. Nested objects are merged recursively and any other value in `override` replaces the value in `base`. Returns the merged object as a JSON string with keys in sorted order.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "base",
+				MarkdownDescription: "The base settings as a JSON object string",
+			},
+			function.StringParameter{
+				Name:                "override",
+				MarkdownDescription: "The settings that take precedence, as a JSON object string",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *MergeSettingsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, override string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &base, &override))
+
+	if resp.Error != nil {
+		return
+	}
+
+	baseObject, err := decodeJSONObject(base)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid base settings: %s", err))
+		return
+	}
+
+	overrideObject, err := decodeJSONObject(override)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid override settings: %s", err))
+		return
+	}
+
+	merged, err := encodeJSON(deepMerge(baseObject, overrideObject))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Unable to encode merged settings: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, merged))
+}
+
+// deepMerge merges override into base, recursing into values that are
+// objects on both sides. Neither input is modified.
+func deepMerge(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, overrideValue := range override {
+		baseObject, baseIsObject := merged[key].(map[string]interface{})
+		overrideObject, overrideIsObject := overrideValue.(map[string]interface{})
+
+		if baseIsObject && overrideIsObject {
+			merged[key] = deepMerge(baseObject, overrideObject)
+			continue
+		}
+
+		merged[key] = overrideValue
+	}
+
+	return merged
+}
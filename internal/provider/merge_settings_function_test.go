@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMergeSettingsFunction_Run(t *testing.T) {
+	testCases := map[string]struct {
+		base     string
+		override string
+		want     string
+		wantErr  bool
+	}{
+		"override precedence": {
+			base:     `{"timezone":"UTC","saveManualExecutions":true}`,
+			override: `{"timezone":"America/New_York"}`,
+			want:     `{"saveManualExecutions":true,"timezone":"America/New_York"}`,
+		},
+		"nested objects": {
+			base:     `{"a":{"b":1,"c":{"d":2}},"list":[1,2]}`,
+			override: `{"a":{"c":{"e":3}},"list":[3]}`,
+			want:     `{"a":{"b":1,"c":{"d":2,"e":3}},"list":[3]}`,
+		},
+		"object replaced by scalar": {
+			base:     `{"a":{"b":1}}`,
+			override: `{"a":null}`,
+			want:     `{"a":null}`,
+		},
+		"number precision": {
+			base:     `{"executionTimeout":3600}`,
+			override: `{"big":12345678901234567890}`,
+			want:     `{"big":12345678901234567890,"executionTimeout":3600}`,
+		},
+		"invalid base": {
+			base:     `[1]`,
+			override: `{}`,
+			wantErr:  true,
+		},
+		"invalid override": {
+			base:     `{}`,
+			override: `{`,
+			wantErr:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(testCase.base),
+					types.StringValue(testCase.override),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewMergeSettingsFunction().Run(context.Background(), req, resp)
+
+			if testCase.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got, ok := resp.Result.Value().(types.String)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+			if got.ValueString() != testCase.want {
+				t.Errorf("expected %s, got %s", testCase.want, got.ValueString())
+			}
+		})
+	}
+}
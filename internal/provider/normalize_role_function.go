@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeRoleFunction{}
+
+func NewNormalizeRoleFunction() function.Function {
+	return &NormalizeRoleFunction{}
+}
+
+// NormalizeRoleFunction defines the function implementation.
+type NormalizeRoleFunction struct{}
+
+func (f *NormalizeRoleFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_role"
+}
+
+func (f *NormalizeRoleFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize a user role to the form the n8n API expects",
+		MarkdownDescription: "Returns the n8n API form of a user role, such as `global:admin` for `admin` or `GLOBAL:ADMIN`, e.g. for sanitizing roles read from an external source before setting the `role` of an `n8ncloud_user`. Accepts the roles and aliases the `role` attribute accepts, matched case-insensitively and ignoring surrounding whitespace. Deprecated roles are replaced with their successor. Fails for roles that cannot be assigned to users.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "role",
+				MarkdownDescription: "The role or role alias to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeRoleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var role string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &role))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if !isKnownRole(strings.TrimSpace(role)) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown role %q. Valid roles are: %s, or the aliases %s", role, strings.Join(userRoles, ", "), strings.Join(roleAliasNames(), ", ")))
+		return
+	}
+
+	result := canonicalRole(strings.TrimSpace(role))
+	if replacement, ok := roleReplacement(result); ok {
+		result = replacement
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
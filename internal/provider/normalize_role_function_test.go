@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestNormalizeRoleFunction_Run(t *testing.T) {
+	testCases := map[string]struct {
+		role      string
+		want      string
+		wantError bool
+	}{
+		"api form":   {role: "global:admin", want: "global:admin"},
+		"upper case": {role: "GLOBAL:MEMBER", want: "global:member"},
+		"admin":      {role: "admin", want: "global:admin"},
+		"member":     {role: "Member", want: "global:member"},
+		"user":       {role: "user", want: "global:member"},
+		"whitespace": {role: " admin\n", want: "global:admin"},
+		"owner":      {role: "global:owner", wantError: true},
+		"project":    {role: "project:editor", wantError: true},
+		"unknown":    {role: "superuser", wantError: true},
+		"empty":      {role: "", wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := runRoleFunction(t, NewNormalizeRoleFunction(), testCase.role)
+			if testCase.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != testCase.want {
+				t.Errorf("expected normalize_role(%q) to be %q, got %q", testCase.role, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// projectTransfer describes a kind of object that transfer resources move
+// between projects, for the transfer handling they share.
+type projectTransfer struct {
+	// name is the kind of object in diagnostics, e.g. "workflow", and title
+	// the same capitalized, e.g. "Workflow".
+	name  string
+	title string
+	// idAttribute is the attribute holding the ID of the object.
+	idAttribute string
+
+	// get reads the object to check that it exists. It is nil for objects
+	// the API cannot read one at a time.
+	get func(ctx context.Context, c *client.Client, id string) error
+	// transfer moves the object to the project with ID projectID.
+	transfer func(ctx context.Context, c *client.Client, id, projectID string) error
+}
+
+// workflowTransfer moves workflows between projects.
+var workflowTransfer = projectTransfer{
+	name:        "workflow",
+	title:       "Workflow",
+	idAttribute: "workflow_id",
+	get: func(ctx context.Context, c *client.Client, id string) error {
+		_, err := c.GetWorkflow(ctx, id)
+		return err
+	},
+	transfer: func(ctx context.Context, c *client.Client, id, projectID string) error {
+		return c.TransferWorkflow(ctx, id, projectID)
+	},
+}
+
+// credentialTransfer moves credentials between projects. The public API
+// has no endpoint reading a single credential, so it has no get.
+var credentialTransfer = projectTransfer{
+	name:        "credential",
+	title:       "Credential",
+	idAttribute: "credential_id",
+	transfer: func(ctx context.Context, c *client.Client, id, projectID string) error {
+		return c.TransferCredential(ctx, id, projectID)
+	},
+}
+
+// run transfers the object with the given ID to the project with ID
+// projectID. An object already owned by the project counts as transferred,
+// since the project ends up owning it either way. It reports false after
+// adding a diagnostic if the transfer failed.
+func (t projectTransfer) run(ctx context.Context, diags *diag.Diagnostics, c *client.Client, id, projectID string) bool {
+	tflog.Debug(ctx, fmt.Sprintf("Transferring n8n %s to project", t.name), map[string]interface{}{
+		t.idAttribute:            id,
+		"destination_project_id": projectID,
+	})
+
+	err := t.transfer(ctx, c, id, projectID)
+	if client.IsAlreadyInProjectError(err) {
+		tflog.Debug(ctx, fmt.Sprintf("n8n %s is already in the destination project", t.name), map[string]interface{}{
+			t.idAttribute:            id,
+			"destination_project_id": projectID,
+		})
+		return true
+	}
+	if err != nil {
+		t.addError(ctx, diags, c, id, projectID, err)
+		return false
+	}
+
+	return true
+}
+
+// addError adds a diagnostic for a failed transfer. The API answers 404 for
+// an unknown object and an unknown project alike, so both are looked up to
+// tell which one is missing. An object that cannot be looked up is reported
+// missing once the project is found.
+func (t projectTransfer) addError(ctx context.Context, diags *diag.Diagnostics, c *client.Client, id, projectID string, err error) {
+	action := fmt.Sprintf("transfer %s", t.name)
+
+	if client.IsNotFound(err) {
+		if t.get != nil {
+			if lookupErr := t.get(ctx, c, id); client.IsNotFound(lookupErr) {
+				t.addNotFoundError(diags, id)
+				return
+			}
+		}
+
+		_, lookupErr := c.GetProject(ctx, projectID)
+		if client.IsNotFound(lookupErr) {
+			diags.AddAttributeError(
+				path.Root("destination_project_id"),
+				"Project Not Found",
+				fmt.Sprintf("Project with ID %q not found. Unable to %s %q to it.", projectID, t.name, id),
+			)
+			return
+		}
+		if lookupErr != nil {
+			err = lookupErr
+		} else if t.get == nil {
+			t.addNotFoundError(diags, id)
+			return
+		}
+	}
+
+	if client.IsFeatureUnavailableError(err) {
+		diags.AddError(
+			"Projects Unavailable",
+			fmt.Sprintf("Unable to %s because the n8n instance does not support moving objects between projects. "+
+				"Transfers require an Enterprise license and an n8n version with the transfer endpoint. API error: %s", action, err),
+		)
+		return
+	}
+
+	addClientError(diags, action, err)
+}
+
+// addNotFoundError adds a diagnostic for the object with the given ID not
+// being found.
+func (t projectTransfer) addNotFoundError(diags *diag.Diagnostics, id string) {
+	diags.AddAttributeError(
+		path.Root(t.idAttribute),
+		fmt.Sprintf("%s Not Found", t.title),
+		fmt.Sprintf("%s with ID %q not found", t.title, id),
+	)
+}
@@ -0,0 +1,370 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectUserResource{}
+var _ resource.ResourceWithImportState = &ProjectUserResource{}
+
+func NewProjectUserResource() resource.Resource {
+	return &ProjectUserResource{}
+}
+
+// ProjectUserResource defines the resource implementation.
+type ProjectUserResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// ProjectUserResourceModel describes the resource data model.
+type ProjectUserResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ProjectID         types.String `tfsdk:"project_id"`
+	UserID            types.String `tfsdk:"user_id"`
+	Role              types.String `tfsdk:"role"`
+	SkipIfUnavailable types.Bool   `tfsdk:"skip_if_unavailable"`
+	Skipped           types.Bool   `tfsdk:"skipped"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *ProjectUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_user"
+}
+
+func (r *ProjectUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Project user resource for assigning a user a role in a project. Projects require an Enterprise license. " +
+			"Memberships can be imported using `project_id:user_id`: `terraform import n8ncloud_project_user.example <project_id>:<user_id>`. " +
+			"The API does not report the user's role in a project, so changes to the role made outside of Terraform are not detected, and the role is applied again on the first apply after an import.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the membership, in the form `project_id:user_id`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project. Changing it moves the user to the other project by replacing the membership.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user. Changing it replaces the membership.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The role of the user in the project, one of %s. Changing it updates the membership in place.", "`"+strings.Join(projectRoles, "`, `")+"`"),
+				Required:            true,
+				Validators: []validator.String{
+					projectRoleValidator{},
+				},
+			},
+			"skip_if_unavailable": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the resource with a warning instead of failing when the instance does not support projects, e.g. a Community edition instance without an Enterprise license. The API answers 404 for an unknown project as well, so a wrong `project_id` is skipped too. A skipped resource records its planned membership in state without touching the instance, is not refreshed, and is removed from state on destroy without any API call. It is retried on the next change to `role`; replace it to retry sooner once the feature is available. Defaults to `false`.",
+				Optional:            true,
+			},
+			"skipped": schema.BoolAttribute{
+				MarkdownDescription: "Whether the resource was skipped because the instance does not support projects. Only set when `skip_if_unavailable` is true.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *ProjectUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *ProjectUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectUserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	tflog.Debug(ctx, "Adding user to n8n project", map[string]interface{}{
+		"project_id": data.ProjectID.ValueString(),
+		"user_id":    data.UserID.ValueString(),
+		"role":       data.Role.ValueString(),
+	})
+
+	data.ID = types.StringValue(projectUserID(data.ProjectID.ValueString(), data.UserID.ValueString()))
+	data.Skipped = types.BoolValue(false)
+
+	err := r.client.AddProjectUser(ctx, data.ProjectID.ValueString(), data.UserID.ValueString(), data.Role.ValueString())
+	if err != nil {
+		if !skipUnavailableProjectUser(&data, &resp.Diagnostics, err) {
+			addProjectUserError(&resp.Diagnostics, "add user to project", err)
+			return
+		}
+	} else {
+		r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_project_user", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Created n8n project user resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A skipped resource has nothing on the instance to refresh
+	if data.Skipped.ValueBool() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	// Listing the members of an unknown project returns nothing rather than
+	// an error, so check that the project still exists first
+	_, err := r.client.GetProject(ctx, data.ProjectID.ValueString())
+	if client.IsNotFound(err) {
+		tflog.Warn(ctx, "n8n project not found, removing project user from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read project", err)
+		return
+	}
+
+	members, err := r.client.ListProjectUsers(ctx, data.ProjectID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list project users", err)
+		return
+	}
+
+	found := false
+	for _, member := range members {
+		if member.ID == data.UserID.ValueString() {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		// The user was removed from the project outside of Terraform, so
+		// plan to add them again
+		tflog.Warn(ctx, "n8n project user not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(projectUserID(data.ProjectID.ValueString(), data.UserID.ValueString()))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ProjectUserResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	data.Skipped = types.BoolValue(false)
+
+	// Only the role can change in place. Nothing was created for a skipped
+	// resource, so the user is added instead.
+	action := "change project role"
+	var err error
+	if state.Skipped.ValueBool() {
+		action = "add user to project"
+		err = r.client.AddProjectUser(ctx, data.ProjectID.ValueString(), data.UserID.ValueString(), data.Role.ValueString())
+	} else {
+		err = r.client.ChangeProjectUserRole(ctx, data.ProjectID.ValueString(), data.UserID.ValueString(), data.Role.ValueString())
+	}
+	if err != nil {
+		if !state.Skipped.ValueBool() || !skipUnavailableProjectUser(&data, &resp.Diagnostics, err) {
+			addProjectUserError(&resp.Diagnostics, action, err)
+			return
+		}
+	} else {
+		r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_project_user", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Updated n8n project user resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectUserResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing was created for a skipped resource, so there is nothing to delete
+	if data.Skipped.ValueBool() {
+		tflog.Trace(ctx, "Removed skipped n8n project user resource")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+	defer cancel()
+
+	err := r.client.RemoveProjectUser(ctx, data.ProjectID.ValueString(), data.UserID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		addProjectUserError(&resp.Diagnostics, "remove user from project", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_project_user", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n project user resource")
+}
+
+func (r *ProjectUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, userID, ok := parseProjectUserID(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form project_id:user_id, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userID)...)
+}
+
+// skipUnavailableProjectUser marks data as skipped and adds a warning to
+// diags when err reports that the instance does not support projects and the
+// configuration opted into skipping. It reports whether the resource was
+// skipped.
+func skipUnavailableProjectUser(data *ProjectUserResourceModel, diags *diag.Diagnostics, err error) bool {
+	if !data.SkipIfUnavailable.ValueBool() || !client.IsFeatureUnavailableError(err) {
+		return false
+	}
+
+	data.Skipped = types.BoolValue(true)
+
+	diags.AddWarning(
+		"Projects Unavailable",
+		fmt.Sprintf("The n8n instance does not support projects, so the resource was skipped because skip_if_unavailable is set. The user was not added to project %s. Replace the resource once the feature is available to add them.\n\nAPI response: %s", data.ProjectID.ValueString(), err),
+	)
+
+	return true
+}
+
+// projectUserID returns the ID of the membership of a user in a project.
+func projectUserID(projectID, userID string) string {
+	return projectID + ":" + userID
+}
+
+// parseProjectUserID splits a membership ID into the project and user IDs,
+// reporting false if id is not of the form project_id:user_id.
+func parseProjectUserID(id string) (string, string, bool) {
+	projectID, userID, ok := strings.Cut(id, ":")
+	if !ok || projectID == "" || userID == "" || strings.Contains(userID, ":") {
+		return "", "", false
+	}
+
+	return projectID, userID, true
+}
+
+// addProjectUserError adds a diagnostic for a failed attempt to action a
+// project membership. n8n rejects changes that would leave a project
+// without an admin, so those get their own diagnostic carrying the hint the
+// API gives on how to proceed.
+func addProjectUserError(diags *diag.Diagnostics, action string, err error) {
+	if client.IsLastProjectAdminError(err) {
+		var apiErr *client.APIError
+		errors.As(err, &apiErr)
+
+		detail := apiErr.Hint
+		if detail == "" {
+			detail = "Assign another member the project:admin role first."
+		}
+
+		diags.AddError(
+			"Last Project Admin",
+			fmt.Sprintf("Unable to %s because the project must keep at least one admin. %s API error: %s", action, detail, err),
+		)
+		return
+	}
+
+	addClientError(diags, action, err)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// projectUserTestHandler serves a project p1 whose only member is u1, and
+// rejects removing members with the last admin error.
+func projectUserTestHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects":
+		_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"Team A","type":"team"}],"nextCursor":null}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/users" && r.URL.Query().Get("projectId") == "p1":
+		_, _ = w.Write([]byte(`{"data":[{"id":"u1","email":"ada@example.com"}],"nextCursor":null}`))
+	case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/projects/p1/users/u1":
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Cannot remove the last admin of the project","hint":"Make another member a project admin first."}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestProjectUserResourceRead(t *testing.T) {
+	testCases := map[string]struct {
+		projectID   string
+		userID      string
+		wantRemoved bool
+	}{
+		"member":          {projectID: "p1", userID: "u1"},
+		"removed member":  {projectID: "p1", userID: "u2", wantRemoved: true},
+		"deleted project": {projectID: "p2", userID: "u1", wantRemoved: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &ProjectUserResource{client: newTestClient(t, projectUserTestHandler)}
+
+			projectUserSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, projectUserID(testCase.projectID, testCase.userID)),
+				"project_id": tftypes.NewValue(tftypes.String, testCase.projectID),
+				"user_id":    tftypes.NewValue(tftypes.String, testCase.userID),
+				"role":       tftypes.NewValue(tftypes.String, "project:editor"),
+			})
+
+			resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: projectUserSchema, Raw: prior}}
+			r.Read(context.Background(), fwresource.ReadRequest{State: tfsdk.State{Schema: projectUserSchema, Raw: prior}}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if got := resp.State.Raw.IsNull(); got != testCase.wantRemoved {
+				t.Errorf("expected removal from state to be %t, got %t", testCase.wantRemoved, got)
+			}
+		})
+	}
+}
+
+func TestProjectUserResourceDelete_lastAdmin(t *testing.T) {
+	r := &ProjectUserResource{client: newTestClient(t, projectUserTestHandler)}
+
+	projectUserSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":         tftypes.NewValue(tftypes.String, "p1:u1"),
+		"project_id": tftypes.NewValue(tftypes.String, "p1"),
+		"user_id":    tftypes.NewValue(tftypes.String, "u1"),
+		"role":       tftypes.NewValue(tftypes.String, "project:admin"),
+	})
+
+	state := tfsdk.State{Schema: projectUserSchema, Raw: prior}
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Last Project Admin" {
+		t.Fatalf("expected a Last Project Admin error, got: %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "Make another member a project admin first.") {
+		t.Errorf("expected the API hint in the diagnostic, got: %s", detail)
+	}
+}
+
+func TestProjectUserResource_skipIfUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int
+	r := &ProjectUserResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Your license does not allow for feat:projectRole:admin. To enable feat:projectRole:admin, please upgrade to a license that supports this feature."}`))
+	})}
+
+	projectUserSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"project_id":          tftypes.NewValue(tftypes.String, "p1"),
+		"user_id":             tftypes.NewValue(tftypes.String, "u1"),
+		"role":                tftypes.NewValue(tftypes.String, "project:editor"),
+		"skip_if_unavailable": tftypes.NewValue(tftypes.Bool, true),
+		"skipped":             tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: projectUserSchema, Raw: plan}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: projectUserSchema, Raw: plan}}, createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if len(createResp.Diagnostics.Warnings()) != 1 || createResp.Diagnostics.Warnings()[0].Summary() != "Projects Unavailable" {
+		t.Fatalf("expected a Projects Unavailable warning, got: %v", createResp.Diagnostics)
+	}
+
+	var state ProjectUserResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if !state.Skipped.ValueBool() || state.ID.ValueString() != "p1:u1" {
+		t.Fatalf("expected the membership p1:u1 to be recorded as skipped, got id %s and skipped %s", state.ID, state.Skipped)
+	}
+
+	// Refreshing and destroying a skipped resource must not call the API.
+	requests = 0
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected the skipped resource to be kept on read, got: %v", readResp.Diagnostics)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error on delete: %v", deleteResp.Diagnostics)
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no API requests for a skipped resource, got %d", requests)
+	}
+}
+
+func TestParseProjectUserID(t *testing.T) {
+	testCases := map[string]struct {
+		id            string
+		wantProjectID string
+		wantUserID    string
+		wantOK        bool
+	}{
+		"valid":         {id: "p1:u1", wantProjectID: "p1", wantUserID: "u1", wantOK: true},
+		"no separator":  {id: "p1", wantOK: false},
+		"empty project": {id: ":u1", wantOK: false},
+		"empty user":    {id: "p1:", wantOK: false},
+		"extra part":    {id: "p1:u1:x", wantOK: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			projectID, userID, ok := parseProjectUserID(testCase.id)
+
+			if ok != testCase.wantOK {
+				t.Fatalf("expected ok %t, got %t", testCase.wantOK, ok)
+			}
+			if projectID != testCase.wantProjectID || userID != testCase.wantUserID {
+				t.Errorf("expected %q and %q, got %q and %q", testCase.wantProjectID, testCase.wantUserID, projectID, userID)
+			}
+		})
+	}
+}
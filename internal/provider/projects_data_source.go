@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProjectsDataSource{}
+
+func NewProjectsDataSource() datasource.DataSource {
+	return &ProjectsDataSource{}
+}
+
+// ProjectsDataSource defines the data source implementation.
+type ProjectsDataSource struct {
+	client *client.Client
+}
+
+// ProjectsDataSourceModel describes the data source data model.
+type ProjectsDataSourceModel struct {
+	ID         types.String         `tfsdk:"id"`
+	Name       types.String         `tfsdk:"name"`
+	ExtraQuery map[string]string    `tfsdk:"extra_query"`
+	Projects   []ListedProjectModel `tfsdk:"projects"`
+}
+
+// ListedProjectModel describes a project returned by the data source.
+type ListedProjectModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *ProjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *ProjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Projects data source for listing the projects of the instance, e.g. to look a project up by name for `n8ncloud_workflow_transfer` or `n8ncloud_project_user` instead of hardcoding its ID. Projects require an Enterprise license.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to `projects`",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Restricts the projects to those with exactly this name. The API cannot filter projects, so every project is read and filtered by the provider.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "The projects, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the project",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the project",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the project: `personal` for the personal project of a user or `team` for a shared project",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ProjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ProjectsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, err := d.client.ListProjects(ctx, &client.ListOptions{ExtraQuery: data.ExtraQuery})
+	if client.IsFeatureUnavailableError(err) {
+		resp.Diagnostics.AddError(
+			"Projects Unavailable",
+			fmt.Sprintf("Unable to list projects because the n8n instance does not support them. Projects require an Enterprise license. API error: %s", err),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list projects", err)
+		return
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	data.Projects = make([]ListedProjectModel, 0, len(projects))
+	for _, project := range projects {
+		if !data.Name.IsNull() && project.Name != data.Name.ValueString() {
+			continue
+		}
+
+		data.Projects = append(data.Projects, ListedProjectModel{
+			ID:   types.StringValue(project.ID),
+			Name: types.StringValue(project.Name),
+			Type: optionalStringValue(project.Type),
+		})
+	}
+
+	data.ID = types.StringValue("projects")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
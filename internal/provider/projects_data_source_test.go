@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readProjects reads d with the given name filter, or none if it is empty,
+// and returns the resulting state and diagnostics.
+func readProjects(t *testing.T, d *ProjectsDataSource, name string) (ProjectsDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for attribute, attributeType := range objectType.AttributeTypes {
+		attributes[attribute] = tftypes.NewValue(attributeType, nil)
+	}
+	if name != "" {
+		attributes["name"] = tftypes.NewValue(tftypes.String, name)
+	}
+	config := tftypes.NewValue(objectType, attributes)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+	d.Read(ctx, req, resp)
+
+	var data ProjectsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+	}
+
+	return data, resp.Diagnostics
+}
+
+func TestProjectsDataSourceRead(t *testing.T) {
+	var pages int
+	d := &ProjectsDataSource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"p3","name":"Sales","type":"team"},{"id":"p1","name":"Ada Lovelace <ada@example.com>","type":"personal"}],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"p2","name":"Marketing","type":"team"}],"nextCursor":null}`))
+	})}
+
+	testCases := map[string]struct {
+		name string
+		want []string
+	}{
+		"all":     {want: []string{"p1", "p2", "p3"}},
+		"name":    {name: "Sales", want: []string{"p3"}},
+		"partial": {name: "Sale"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pages = 0
+			data, diags := readProjects(t, d, tc.name)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var ids []string
+			for _, project := range data.Projects {
+				ids = append(ids, project.ID.ValueString())
+			}
+			if !slices.Equal(ids, tc.want) {
+				t.Errorf("expected projects %v, got %v", tc.want, ids)
+			}
+			if pages != 2 {
+				t.Errorf("expected every page to be read, got %d", pages)
+			}
+		})
+	}
+}
+
+func TestProjectsDataSourceRead_unavailable(t *testing.T) {
+	d := &ProjectsDataSource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Your license does not allow for feat:projectRole:admin."}`))
+	})}
+
+	_, diags := readProjects(t, d, "")
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Projects Unavailable" {
+		t.Fatalf("expected a Projects Unavailable error, got: %v", diags)
+	}
+}
@@ -6,7 +6,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -16,7 +19,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
 )
 
@@ -35,9 +40,112 @@ type N8nCloudProvider struct {
 
 // N8nCloudProviderModel describes the provider data model.
 type N8nCloudProviderModel struct {
-	APIKey      types.String `tfsdk:"api_key"`
-	InstanceURL types.String `tfsdk:"instance_url"`
-	Timeout     types.Int64  `tfsdk:"timeout"`
+	APIKey                  types.String  `tfsdk:"api_key"`
+	APIKeyFile              types.String  `tfsdk:"api_key_file"`
+	ReloadKeyOnAuthError    types.Bool    `tfsdk:"reload_key_on_auth_error"`
+	InstanceURL             types.String  `tfsdk:"instance_url"`
+	APIBasePath             types.String  `tfsdk:"api_base_path"`
+	Timeout                 types.Int64   `tfsdk:"timeout"`
+	SlowRequestThreshold    types.Int64   `tfsdk:"slow_request_threshold"`
+	ExposeRaw               types.Bool    `tfsdk:"expose_raw"`
+	AcceptHeader            types.String  `tfsdk:"accept_header"`
+	WorkspaceID             types.String  `tfsdk:"workspace_id"`
+	PageSize                types.Int64   `tfsdk:"page_size"`
+	RetryOnStatus           types.List    `tfsdk:"retry_on_status"`
+	MaxRetries              types.Int64   `tfsdk:"max_retries"`
+	RequestHeaders          types.Map     `tfsdk:"request_headers"`
+	AllowInsecureHTTP       types.Bool    `tfsdk:"allow_insecure_http"`
+	DisableCompression      types.Bool    `tfsdk:"disable_compression"`
+	ForceHTTP1              types.Bool    `tfsdk:"force_http1"`
+	CACertFile              types.String  `tfsdk:"ca_cert_file"`
+	ProxyURL                types.String  `tfsdk:"proxy_url"`
+	InsecureSkipVerify      types.Bool    `tfsdk:"insecure_skip_verify"`
+	CACertPEM               types.String  `tfsdk:"ca_cert_pem"`
+	MethodOverride          types.Bool    `tfsdk:"method_override"`
+	DryRun                  types.Bool    `tfsdk:"dry_run"`
+	SendNullForEmpty        types.Bool    `tfsdk:"send_null_for_empty"`
+	GlobalDeadline          types.String  `tfsdk:"global_deadline"`
+	DetectVersion           types.Bool    `tfsdk:"detect_version"`
+	SkipCredentialsCheck    types.Bool    `tfsdk:"skip_credentials_validation"`
+	MaxConcurrentRequests   types.Int64   `tfsdk:"max_concurrent_requests"`
+	RateLimit               types.Float64 `tfsdk:"rate_limit"`
+	MaxIdleConnsPerHost     types.Int64   `tfsdk:"max_idle_conns_per_host"`
+	MaxConnsPerHost         types.Int64   `tfsdk:"max_conns_per_host"`
+	CircuitBreakerThreshold types.Int64   `tfsdk:"circuit_breaker_threshold"`
+	ChangeReportFile        types.String  `tfsdk:"change_report_file"`
+	InviteTTL               types.String  `tfsdk:"invite_ttl"`
+	DefaultUserRole         types.String  `tfsdk:"default_user_role"`
+	ManagedMarkerTag        types.String  `tfsdk:"managed_marker_tag"`
+	EnableReadCache         types.Bool    `tfsdk:"enable_read_cache"`
+}
+
+// readCacheTTL is how long API responses are reused with enable_read_cache,
+// long enough to cover the reads of one plan or apply walk but short enough
+// that a later operation in the same run sees fresh data.
+const readCacheTTL = 5 * time.Second
+
+// N8nCloudProviderData is made available to resources and data sources
+// during their Configure methods.
+type N8nCloudProviderData struct {
+	Client *client.Client
+
+	// ExposeRaw enables populating raw_json attributes with the server
+	// response.
+	ExposeRaw bool
+
+	// ChangeReport records the changes resources make, or is nil when no
+	// change_report_file is configured.
+	ChangeReport *changeReport
+
+	// InviteTTL is how long invitations are assumed to stay valid, for the
+	// invite_expired attributes.
+	InviteTTL time.Duration
+
+	// DefaultUserRole is the role of users whose role is not configured, in
+	// its canonical form, or empty to leave it to n8n.
+	DefaultUserRole string
+
+	// InstanceURL is the URL of the n8n instance, without a trailing slash,
+	// for links to the editor.
+	InstanceURL string
+
+	// ManagedMarkerTag is the name of the tag the workflow resource attaches
+	// to every workflow it manages, or empty when none is configured.
+	ManagedMarkerTag string
+}
+
+// apiKeyOverrideDescription documents the per-resource api_key attribute.
+const apiKeyOverrideDescription = "An API key to use for this resource's requests instead of the provider `api_key`, for setups where resources are managed with differently scoped keys. " +
+	"The key is stored in the Terraform state, so protect the state accordingly. Defaults to the provider API key."
+
+// clientWithAPIKeyOverride returns c, or a client authenticating with apiKey
+// when a per-resource key override is set.
+func clientWithAPIKeyOverride(c *client.Client, apiKey types.String) *client.Client {
+	if apiKey.IsNull() || apiKey.IsUnknown() {
+		return c
+	}
+
+	key := strings.TrimSpace(apiKey.ValueString())
+	if key == "" {
+		return c
+	}
+
+	return c.WithAPIKey(key)
+}
+
+// requestHeadersDescription documents the per-resource request_headers
+// attribute.
+const requestHeadersDescription = "Advanced: extra HTTP headers sent with this resource's API requests, keyed by header name, e.g. for a gateway with resource-specific routing. " +
+	"They are merged with the provider `request_headers`, replacing provider headers of the same name. Headers the provider manages cannot be set."
+
+// clientWithRequestHeaders returns c, or a client that also sends headers
+// when per-resource request headers are set.
+func clientWithRequestHeaders(c *client.Client, headers map[string]string) *client.Client {
+	if len(headers) == 0 {
+		return c
+	}
+
+	return c.WithHeaders(headers)
 }
 
 func (p *N8nCloudProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -54,12 +162,165 @@ func (p *N8nCloudProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the API key, e.g. one written by a secrets manager or mounted from a Kubernetes secret. Can also be set via N8N_API_KEY_FILE environment variable. Takes precedence over the N8N_API_KEY environment variable. When `api_key` is also set, the file must contain the same key.",
+				Optional:            true,
+			},
+			"reload_key_on_auth_error": schema.BoolAttribute{
+				MarkdownDescription: "Whether to re-read `api_key_file` when a request is rejected with 401 Unauthorized and retry it once with the new key, so a rotated key is picked up without restarting the provider. Requires `api_key_file` or the N8N_API_KEY_FILE environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
 			"instance_url": schema.StringAttribute{
 				MarkdownDescription: "The URL of your n8n cloud instance (e.g., https://yourinstance.app.n8n.cloud). Can also be set via N8N_INSTANCE_URL environment variable.",
 				Optional:            true,
 			},
+			"api_base_path": schema.StringAttribute{
+				MarkdownDescription: "Advanced: the path of the n8n API under `instance_url`. Defaults to `/api/v1`, where n8n serves its public API, which authenticates with the API key and which every n8n version with API keys provides. " +
+					"Older n8n versions managed users only through the editor's internal `/rest` API, which authenticates with a browser session instead of an API key, so set `/rest` only behind a gateway that authenticates those requests itself. " +
+					"Other values suit gateways that expose the public API under a different path; use `/` for the root of `instance_url`.",
+				Optional: true,
+			},
+			"allow_insecure_http": schema.BoolAttribute{
+				MarkdownDescription: "Suppresses the warning shown when `instance_url` uses plain `http://` for a host other than localhost, which sends the API key unencrypted. Defaults to false.",
+				Optional:            true,
+			},
 			"timeout": schema.Int64Attribute{
-				MarkdownDescription: "The timeout for API requests in seconds. Defaults to 30.",
+				MarkdownDescription: "The timeout for each attempt of an API request in seconds, including reading the response. Can also be set via N8N_TIMEOUT environment variable. Defaults to 30. Whole resource operations, including retries, are bounded by the `timeouts` block of the resource instead.",
+				Optional:            true,
+			},
+			"slow_request_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Requests taking longer than this many seconds are logged as warnings. Set to 0 to disable. Defaults to 5.",
+				Optional:            true,
+			},
+			"accept_header": schema.StringAttribute{
+				MarkdownDescription: "Overrides the `Accept` header sent with every API request, e.g. to request a specific API response version such as `application/vnd.n8n.v2+json`. Defaults to `application/json`.",
+				Optional:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "The number of items requested per page when listing from the API, between 1 and 250. Defaults to 250 to minimize round-trips.",
+				Optional:            true,
+			},
+			"workspace_id": schema.StringAttribute{
+				MarkdownDescription: "Scopes all API requests to a workspace by sending its ID in the `X-N8N-Workspace-ID` header, for multi-tenant deployments that route requests by workspace. Leave unset for a single-workspace instance.",
+				Optional:            true,
+			},
+			"disable_compression": schema.BoolAttribute{
+				MarkdownDescription: "Disables requesting gzip-compressed responses from the API, for proxies that mishandle compression. Defaults to false.",
+				Optional:            true,
+			},
+			"force_http1": schema.BoolAttribute{
+				MarkdownDescription: "Forces HTTP/1.1 for API requests by disabling HTTP/2, for proxies and gateways that mishandle HTTP/2. Defaults to false.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disables verification of the instance's TLS certificate, e.g. for a test instance with a self-signed certificate. Anyone on the network path can then intercept requests, including the API key, so prefer `ca_cert_file` for instances behind an internal CA. Defaults to false.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of an HTTP, HTTPS or SOCKS5 proxy to send API requests through, such as `http://proxy.internal:3128`, for instances only reachable through an outbound proxy. Credentials can be given in the URL. The `timeout` includes connecting through the proxy. Defaults to the proxy given by the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM bundle of CA certificates trusted in addition to the system pool, for instances behind an internal CA.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates trusted in addition to the system pool, given inline or base64-encoded, e.g. from a CI variable on runners without the bundle on disk. Can be combined with `ca_cert_file`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"method_override": schema.BoolAttribute{
+				MarkdownDescription: "Sends `PATCH` and `DELETE` requests as `POST` with the intended method in the `X-HTTP-Method-Override` header, for proxies that block those methods. The instance or a gateway in front of it must honor the header. Defaults to false.",
+				Optional:            true,
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Stops the provider from sending requests that would modify the instance, e.g. to check configurations against the API in CI. Reads still run, so plans and data sources work as usual, but any create, update or delete fails with an error naming the request that was skipped. The n8n API has no validation-only endpoints. Defaults to false.",
+				Optional:            true,
+			},
+			"enable_read_cache": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reuse API responses for identical reads within a few seconds, e.g. for configurations with many `n8ncloud_user` lookups. Any create, update or delete through the provider drops the cached responses of the same resource type, but changes made outside Terraform during the run may be missed. Defaults to false.",
+				Optional:            true,
+			},
+			"send_null_for_empty": schema.BoolAttribute{
+				MarkdownDescription: "Whether empty optional string fields in request bodies, such as the first and last name of an invited user, are sent as `null` instead of being left out, for instances that treat a missing field and `null` differently. Defaults to `false`, which leaves them out.",
+				Optional:            true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip checking the API key and instance URL when the provider is configured. The check sends one low-cost authenticated request, listing a single user, so that a wrong API key, instance URL or network setup fails fast with a specific error instead of on the first resource. Set to `true` for offline plans or instances that are not reachable yet. Defaults to `false`.",
+				Optional:            true,
+			},
+			"detect_version": schema.BoolAttribute{
+				MarkdownDescription: "Whether to look up the n8n version of the instance once when the provider is configured, so that behavior which depends on it, such as workflow archival, follows the instance. The version is read from the frontend settings endpoint; if it is unavailable, the provider behaves as without detection. Defaults to `false`.",
+				Optional:            true,
+			},
+			"global_deadline": schema.StringAttribute{
+				MarkdownDescription: "A hard cap on how long the provider may spend on API requests, as a duration string such as `30m`, counted from when the provider is configured at the start of each plan or apply. Requests still running when it is reached are aborted and later requests fail immediately. A warning is shown when it is shorter than the worst case of a single request with its retries. Defaults to no cap.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of API requests the provider sends at once across all resources, e.g. to avoid overwhelming the instance when destroying many users. Defaults to unlimited.",
+				Optional:            true,
+			},
+			"rate_limit": schema.Float64Attribute{
+				MarkdownDescription: "The maximum number of API requests per second the provider sends across all resources, e.g. `5` to stay below the instance's rate limit when managing many users. Fractions such as `0.5` are allowed. Defaults to unlimited.",
+				Optional:            true,
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: "The number of idle connections to the instance kept open for reuse, so that resources created in parallel do not each open a new connection. Defaults to 10, or `max_concurrent_requests` when it is higher.",
+				Optional:            true,
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of connections open to the instance at once, including those in use. Requests waiting for a connection count against `timeout`, so prefer `rate_limit` or `max_concurrent_requests` to limit the load on the instance. Defaults to unlimited.",
+				Optional:            true,
+			},
+			"retry_on_status": schema.ListAttribute{
+				MarkdownDescription: "Additional HTTP status codes to retry, beyond the default 429, 502, 503 and 504, e.g. `[409]` for deployments that return conflicts transiently. POST requests are only retried on 429 and 503, which the instance sends before creating anything.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How many times a request failing with a retryable status or a connection error is retried, with jittered exponential backoff from 1 to 30 seconds, or the delay of a `Retry-After` header. Set to 0 to disable retries. Defaults to 3.",
+				Optional:            true,
+			},
+			"request_headers": schema.MapAttribute{
+				MarkdownDescription: "Advanced: extra HTTP headers sent with every API request, keyed by header name, e.g. for a gateway in front of the instance that routes on them. Setting one of the headers the provider manages, such as `X-N8N-API-KEY`, `Accept`, `Content-Type` or `User-Agent`, is an error. Resources can add to or override them with their own `request_headers`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					requestHeadersValidator{},
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "The number of consecutive failed API requests (connection errors or 5xx responses) after which further requests fail fast for 30 seconds, to avoid hammering an instance that is down. Defaults to 0, which disables the circuit breaker.",
+				Optional:            true,
+			},
+			"invite_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long an invitation is assumed to stay valid, as a duration string such as `72h`, for the `invite_expired` attribute of users. The API does not report invitation expiry, so a pending user counts as expired once they were created longer ago than this. Defaults to `168h`, seven days.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"default_user_role": schema.StringAttribute{
+				MarkdownDescription: "The role of `n8ncloud_user` resources that do not set `role`, e.g. to invite every user as `global:member` without repeating it. Accepts the same values as `role`. The `role` of a resource always takes precedence. Changing it changes the role of the users relying on it. Defaults to the role n8n assigns new users.",
+				Optional:            true,
+				Validators: []validator.String{
+					roleValidator{},
+				},
+			},
+			"managed_marker_tag": schema.StringAttribute{
+				MarkdownDescription: "The name of a tag, such as `managed-by-terraform`, that `n8ncloud_workflow` resources attach to their workflows to tell them apart from workflows created in the editor. The tag is created when it does not exist. It is not reported in the `tag_ids` of the resources. Defaults to no marker.",
+				Optional:            true,
+			},
+			"change_report_file": schema.StringAttribute{
+				MarkdownDescription: "A path to write a JSON summary of the resources the provider creates, updates and deletes, for CI reporting. The file has `created`, `updated` and `deleted` lists of objects with the resource `type` and `id`, and is rewritten after each change. It is only written when something changes, so remove it before an apply to tell a no-op apply from a stale report. Defaults to no report.",
+				Optional:            true,
+			},
+			"expose_raw": schema.BoolAttribute{
+				MarkdownDescription: "Whether resources populate their `raw_json` attribute with the full API response. Sensitive fields are excluded. Defaults to false to keep state small.",
 				Optional:            true,
 			},
 		},
@@ -101,10 +362,52 @@ func (p *N8nCloudProvider) Configure(ctx context.Context, req provider.Configure
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 	apiKey := os.Getenv("N8N_API_KEY")
+	apiKeyFile := os.Getenv("N8N_API_KEY_FILE")
 	instanceURL := os.Getenv("N8N_INSTANCE_URL")
 	timeout := int64(30)
+	slowRequestThreshold := int64(5)
+
+	if v := os.Getenv("N8N_TIMEOUT"); v != "" && data.Timeout.IsNull() {
+		envTimeout, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || envTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid n8n Cloud Timeout",
+				fmt.Sprintf("The N8N_TIMEOUT environment variable value %q is not a positive whole number of seconds.", v),
+			)
+			return
+		}
+		timeout = envTimeout
+	}
+
+	if !data.APIKeyFile.IsNull() {
+		apiKeyFile = data.APIKeyFile.ValueString()
+	}
+
+	// The file is only read from the environment when the configuration
+	// gives no key, but a configured file must agree with a configured key.
+	if apiKeyFile != "" && (data.APIKey.IsNull() || !data.APIKeyFile.IsNull()) {
+		key, err := readAPIKeyFile(apiKeyFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Unable to Read n8n Cloud API Key File",
+				fmt.Sprintf("The provider cannot read the API key from %q: %s", apiKeyFile, err),
+			)
+			return
+		}
+		apiKey = key
+	}
 
 	if !data.APIKey.IsNull() {
+		if !data.APIKeyFile.IsNull() && strings.TrimSpace(data.APIKey.ValueString()) != apiKey {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Conflicting n8n Cloud API Key",
+				fmt.Sprintf("The api_key value differs from the API key in %q. Set only one of api_key and api_key_file.", apiKeyFile),
+			)
+			return
+		}
 		apiKey = data.APIKey.ValueString()
 	}
 
@@ -116,13 +419,23 @@ func (p *N8nCloudProvider) Configure(ctx context.Context, req provider.Configure
 		timeout = data.Timeout.ValueInt64()
 	}
 
+	if !data.SlowRequestThreshold.IsNull() {
+		slowRequestThreshold = data.SlowRequestThreshold.ValueInt64()
+	}
+
+	// Copy-pasted keys often carry a trailing newline, which the API rejects
+	if trimmed := strings.TrimSpace(apiKey); trimmed != apiKey {
+		tflog.Debug(ctx, "Trimmed surrounding whitespace from the n8n Cloud API key")
+		apiKey = trimmed
+	}
+
 	// Validate configuration
 	if apiKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing n8n Cloud API Key",
 			"The provider cannot create the n8n Cloud API client as there is a missing or empty value for the n8n Cloud API key. "+
-				"Set the api_key value in the configuration or use the N8N_API_KEY environment variable. "+
+				"Set the api_key or api_key_file value in the configuration or use the N8N_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -135,17 +448,234 @@ func (p *N8nCloudProvider) Configure(ctx context.Context, req provider.Configure
 				"Set the instance_url value in the configuration or use the N8N_INSTANCE_URL environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+	} else if normalized, err := normalizeInstanceURL(instanceURL); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_url"),
+			"Invalid n8n Cloud Instance URL",
+			fmt.Sprintf("The instance URL %q is not valid: %s. Set instance_url to the base URL of the instance, such as https://example.app.n8n.cloud.", instanceURL, err),
+		)
+	} else {
+		instanceURL = normalized
+	}
+
+	if isInsecureInstanceURL(instanceURL) && !data.AllowInsecureHTTP.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("instance_url"),
+			"Insecure n8n Cloud Instance URL",
+			fmt.Sprintf("The instance URL %q uses plain http, so the API key is sent over the network unencrypted. "+
+				"Use an https:// URL, or set allow_insecure_http to true to suppress this warning.", instanceURL),
+		)
+	}
+
+	if slowRequestThreshold < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("slow_request_threshold"),
+			"Invalid Slow Request Threshold",
+			"The slow_request_threshold value must be zero or a positive number of seconds.",
+		)
+	}
+
+	if data.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Maximum Concurrent Requests",
+			"The max_concurrent_requests value must be zero (unlimited) or a positive number.",
+		)
+	}
+
+	if data.MaxIdleConnsPerHost.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns_per_host"),
+			"Invalid Maximum Idle Connections",
+			"The max_idle_conns_per_host value must be a positive number.",
+		)
+	}
+
+	if data.MaxConnsPerHost.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_conns_per_host"),
+			"Invalid Maximum Connections",
+			"The max_conns_per_host value must be a positive number.",
+		)
+	}
+
+	// The role validator skips values unknown at validation time, such as
+	// references to other resources
+	if !data.DefaultUserRole.IsNull() && !data.DefaultUserRole.IsUnknown() && !isKnownRole(data.DefaultUserRole.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_user_role"),
+			"Invalid Default User Role",
+			fmt.Sprintf("The default_user_role %q is not supported. Valid roles are: %s, or the aliases %s.", data.DefaultUserRole.ValueString(), strings.Join(userRoles, ", "), strings.Join(roleAliasNames(), ", ")),
+		)
+	}
+
+	if !data.PageSize.IsNull() && (data.PageSize.ValueInt64() < 1 || data.PageSize.ValueInt64() > 250) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_size"),
+			"Invalid Page Size",
+			"The page_size value must be between 1 and 250.",
+		)
+	}
+
+	var retryOnStatus []int64
+	resp.Diagnostics.Append(data.RetryOnStatus.ElementsAs(ctx, &retryOnStatus, false)...)
+	for _, code := range retryOnStatus {
+		if code < 100 || code > 599 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_on_status"),
+				"Invalid Retry Status Code",
+				fmt.Sprintf("The retry_on_status value %d is not a valid HTTP status code. Status codes must be between 100 and 599.", code),
+			)
+		}
+	}
+
+	var requestHeaders map[string]string
+	resp.Diagnostics.Append(data.RequestHeaders.ElementsAs(ctx, &requestHeaders, false)...)
+
+	if data.ReloadKeyOnAuthError.ValueBool() && (apiKeyFile == "" || !data.APIKey.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reload_key_on_auth_error"),
+			"Invalid API Key Reload Configuration",
+			"The reload_key_on_auth_error value can only be true when the API key is read from api_key_file or the N8N_API_KEY_FILE environment variable and api_key is not set.",
+		)
+	}
+
+	if !data.RateLimit.IsNull() && data.RateLimit.ValueFloat64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rate_limit"),
+			"Invalid Rate Limit",
+			"The rate_limit value must be a positive number of requests per second.",
+		)
+	}
+
+	if data.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Max Retries",
+			"The max_retries value must be zero or a positive number.",
+		)
+	}
+
+	if data.CircuitBreakerThreshold.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Invalid Circuit Breaker Threshold",
+			"The circuit_breaker_threshold value must be zero or a positive number.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DryRun.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("dry_run"),
+			"Dry Run Enabled",
+			"Requests that would modify the n8n instance are not sent. Resources that need to be created, updated or deleted will fail with an error naming the skipped request.",
+		)
+	}
+
+	if data.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The instance's TLS certificate is not verified, so requests and the API key they carry can be intercepted. "+
+				"Use ca_cert_file or ca_cert_pem to trust an internal CA instead.",
+		)
+	}
+
+	rootCAs, diags := caCertPool(data.CACertFile, data.CACertPEM)
+	resp.Diagnostics.Append(diags...)
+
+	var proxyURL *url.URL
+	if !data.ProxyURL.IsNull() {
+		var err error
+		proxyURL, err = parseProxyURL(data.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy_url value must be a URL such as http://proxy.internal:3128: %s", err),
+			)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// A zero threshold disables slow request warnings in the client.
+	slowRequestDuration := time.Duration(slowRequestThreshold) * time.Second
+	if slowRequestThreshold == 0 {
+		slowRequestDuration = -1
+	}
+
+	// A zero max_retries disables retries in the client.
+	maxRetries := int(data.MaxRetries.ValueInt64())
+	if !data.MaxRetries.IsNull() && maxRetries == 0 {
+		maxRetries = -1
+	}
+
 	// Create the API client
 	clientConfig := &client.Config{
-		BaseURL: instanceURL,
-		APIKey:  apiKey,
-		Timeout: time.Duration(timeout) * time.Second,
+		BaseURL:                 instanceURL,
+		BasePath:                data.APIBasePath.ValueString(),
+		APIKey:                  apiKey,
+		Version:                 p.version,
+		Timeout:                 time.Duration(timeout) * time.Second,
+		SlowRequestThreshold:    slowRequestDuration,
+		Accept:                  data.AcceptHeader.ValueString(),
+		WorkspaceID:             data.WorkspaceID.ValueString(),
+		PageSize:                int(data.PageSize.ValueInt64()),
+		DisableCompression:      data.DisableCompression.ValueBool(),
+		ForceHTTP1:              data.ForceHTTP1.ValueBool(),
+		RootCAs:                 rootCAs,
+		InsecureSkipVerify:      data.InsecureSkipVerify.ValueBool(),
+		ProxyURL:                proxyURL,
+		MethodOverride:          data.MethodOverride.ValueBool(),
+		DryRun:                  data.DryRun.ValueBool(),
+		SendNullForEmpty:        data.SendNullForEmpty.ValueBool(),
+		MaxConcurrentRequests:   int(data.MaxConcurrentRequests.ValueInt64()),
+		RateLimit:               data.RateLimit.ValueFloat64(),
+		MaxIdleConnsPerHost:     int(data.MaxIdleConnsPerHost.ValueInt64()),
+		MaxConnsPerHost:         int(data.MaxConnsPerHost.ValueInt64()),
+		MaxRetries:              maxRetries,
+		CircuitBreakerThreshold: int(data.CircuitBreakerThreshold.ValueInt64()),
+		Headers:                 requestHeaders,
+	}
+	if data.EnableReadCache.ValueBool() {
+		clientConfig.ReadCacheTTL = readCacheTTL
+	}
+	if data.ReloadKeyOnAuthError.ValueBool() {
+		clientConfig.ReloadAPIKey = func() (string, error) {
+			return readAPIKeyFile(apiKeyFile)
+		}
+	}
+	if !data.GlobalDeadline.IsNull() {
+		// Values are validated by durationValidator; an unparsable value
+		// leaves requests uncapped.
+		if globalDeadline, err := time.ParseDuration(data.GlobalDeadline.ValueString()); err == nil {
+			clientConfig.Deadline = time.Now().Add(globalDeadline)
+
+			// A deadline shorter than the retry schedule cuts retries short
+			if worstCase := client.WorstCaseRequestDuration(clientConfig); globalDeadline < worstCase {
+				retries := data.MaxRetries.ValueInt64()
+				if data.MaxRetries.IsNull() {
+					retries = 3
+				}
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("global_deadline"),
+					"Global Deadline Shorter Than Retry Schedule",
+					fmt.Sprintf("A request answered with a retryable status %d times before its last attempt times out can take up to %s with a timeout of %ds, which exceeds the global_deadline of %s. "+
+						"Retries may never complete before the deadline. Consider raising global_deadline to at least %s or lowering timeout or max_retries.",
+						retries, worstCase, timeout, globalDeadline, worstCase),
+				)
+			}
+		}
+	}
+	for _, code := range retryOnStatus {
+		clientConfig.RetryOnStatus = append(clientConfig.RetryOnStatus, int(code))
 	}
 
 	apiClient, err := client.NewClient(clientConfig)
@@ -157,33 +687,109 @@ func (p *N8nCloudProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if !data.SkipCredentialsCheck.ValueBool() {
+		if err := apiClient.VerifyConnection(ctx); err != nil {
+			addConnectionError(&resp.Diagnostics, instanceURL, err)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	if data.DetectVersion.ValueBool() {
+		version, err := apiClient.DetectVersion(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to detect the n8n instance version, using the default behavior", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else {
+			tflog.Info(ctx, "Detected n8n instance version", map[string]interface{}{
+				"version": version.String(),
+			})
+		}
+	}
+
+	// Values are validated by durationValidator; an unparsable value falls
+	// back to the default.
+	inviteTTL := defaultInviteTTL
+	if !data.InviteTTL.IsNull() {
+		if ttl, err := time.ParseDuration(data.InviteTTL.ValueString()); err == nil {
+			inviteTTL = ttl
+		}
+	}
+
+	providerData := &N8nCloudProviderData{
+		Client:       apiClient,
+		ExposeRaw:    data.ExposeRaw.ValueBool(),
+		ChangeReport: newChangeReport(data.ChangeReportFile.ValueString()),
+		InviteTTL:    inviteTTL,
+
+		DefaultUserRole: canonicalRole(data.DefaultUserRole.ValueString()),
+
+		InstanceURL:      instanceURL,
+		ManagedMarkerTag: data.ManagedMarkerTag.ValueString(),
+	}
+
 	// Make the n8n Cloud client available during DataSource and Resource
 	// type Configure methods.
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 func (p *N8nCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewUserResource,
+		NewProjectUserResource,
+		NewWorkflowResource,
+		NewWorkflowActivationResource,
+		NewWorkflowTagsResource,
+		NewWorkflowTransferResource,
+		NewCredentialTransferResource,
+		NewSourceControlPullResource,
+		NewTagResource,
+		NewVariablesResource,
 	}
 }
 
 func (p *N8nCloudProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
-		// No ephemeral resources for n8n cloud provider currently
+		NewCredentialEphemeralResource,
 	}
 }
 
 func (p *N8nCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserDataSource,
+		NewUsersDataSource,
+		NewUserStatsDataSource,
+		NewWorkflowsDataSource,
+		NewWorkflowsByTagDataSource,
+		NewVariablesDataSource,
+		NewProjectsDataSource,
+		NewRateLimitDataSource,
+		NewTagDataSource,
+		NewTagIDsDataSource,
+		NewWorkflowExportDataSource,
+		NewWorkflowTagDiffDataSource,
+		NewLatestExecutionDataSource,
+		NewExecutionsDataSource,
+		NewCredentialSchemaDataSource,
 	}
 }
 
 func (p *N8nCloudProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// No functions for n8n cloud provider currently
+		NewMergeSettingsFunction,
+		NewValidateWorkflowFunction,
+		NewSchemaRequiredFieldsFunction,
+		NewListAddedFunction,
+		NewListRemovedFunction,
+		NewRoleLabelFunction,
+		NewRoleAPIFunction,
+		NewNormalizeRoleFunction,
+		NewInviteTokenFunction,
+		NewEscapeExpressionFunction,
 	}
 }
 
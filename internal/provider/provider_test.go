@@ -4,11 +4,24 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -29,3 +42,598 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("N8N_INSTANCE_URL must be set for acceptance tests")
 	}
 }
+
+// newTestClient returns an API client pointed at a test server serving
+// handler, for unit testing provider logic without a real n8n instance.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(&client.Config{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return c
+}
+
+// configureTestProvider runs the provider's Configure with the given
+// provider configuration values. Attributes not in values are null.
+func configureTestProvider(t *testing.T, values map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected provider schema type %T", schemaResp.Schema.Type().TerraformType(ctx))
+	}
+
+	// Tests run offline, so skip the credentials check unless a test opts in
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	attributes["skip_credentials_validation"] = tftypes.NewValue(tftypes.Bool, true)
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+	}
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, req, resp)
+
+	return resp
+}
+
+func TestProviderConfigure_trimsAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-N8N-API-KEY") != "test-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com"}`))
+	}))
+	defer server.Close()
+
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "  test-api-key\n"),
+		"instance_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	providerData, ok := resp.ResourceData.(*N8nCloudProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data type %T", resp.ResourceData)
+	}
+
+	if _, err := providerData.Client.GetUser(context.Background(), "1"); err != nil {
+		t.Errorf("expected the trimmed API key to authenticate, got: %s", err)
+	}
+}
+
+func TestProviderConfigure_reloadKeyOnAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-N8N-API-KEY") != "rotated-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com"}`))
+	}))
+	defer server.Close()
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("test-api-key\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing key file: %s", err)
+	}
+
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key_file":             tftypes.NewValue(tftypes.String, keyFile),
+		"reload_key_on_auth_error": tftypes.NewValue(tftypes.Bool, true),
+		"instance_url":             tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	providerData, ok := resp.ResourceData.(*N8nCloudProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data type %T", resp.ResourceData)
+	}
+
+	// Rotate the key after the provider read it.
+	if err := os.WriteFile(keyFile, []byte("rotated-api-key\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing key file: %s", err)
+	}
+
+	if _, err := providerData.Client.GetUser(context.Background(), "1"); err != nil {
+		t.Errorf("expected the request to be retried with the rotated API key, got: %s", err)
+	}
+}
+
+func TestProviderConfigure_enableReadCache(t *testing.T) {
+	for name, enabled := range map[string]bool{"enabled": true, "disabled": false} {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com"}`))
+			}))
+			defer server.Close()
+
+			resp := configureTestProvider(t, map[string]tftypes.Value{
+				"api_key":           tftypes.NewValue(tftypes.String, "test-api-key"),
+				"instance_url":      tftypes.NewValue(tftypes.String, server.URL),
+				"enable_read_cache": tftypes.NewValue(tftypes.Bool, enabled),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			providerData, ok := resp.ResourceData.(*N8nCloudProviderData)
+			if !ok {
+				t.Fatalf("unexpected resource data type %T", resp.ResourceData)
+			}
+
+			for range 3 {
+				if _, err := providerData.Client.GetUser(context.Background(), "1"); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			want := 3
+			if enabled {
+				want = 1
+			}
+			if requests != want {
+				t.Errorf("expected %d requests for 3 identical reads, got %d", want, requests)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_apiKeyFileFromEnv(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-N8N-API-KEY") != "file-api-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com"}`))
+	}))
+	defer server.Close()
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("file-api-key\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing key file: %s", err)
+	}
+
+	// The file takes precedence over the key in the environment.
+	t.Setenv("N8N_API_KEY", "env-api-key")
+	t.Setenv("N8N_API_KEY_FILE", keyFile)
+
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"instance_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	providerData, ok := resp.ResourceData.(*N8nCloudProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data type %T", resp.ResourceData)
+	}
+
+	if _, err := providerData.Client.GetUser(context.Background(), "1"); err != nil {
+		t.Errorf("expected the API key from N8N_API_KEY_FILE to authenticate, got: %s", err)
+	}
+}
+
+func TestProviderConfigure_conflictingAPIKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(keyFile, []byte("file-api-key\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing key file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		apiKey    string
+		wantError bool
+	}{
+		"same key":      {apiKey: "file-api-key"},
+		"different key": {apiKey: "other-api-key", wantError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := configureTestProvider(t, map[string]tftypes.Value{
+				"api_key":      tftypes.NewValue(tftypes.String, tc.apiKey),
+				"api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+				"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+			})
+
+			if !tc.wantError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Conflicting n8n Cloud API Key" {
+				t.Fatalf("expected a Conflicting n8n Cloud API Key error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_invalidTimeoutEnv(t *testing.T) {
+	t.Setenv("N8N_TIMEOUT", "30s")
+
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid n8n Cloud Timeout" {
+		t.Fatalf("expected an Invalid n8n Cloud Timeout error, got: %v", resp.Diagnostics)
+	}
+
+	// The configuration takes precedence over the environment.
+	resp = configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"timeout":      tftypes.NewValue(tftypes.Number, 10),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_reloadKeyOnAuthErrorWithoutFile(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":                  tftypes.NewValue(tftypes.String, "test-api-key"),
+		"reload_key_on_auth_error": tftypes.NewValue(tftypes.Bool, true),
+		"instance_url":             tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected reload_key_on_auth_error without api_key_file to be rejected")
+	}
+}
+
+func TestProviderConfigure_globalDeadline(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":         tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":    tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"global_deadline": tftypes.NewValue(tftypes.String, "1ns"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	providerData, ok := resp.ResourceData.(*N8nCloudProviderData)
+	if !ok {
+		t.Fatalf("unexpected resource data type %T", resp.ResourceData)
+	}
+
+	if _, err := providerData.Client.GetUser(context.Background(), "1"); !errors.Is(err, client.ErrDeadlineExceeded) {
+		t.Errorf("expected requests after the global deadline to fail fast, got: %v", err)
+	}
+}
+
+func TestProviderConfigure_whitespaceOnlyAPIKey(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, " \n"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected a whitespace-only API key to be reported as missing")
+	}
+}
+
+func TestClientWithAPIKeyOverride(t *testing.T) {
+	var got string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("X-N8N-API-KEY")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+
+	testCases := map[string]struct {
+		apiKey types.String
+		want   string
+	}{
+		"null":     {apiKey: types.StringNull(), want: "test-api-key"},
+		"unknown":  {apiKey: types.StringUnknown(), want: "test-api-key"},
+		"empty":    {apiKey: types.StringValue(" "), want: "test-api-key"},
+		"override": {apiKey: types.StringValue("scoped-api-key\n"), want: "scoped-api-key"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := clientWithAPIKeyOverride(c, testCase.apiKey).GetUser(context.Background(), "1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.want {
+				t.Errorf("expected API key %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+// resourceTestValue returns the schema of r and an object value for it with
+// the given attribute values. Attributes and blocks not in values are null.
+func resourceTestValue(t *testing.T, r resource.Resource, values map[string]tftypes.Value) (resourceschema.Schema, tftypes.Value) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("unexpected resource schema type %T", schemaResp.Schema.Type().TerraformType(ctx))
+	}
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+			continue
+		}
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	return schemaResp.Schema, tftypes.NewValue(objectType, attributes)
+}
+
+func TestProviderConfigure_invalidRetryOnStatus(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"retry_on_status": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+			tftypes.NewValue(tftypes.Number, 409),
+			tftypes.NewValue(tftypes.Number, 999),
+		}),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected exactly one error for the invalid status code, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_invalidInstanceURL(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "example.app.n8n.cloud"),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid n8n Cloud Instance URL" {
+		t.Fatalf("expected an Invalid n8n Cloud Instance URL error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_credentialsValidation(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":                     tftypes.NewValue(tftypes.String, "wrong-api-key"),
+		"instance_url":                tftypes.NewValue(tftypes.String, server.URL),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, nil),
+	})
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "n8n Cloud Authentication Failed" {
+		t.Fatalf("expected an n8n Cloud Authentication Failed error, got: %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "check api_key and instance_url") {
+		t.Errorf("expected the detail to name the attributes to check, got: %s", detail)
+	}
+
+	requests = 0
+	resp = configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":                     tftypes.NewValue(tftypes.String, "wrong-api-key"),
+		"instance_url":                tftypes.NewValue(tftypes.String, server.URL),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests when skipping the check, got %d", requests)
+	}
+}
+
+func TestProviderConfigure_globalDeadlineShorterThanRetries(t *testing.T) {
+	testCases := map[string]struct {
+		timeout      int64
+		maxRetries   interface{}
+		deadline     string
+		expectWarned bool
+	}{
+		"deadline below one timeout": {timeout: 30, deadline: "10s", expectWarned: true},
+		"deadline below retries":     {timeout: 5, deadline: "10s", expectWarned: true},
+		"deadline covers retries":    {timeout: 5, deadline: "12s", expectWarned: false},
+		"fewer retries":              {timeout: 5, maxRetries: 1, deadline: "6s", expectWarned: false},
+		"retries disabled":           {timeout: 5, maxRetries: 0, deadline: "5s", expectWarned: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := configureTestProvider(t, map[string]tftypes.Value{
+				"api_key":         tftypes.NewValue(tftypes.String, "test-api-key"),
+				"instance_url":    tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+				"timeout":         tftypes.NewValue(tftypes.Number, tc.timeout),
+				"max_retries":     tftypes.NewValue(tftypes.Number, tc.maxRetries),
+				"global_deadline": tftypes.NewValue(tftypes.String, tc.deadline),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			warned := false
+			for _, warning := range resp.Diagnostics.Warnings() {
+				if warning.Summary() == "Global Deadline Shorter Than Retry Schedule" {
+					warned = true
+				}
+			}
+
+			if warned != tc.expectWarned {
+				t.Errorf("expected warning %t, got diagnostics: %v", tc.expectWarned, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_negativeMaxRetries(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"max_retries":  tftypes.NewValue(tftypes.Number, -1),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected exactly one error for the negative max_retries, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_invalidRateLimit(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":      tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url": tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"rate_limit":   tftypes.NewValue(tftypes.Number, 0),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected exactly one error for the zero rate_limit, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_invalidConnectionsPerHost(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":                 tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":            tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"max_idle_conns_per_host": tftypes.NewValue(tftypes.Number, -1),
+		"max_conns_per_host":      tftypes.NewValue(tftypes.Number, -1),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 2 {
+		t.Fatalf("expected an error for each negative connection limit, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_defaultUserRole(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":           tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":      tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"default_user_role": tftypes.NewValue(tftypes.String, "Member"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if role := resp.ResourceData.(*N8nCloudProviderData).DefaultUserRole; role != "global:member" {
+		t.Errorf("expected the canonical default role global:member, got %q", role)
+	}
+
+	resp = configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":           tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":      tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"default_user_role": tftypes.NewValue(tftypes.String, "global:owner"),
+	})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Default User Role" {
+		t.Fatalf("expected an Invalid Default User Role error, got: %v", resp.Diagnostics)
+	}
+
+	// A role that is not known yet is not checked
+	resp = configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":           tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":      tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"default_user_role": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error for an unknown default role: %v", resp.Diagnostics)
+	}
+}
+
+func TestProviderConfigure_insecureSkipVerify(t *testing.T) {
+	resp := configureTestProvider(t, map[string]tftypes.Value{
+		"api_key":              tftypes.NewValue(tftypes.String, "test-api-key"),
+		"instance_url":         tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud"),
+		"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	warned := false
+	for _, warning := range resp.Diagnostics.Warnings() {
+		if warning.Summary() == "TLS Certificate Verification Disabled" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("expected a warning about disabled certificate verification, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestClientWithRequestHeaders(t *testing.T) {
+	var got http.Header
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	})
+
+	testCases := map[string]struct {
+		headers map[string]string
+		want    string
+	}{
+		"null":     {headers: nil, want: ""},
+		"override": {headers: map[string]string{"X-Route": "users"}, want: "users"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := clientWithRequestHeaders(c, testCase.headers).GetUser(context.Background(), "1"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.Get("X-Route") != testCase.want {
+				t.Errorf("expected X-Route header %q, got %q", testCase.want, got.Get("X-Route"))
+			}
+		})
+	}
+}
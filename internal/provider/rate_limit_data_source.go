@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RateLimitDataSource{}
+
+func NewRateLimitDataSource() datasource.DataSource {
+	return &RateLimitDataSource{}
+}
+
+// RateLimitDataSource defines the data source implementation.
+type RateLimitDataSource struct {
+	client *client.Client
+}
+
+// RateLimitDataSourceModel describes the data source data model.
+type RateLimitDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Limit     types.Int64  `tfsdk:"limit"`
+	Remaining types.Int64  `tfsdk:"remaining"`
+	Reset     types.Int64  `tfsdk:"reset"`
+}
+
+func (d *RateLimitDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rate_limit"
+}
+
+func (d *RateLimitDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Rate limit data source for capacity monitoring. Performs a minimal API request and reports the `X-RateLimit-*` response headers. Values are null when the instance does not send these headers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the data source",
+				Computed:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests allowed in the current window, from `X-RateLimit-Limit`",
+				Computed:            true,
+			},
+			"remaining": schema.Int64Attribute{
+				MarkdownDescription: "The number of requests left in the current window, from `X-RateLimit-Remaining`",
+				Computed:            true,
+			},
+			"reset": schema.Int64Attribute{
+				MarkdownDescription: "When the current window resets, from `X-RateLimit-Reset`, as reported by the instance",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RateLimitDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *RateLimitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RateLimitDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rateLimit, err := d.client.GetRateLimit(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read rate limit", err)
+		return
+	}
+
+	data.ID = types.StringValue("rate_limit")
+	data.Limit = types.Int64PointerValue(rateLimit.Limit)
+	data.Remaining = types.Int64PointerValue(rateLimit.Remaining)
+	data.Reset = types.Int64PointerValue(rateLimit.Reset)
+
+	if rateLimit.Limit == nil && rateLimit.Remaining == nil && rateLimit.Reset == nil {
+		resp.Diagnostics.AddWarning(
+			"Rate Limit Headers Not Available",
+			"The n8n instance did not send X-RateLimit-* headers, so all rate limit values are null.",
+		)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
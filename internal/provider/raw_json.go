@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// userSensitiveFields lists the user response fields that must never be
+// copied into raw_json.
+var userSensitiveFields = []string{"inviteAcceptUrl"}
+
+// rawJSONValue converts a raw API response into the value of a raw_json
+// attribute. Top-level sensitive fields are removed before the object is
+// re-encoded. A null value is returned when enabled is false or there is no
+// response body.
+func rawJSONValue(enabled bool, raw []byte, sensitiveFields []string) (types.String, error) {
+	if !enabled || len(raw) == 0 {
+		return types.StringNull(), nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return types.StringNull(), fmt.Errorf("failed to decode raw response: %w", err)
+	}
+
+	for _, field := range sensitiveFields {
+		delete(fields, field)
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("failed to encode raw response: %w", err)
+	}
+
+	return types.StringValue(string(encoded)), nil
+}
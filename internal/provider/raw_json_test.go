@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestRawJSONValue(t *testing.T) {
+	raw := []byte(`{"id":"1","email":"user@example.com","inviteAcceptUrl":"https://example.com/signup?inviterId=a&inviteeId=b"}`)
+
+	disabled, err := rawJSONValue(false, raw, userSensitiveFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !disabled.IsNull() {
+		t.Errorf("expected null value when disabled, got %s", disabled)
+	}
+
+	enabled, err := rawJSONValue(true, raw, userSensitiveFields)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := enabled.ValueString(), `{"email":"user@example.com","id":"1"}`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if _, err := rawJSONValue(true, []byte(`[]`), userSensitiveFields); err == nil {
+		t.Error("expected error for non-object response")
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+var _ validator.Map = requestHeadersValidator{}
+
+// requestHeadersValidator validates that a request_headers map does not
+// name a header the provider manages, such as X-N8N-API-KEY. The client
+// would ignore such a header, so it is rejected rather than silently dropped.
+type requestHeadersValidator struct{}
+
+func (v requestHeadersValidator) Description(ctx context.Context) string {
+	return "header names must not be one of the headers the provider manages"
+}
+
+func (v requestHeadersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requestHeadersValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	names := make([]string, 0, len(req.ConfigValue.Elements()))
+	for name := range req.ConfigValue.Elements() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if client.IsManagedHeader(name) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Reserved Request Header",
+				fmt.Sprintf("The %s header is managed by the provider and cannot be set in request_headers. Use api_key to set the API key.", name),
+			)
+		}
+	}
+}
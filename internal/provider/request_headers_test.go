@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRequestHeadersValidator(t *testing.T) {
+	testCases := map[string]struct {
+		headers    map[string]string
+		wantErrors int
+	}{
+		"custom headers": {
+			headers: map[string]string{"CF-Access-Client-Id": "id", "X-Route": "users"},
+		},
+		"api key": {
+			headers:    map[string]string{"X-N8N-API-KEY": "other-key"},
+			wantErrors: 1,
+		},
+		"case-insensitive": {
+			headers:    map[string]string{"content-type": "text/plain", "user-agent": "curl"},
+			wantErrors: 2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			elements := make(map[string]attr.Value, len(tc.headers))
+			for header, value := range tc.headers {
+				elements[header] = types.StringValue(value)
+			}
+
+			req := validator.MapRequest{
+				Path:        path.Root("request_headers"),
+				ConfigValue: types.MapValueMust(types.StringType, elements),
+			}
+			resp := &validator.MapResponse{}
+
+			requestHeadersValidator{}.ValidateMap(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tc.wantErrors {
+				t.Errorf("expected %d errors, got: %v", tc.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RoleAPIFunction{}
+
+func NewRoleAPIFunction() function.Function {
+	return &RoleAPIFunction{}
+}
+
+// RoleAPIFunction defines the function implementation.
+type RoleAPIFunction struct{}
+
+func (f *RoleAPIFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_api"
+}
+
+func (f *RoleAPIFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a friendly role label to its n8n role",
+		MarkdownDescription: "Returns the n8n role for a friendly label, such as `global:admin` for `Admin` or `project:editor` for `Project Editor`, e.g. for constructing the `role` of a user from a label. The label is matched case-insensitively. Fails for labels the provider does not know.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "label",
+				MarkdownDescription: "The friendly label of the role",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RoleAPIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var label string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &label))
+
+	if resp.Error != nil {
+		return
+	}
+
+	result, ok := roleForLabel(label)
+	if !ok {
+		known := make([]string, 0, len(roleLabels))
+		for _, entry := range roleLabels {
+			known = append(known, entry.label)
+		}
+
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown role label %q. Known labels: %s", label, strings.Join(known, ", ")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
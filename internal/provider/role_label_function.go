@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RoleLabelFunction{}
+
+func NewRoleLabelFunction() function.Function {
+	return &RoleLabelFunction{}
+}
+
+// RoleLabelFunction defines the function implementation.
+type RoleLabelFunction struct{}
+
+func (f *RoleLabelFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "role_label"
+}
+
+func (f *RoleLabelFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an n8n role to its friendly label",
+		MarkdownDescription: "Returns the friendly label of an n8n role, such as `Admin` for `global:admin` or `Project Editor` for `project:editor`, e.g. for displaying roles in outputs. The role is matched case-insensitively. Fails for roles the provider does not know.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "role",
+				MarkdownDescription: "The role as returned by the API",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RoleLabelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var role string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &role))
+
+	if resp.Error != nil {
+		return
+	}
+
+	result, ok := roleLabel(role)
+	if !ok {
+		known := make([]string, 0, len(roleLabels))
+		for _, entry := range roleLabels {
+			known = append(known, entry.role)
+		}
+
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown role %q. Known roles: %s", role, strings.Join(known, ", ")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runRoleFunction runs f with argument and returns its result, or the
+// function error if it failed.
+func runRoleFunction(t *testing.T, f function.Function, argument string) (string, *function.FuncError) {
+	t.Helper()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(argument)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.StringUnknown()),
+	}
+
+	f.Run(context.Background(), req, resp)
+
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+
+	got, ok := resp.Result.Value().(types.String)
+	if !ok {
+		t.Fatalf("unexpected result type %T", resp.Result.Value())
+	}
+
+	return got.ValueString(), nil
+}
+
+func TestRoleFunctions_Run(t *testing.T) {
+	testCases := map[string]struct {
+		role  string
+		label string
+	}{
+		"owner":          {role: "global:owner", label: "Owner"},
+		"admin":          {role: "global:admin", label: "Admin"},
+		"member":         {role: "global:member", label: "Member"},
+		"project owner":  {role: "project:personalOwner", label: "Project Owner"},
+		"project admin":  {role: "project:admin", label: "Project Admin"},
+		"project editor": {role: "project:editor", label: "Project Editor"},
+		"project viewer": {role: "project:viewer", label: "Project Viewer"},
+	}
+
+	if len(testCases) != len(roleLabels) {
+		t.Fatalf("expected a test case for each of the %d known roles, got %d", len(roleLabels), len(testCases))
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			label, err := runRoleFunction(t, NewRoleLabelFunction(), testCase.role)
+			if err != nil {
+				t.Fatalf("unexpected error from role_label: %s", err)
+			}
+			if label != testCase.label {
+				t.Errorf("expected role_label(%q) to be %q, got %q", testCase.role, testCase.label, label)
+			}
+
+			role, err := runRoleFunction(t, NewRoleAPIFunction(), testCase.label)
+			if err != nil {
+				t.Fatalf("unexpected error from role_api: %s", err)
+			}
+			if role != testCase.role {
+				t.Errorf("expected role_api(%q) to be %q, got %q", testCase.label, testCase.role, role)
+			}
+		})
+	}
+}
+
+func TestRoleFunctions_caseInsensitive(t *testing.T) {
+	if label, err := runRoleFunction(t, NewRoleLabelFunction(), "GLOBAL:ADMIN"); err != nil || label != "Admin" {
+		t.Errorf("expected role_label to match roles case-insensitively, got %q, %v", label, err)
+	}
+	if role, err := runRoleFunction(t, NewRoleAPIFunction(), "project editor"); err != nil || role != "project:editor" {
+		t.Errorf("expected role_api to match labels case-insensitively, got %q, %v", role, err)
+	}
+}
+
+func TestRoleFunctions_unknown(t *testing.T) {
+	if _, err := runRoleFunction(t, NewRoleLabelFunction(), "global:superuser"); err == nil {
+		t.Error("expected role_label to fail for an unknown role")
+	}
+	if _, err := runRoleFunction(t, NewRoleAPIFunction(), "Superuser"); err == nil {
+		t.Error("expected role_api to fail for an unknown label")
+	}
+}
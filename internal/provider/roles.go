@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// userRoles lists the roles that can be assigned to users through the API,
+// in the form the API expects.
+var userRoles = client.UserRoles
+
+// roleAliases maps the short role names accepted in configuration to the
+// role the API expects.
+var roleAliases = map[string]string{
+	"admin":  "global:admin",
+	"member": "global:member",
+	"user":   "global:member",
+}
+
+// deprecatedRoles maps role names that n8n still accepts but has deprecated
+// to their replacement. Add entries here as n8n evolves its role names, and
+// keep the deprecated role in client.UserRoles while n8n accepts it.
+var deprecatedRoles = map[string]string{}
+
+// roleReplacement returns the replacement for role and true if role is
+// deprecated.
+func roleReplacement(role string) (string, bool) {
+	replacement, ok := deprecatedRoles[canonicalRole(role)]
+	return replacement, ok
+}
+
+// canonicalRole returns the API form of role, matching known and deprecated
+// roles and aliases case-insensitively. Unknown roles are returned
+// unchanged.
+func canonicalRole(role string) string {
+	if aliased, ok := roleAliases[strings.ToLower(role)]; ok {
+		return aliased
+	}
+
+	for _, known := range userRoles {
+		if strings.EqualFold(role, known) {
+			return known
+		}
+	}
+
+	for deprecated := range deprecatedRoles {
+		if strings.EqualFold(role, deprecated) {
+			return deprecated
+		}
+	}
+
+	return role
+}
+
+// isKnownRole reports whether role, in any casing, is a role the API accepts.
+func isKnownRole(role string) bool {
+	role = canonicalRole(role)
+
+	for _, known := range userRoles {
+		if role == known {
+			return true
+		}
+	}
+
+	_, ok := deprecatedRoles[role]
+	return ok
+}
+
+// adminRoles lists the roles with administrative access to the instance.
+var adminRoles = []string{"global:owner", "global:admin"}
+
+// isAdminRole reports whether role, in any casing, grants administrative
+// access.
+func isAdminRole(role string) bool {
+	role = canonicalRole(role)
+
+	for _, admin := range adminRoles {
+		if strings.EqualFold(role, admin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roleLabels maps every role n8n assigns, globally and within projects, to
+// a friendly label, in the order they are documented.
+var roleLabels = []struct {
+	role  string
+	label string
+}{
+	{role: "global:owner", label: "Owner"},
+	{role: "global:admin", label: "Admin"},
+	{role: "global:member", label: "Member"},
+	{role: "project:personalOwner", label: "Project Owner"},
+	{role: "project:admin", label: "Project Admin"},
+	{role: "project:editor", label: "Project Editor"},
+	{role: "project:viewer", label: "Project Viewer"},
+}
+
+// roleLabel returns the friendly label of role, matched
+// case-insensitively, and false if role is not known.
+func roleLabel(role string) (string, bool) {
+	for _, entry := range roleLabels {
+		if strings.EqualFold(role, entry.role) {
+			return entry.label, true
+		}
+	}
+
+	return "", false
+}
+
+// roleForLabel returns the role with the friendly label, matched
+// case-insensitively, and false if label is not known.
+func roleForLabel(label string) (string, bool) {
+	for _, entry := range roleLabels {
+		if strings.EqualFold(label, entry.label) {
+			return entry.role, true
+		}
+	}
+
+	return "", false
+}
+
+// roleStateValue returns the value to store for a role read back from the
+// API. The configured spelling is kept when it refers to the same role, so
+// writing e.g. GLOBAL:ADMIN does not produce a diff against global:admin. A
+// role unknown until apply that the API does not return is stored as null.
+func roleStateValue(current types.String, apiRole string) types.String {
+	if apiRole == "" {
+		if current.IsUnknown() {
+			return types.StringNull()
+		}
+		return current
+	}
+
+	if !current.IsNull() && !current.IsUnknown() && canonicalRole(current.ValueString()) == apiRole {
+		return current
+	}
+
+	return types.StringValue(apiRole)
+}
+
+// roleAliasNames returns the accepted role aliases in alphabetical order.
+func roleAliasNames() []string {
+	names := make([]string, 0, len(roleAliases))
+	for alias := range roleAliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+var _ validator.String = roleValidator{}
+
+// roleValidator validates that a string is a known user role, ignoring case.
+type roleValidator struct{}
+
+func (v roleValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of %s or an alias (%s), ignoring case", strings.Join(userRoles, ", "), strings.Join(roleAliasNames(), ", "))
+}
+
+func (v roleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v roleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isKnownRole(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Role",
+			fmt.Sprintf("The role %q is not supported. Valid roles are: %s, or the aliases %s.", req.ConfigValue.ValueString(), strings.Join(userRoles, ", "), strings.Join(roleAliasNames(), ", ")),
+		)
+	}
+}
+
+// projectRoles lists the roles that can be assigned to project members
+// through the API. The personal owner role belongs to the owner of a
+// personal project and cannot be assigned.
+var projectRoles = []string{"project:admin", "project:editor", "project:viewer"}
+
+var _ validator.String = projectRoleValidator{}
+
+// projectRoleValidator validates that a string is an assignable project
+// role.
+type projectRoleValidator struct{}
+
+func (v projectRoleValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of %s", strings.Join(projectRoles, ", "))
+}
+
+func (v projectRoleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v projectRoleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(projectRoles, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Project Role",
+			fmt.Sprintf("The project role %q is not supported. Valid project roles are: %s.", req.ConfigValue.ValueString(), strings.Join(projectRoles, ", ")),
+		)
+	}
+}
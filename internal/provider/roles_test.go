@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRoleReplacement(t *testing.T) {
+	deprecatedRoles["global:legacy"] = "global:member"
+	t.Cleanup(func() { delete(deprecatedRoles, "global:legacy") })
+
+	replacement, ok := roleReplacement("global:legacy")
+	if !ok {
+		t.Fatal("expected global:legacy to be deprecated")
+	}
+	if replacement != "global:member" {
+		t.Errorf("expected replacement global:member, got %s", replacement)
+	}
+
+	if _, ok := roleReplacement("global:admin"); ok {
+		t.Error("expected global:admin not to be deprecated")
+	}
+}
+
+func TestCanonicalRole(t *testing.T) {
+	for _, role := range []string{"global:admin", "Global:Admin", "GLOBAL:ADMIN", "gLoBaL:aDmIn"} {
+		if got := canonicalRole(role); got != "global:admin" {
+			t.Errorf("canonicalRole(%q) = %q, expected global:admin", role, got)
+		}
+	}
+
+	for alias, want := range map[string]string{"admin": "global:admin", "Member": "global:member", "USER": "global:member"} {
+		if got := canonicalRole(alias); got != want {
+			t.Errorf("canonicalRole(%q) = %q, expected %s", alias, got, want)
+		}
+	}
+
+	if got := canonicalRole("global:owner"); got != "global:owner" {
+		t.Errorf("expected unknown role to be returned unchanged, got %q", got)
+	}
+}
+
+func TestRoleStateValue(t *testing.T) {
+	configured := types.StringValue("Global:Member")
+
+	if got := roleStateValue(configured, "global:member"); !got.Equal(configured) {
+		t.Errorf("expected configured spelling to be kept, got %s", got)
+	}
+
+	if got := roleStateValue(configured, "global:admin"); got.ValueString() != "global:admin" {
+		t.Errorf("expected changed role to be taken from the API, got %s", got)
+	}
+
+	alias := types.StringValue("user")
+	if got := roleStateValue(alias, "global:member"); !got.Equal(alias) {
+		t.Errorf("expected configured alias to be kept, got %s", got)
+	}
+
+	if got := roleStateValue(configured, ""); !got.Equal(configured) {
+		t.Errorf("expected missing API role to keep the current value, got %s", got)
+	}
+}
+
+func TestRoleValidator(t *testing.T) {
+	tests := map[string]bool{
+		"global:member": false,
+		"GLOBAL:MEMBER": false,
+		"Global:Admin":  false,
+		"global:owner":  true,
+		"admin":         false,
+		"User":          false,
+		"owner":         true,
+	}
+
+	for role, expectError := range tests {
+		req := validator.StringRequest{
+			Path:        path.Root("role"),
+			ConfigValue: types.StringValue(role),
+		}
+		resp := &validator.StringResponse{}
+
+		roleValidator{}.ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != expectError {
+			t.Errorf("role %q: expected error %t, got diagnostics: %v", role, expectError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestIsAdminRole(t *testing.T) {
+	tests := map[string]bool{
+		"global:owner":  true,
+		"global:admin":  true,
+		"GLOBAL:ADMIN":  true,
+		"global:member": false,
+		"":              false,
+	}
+
+	for role, want := range tests {
+		if got := isAdminRole(role); got != want {
+			t.Errorf("isAdminRole(%q) = %t, expected %t", role, got, want)
+		}
+	}
+}
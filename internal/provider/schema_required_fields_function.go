@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SchemaRequiredFieldsFunction{}
+
+func NewSchemaRequiredFieldsFunction() function.Function {
+	return &SchemaRequiredFieldsFunction{}
+}
+
+// SchemaRequiredFieldsFunction defines the function implementation.
+type SchemaRequiredFieldsFunction struct{}
+
+func (f *SchemaRequiredFieldsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schema_required_fields"
+}
+
+func (f *SchemaRequiredFieldsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "List the required properties of a credential schema",
+		MarkdownDescription: "Returns the names of the required properties of a credential data schema, as returned by the `/credentials/schema/{credentialTypeName}` endpoint, in the order the schema lists them. Returns an empty list when the schema has no `required` properties.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schema",
+				MarkdownDescription: "The credential data schema as a JSON object string",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *SchemaRequiredFieldsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schemaJSON string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &schemaJSON))
+
+	if resp.Error != nil {
+		return
+	}
+
+	schemaObject, err := decodeJSONObject(schemaJSON)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid schema: %s", err))
+		return
+	}
+
+	required, err := schemaRequiredFields(schemaObject)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid schema: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, required))
+}
+
+// schemaRequiredFields returns the entries of the required array of a JSON
+// schema object.
+func schemaRequiredFields(schema map[string]interface{}) ([]string, error) {
+	fields := []string{}
+
+	value, ok := schema["required"]
+	if !ok || value == nil {
+		return fields, nil
+	}
+
+	required, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected required to be an array")
+	}
+
+	for i, entry := range required {
+		field, ok := entry.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected required entry at index %d to be a string", i)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSchemaRequiredFieldsFunction_Run(t *testing.T) {
+	testCases := map[string]struct {
+		schema  string
+		want    []string
+		wantErr bool
+	}{
+		"credential schema": {
+			schema: `{
+				"additionalProperties": false,
+				"type": "object",
+				"properties": {
+					"domain": {"type": "string"},
+					"apiKey": {"type": "string"},
+					"region": {"type": "string"}
+				},
+				"required": ["domain", "apiKey"]
+			}`,
+			want: []string{"domain", "apiKey"},
+		},
+		"no required properties": {
+			schema: `{"type":"object","properties":{"token":{"type":"string"}}}`,
+			want:   []string{},
+		},
+		"invalid json": {
+			schema:  `{"required":`,
+			wantErr: true,
+		},
+		"required not an array": {
+			schema:  `{"required":"apiKey"}`,
+			wantErr: true,
+		},
+		"required entry not a string": {
+			schema:  `{"required":["apiKey",1]}`,
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(testCase.schema),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewSchemaRequiredFieldsFunction().Run(context.Background(), req, resp)
+
+			if testCase.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got, ok := resp.Result.Value().(types.List)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			fields := []string{}
+			if diags := got.ElementsAs(context.Background(), &fields, false); diags.HasError() {
+				t.Fatalf("unexpected error converting result: %v", diags)
+			}
+			if !reflect.DeepEqual(fields, testCase.want) {
+				t.Errorf("expected %q, got %q", testCase.want, fields)
+			}
+		})
+	}
+}
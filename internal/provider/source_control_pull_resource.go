@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SourceControlPullResource{}
+
+func NewSourceControlPullResource() resource.Resource {
+	return &SourceControlPullResource{}
+}
+
+// SourceControlPullResource defines the resource implementation.
+type SourceControlPullResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// SourceControlPullResourceModel describes the resource data model.
+type SourceControlPullResourceModel struct {
+	ID                types.String      `tfsdk:"id"`
+	Force             types.Bool        `tfsdk:"force"`
+	Variables         map[string]string `tfsdk:"variables"`
+	Triggers          map[string]string `tfsdk:"triggers"`
+	SkipIfUnavailable types.Bool        `tfsdk:"skip_if_unavailable"`
+	Skipped           types.Bool        `tfsdk:"skipped"`
+	WorkflowIDs       types.Set         `tfsdk:"workflow_ids"`
+	CredentialIDs     types.Set         `tfsdk:"credential_ids"`
+	PulledAt          types.String      `tfsdk:"pulled_at"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *SourceControlPullResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_control_pull"
+}
+
+func (r *SourceControlPullResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Source control pull resource for driving n8n's git-based environments from Terraform pipelines. Creating the resource pulls the workflows, credentials, tags and variables from the git repository connected to the instance. " +
+			"Changing any argument pulls again, so set `triggers`, e.g. to the commit being deployed, to pull on every deployment. Destroying the resource only removes it from state. Source control requires an Enterprise license and a connected repository.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the pull, set to `pulled_at`, or to the time of the attempt for a skipped pull",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force": schema.BoolAttribute{
+				MarkdownDescription: "Whether to overwrite changes made on the instance that conflict with the repository. Otherwise the pull fails on conflicts. Defaults to false.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "Variable values keyed by variable key, overriding the values in the repository",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that pull again when they change, such as the commit SHA of a deployment",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"skip_if_unavailable": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the pull with a warning instead of failing when the instance cannot use source control, e.g. a Community edition instance or one without a connected repository. A skipped pull records empty `workflow_ids` and `credential_ids` and a null `pulled_at` without touching the instance, and is removed from state on destroy without any API call. Change `triggers` to retry it once source control is available. Defaults to `false`.",
+				Optional:            true,
+			},
+			"skipped": schema.BoolAttribute{
+				MarkdownDescription: "Whether the pull was skipped because the instance cannot use source control. Only set when `skip_if_unavailable` is true.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the workflows the pull imported or updated",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credential_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the credentials the pull imported or updated",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"pulled_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the pull, in RFC3339 format",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *SourceControlPullResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *SourceControlPullResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SourceControlPullResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	result, err := r.client.PullSourceControl(ctx, &client.SourceControlPullRequest{
+		Force:     data.Force.ValueBool(),
+		Variables: data.Variables,
+	})
+	if data.SkipIfUnavailable.ValueBool() && client.IsSourceControlUnavailableError(err) {
+		resp.Diagnostics.AddWarning(
+			"Source Control Unavailable",
+			fmt.Sprintf("The n8n instance cannot use source control, so the pull was skipped because skip_if_unavailable is set. Nothing was imported. Change triggers to pull again once source control is set up.\n\nAPI response: %s", err),
+		)
+
+		var diags diag.Diagnostics
+		data.WorkflowIDs, diags = types.SetValueFrom(ctx, types.StringType, []string{})
+		resp.Diagnostics.Append(diags...)
+		data.CredentialIDs, diags = types.SetValueFrom(ctx, types.StringType, []string{})
+		resp.Diagnostics.Append(diags...)
+		data.Skipped = types.BoolValue(true)
+		data.PulledAt = types.StringNull()
+		data.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339Nano))
+
+		// Save data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	if err != nil {
+		addSourceControlError(&resp.Diagnostics, err)
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.WorkflowIDs, diags = types.SetValueFrom(ctx, types.StringType, importedItemIDs(result.Workflows))
+	resp.Diagnostics.Append(diags...)
+	data.CredentialIDs, diags = types.SetValueFrom(ctx, types.StringType, importedItemIDs(result.Credentials))
+	resp.Diagnostics.Append(diags...)
+	data.PulledAt = types.StringValue(time.Now().UTC().Format(time.RFC3339Nano))
+	data.ID = data.PulledAt
+	data.Skipped = types.BoolValue(false)
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_source_control_pull", data.ID.ValueString())
+
+	tflog.Debug(ctx, "Pulled from n8n source control", map[string]interface{}{
+		"workflows":   len(result.Workflows),
+		"credentials": len(result.Credentials),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourceControlPullResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A pull is an action with nothing to refresh, so the state is kept
+	// as it is
+}
+
+func (r *SourceControlPullResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SourceControlPullResourceModel
+
+	// Every argument except the timeouts replaces the resource, so only
+	// the timeouts can change here
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourceControlPullResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SourceControlPullResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing was pulled for a skipped resource
+	if data.Skipped.ValueBool() {
+		tflog.Trace(ctx, "Removed skipped n8n source control pull resource")
+		return
+	}
+
+	// A pull cannot be undone, so destroying the resource leaves the
+	// imported workflows and credentials as they are
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_source_control_pull", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n source control pull resource")
+}
+
+// importedItemIDs returns the sorted IDs of items.
+func importedItemIDs(items []client.SourceControlImportedItem) []string {
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		ids[string(item.ID)] = true
+	}
+
+	return sortedKeys(ids)
+}
+
+// addSourceControlError adds a diagnostic for a failed pull, with a targeted
+// one when the instance cannot use source control at all.
+func addSourceControlError(diags *diag.Diagnostics, err error) {
+	if client.IsSourceControlUnavailableError(err) {
+		diags.AddError(
+			"Source Control Unavailable",
+			fmt.Sprintf("Unable to pull from source control because it is not set up on the instance. "+
+				"Source control requires an Enterprise license, and a git repository must be connected under Settings > Environments before pulling. API error: %s", err),
+		)
+		return
+	}
+
+	addClientError(diags, "pull from source control", err)
+}
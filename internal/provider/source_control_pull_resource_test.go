@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// runSourceControlPullCreate creates a pull through r with the given plan
+// values and returns the response.
+func runSourceControlPullCreate(t *testing.T, r *SourceControlPullResource, values map[string]tftypes.Value) *fwresource.CreateResponse {
+	t.Helper()
+
+	values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	values["workflow_ids"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue)
+	values["credential_ids"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue)
+	values["pulled_at"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	pullSchema, plan := resourceTestValue(t, r, values)
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: pullSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: pullSchema, Raw: plan}}, resp)
+
+	return resp
+}
+
+func TestSourceControlPullResourceCreate(t *testing.T) {
+	var request map[string]interface{}
+	r := &SourceControlPullResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/source-control/pull" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"variables":{"added":[],"changed":["env"]},
+			"credentials":[{"id":"c1","name":"GitHub","type":"githubApi"}],
+			"workflows":[{"id":"wf2","name":"Invoices"},{"id":"wf1","name":"Orders"}],
+			"tags":{"tags":[],"mappings":[]}
+		}`))
+	})}
+
+	resp := runSourceControlPullCreate(t, r, map[string]tftypes.Value{
+		"force": tftypes.NewValue(tftypes.Bool, true),
+		"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "production"),
+		}),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if request["force"] != true {
+		t.Errorf("expected a forced pull, got %v", request)
+	}
+	if variables, _ := request["variables"].(map[string]interface{}); variables["env"] != "production" {
+		t.Errorf("expected the variable overrides to be sent, got %v", request["variables"])
+	}
+
+	var state SourceControlPullResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	var workflowIDs, credentialIDs []string
+	if diags := state.WorkflowIDs.ElementsAs(context.Background(), &workflowIDs, false); diags.HasError() {
+		t.Fatalf("unexpected error reading workflow_ids: %v", diags)
+	}
+	if diags := state.CredentialIDs.ElementsAs(context.Background(), &credentialIDs, false); diags.HasError() {
+		t.Fatalf("unexpected error reading credential_ids: %v", diags)
+	}
+	if want := []string{"wf1", "wf2"}; !slices.Equal(workflowIDs, want) {
+		t.Errorf("expected workflow_ids %v, got %v", want, workflowIDs)
+	}
+	if want := []string{"c1"}; !slices.Equal(credentialIDs, want) {
+		t.Errorf("expected credential_ids %v, got %v", want, credentialIDs)
+	}
+	if state.PulledAt.IsNull() || state.ID != state.PulledAt {
+		t.Errorf("expected the id to be the pull timestamp, got id %s and pulled_at %s", state.ID, state.PulledAt)
+	}
+}
+
+func TestSourceControlPullResourceCreate_unavailable(t *testing.T) {
+	testCases := map[string]struct {
+		status int
+		body   string
+		want   string
+	}{
+		"unlicensed":    {status: http.StatusUnauthorized, body: `{"message":"Source Control feature is not licensed or not enabled"}`, want: "Source Control Unavailable"},
+		"not connected": {status: http.StatusBadRequest, body: `{"message":"Source control is not connected to a repository"}`, want: "Source Control Unavailable"},
+		"conflict":      {status: http.StatusConflict, body: `{"message":"There are conflicting changes, use force to overwrite"}`, want: "Client Error"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &SourceControlPullResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			})}
+
+			resp := runSourceControlPullCreate(t, r, map[string]tftypes.Value{})
+
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tc.want {
+				t.Fatalf("expected a %s error, got: %v", tc.want, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSourceControlPullResource_skipIfUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int
+	r := &SourceControlPullResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Source Control feature is not licensed or not enabled"}`))
+	})}
+
+	createResp := runSourceControlPullCreate(t, r, map[string]tftypes.Value{
+		"skip_if_unavailable": tftypes.NewValue(tftypes.Bool, true),
+		"skipped":             tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+	})
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if len(createResp.Diagnostics.Warnings()) != 1 || createResp.Diagnostics.Warnings()[0].Summary() != "Source Control Unavailable" {
+		t.Fatalf("expected a Source Control Unavailable warning, got: %v", createResp.Diagnostics)
+	}
+
+	var state SourceControlPullResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if !state.Skipped.ValueBool() || !state.PulledAt.IsNull() || len(state.WorkflowIDs.Elements()) != 0 {
+		t.Fatalf("expected the pull to be recorded as skipped, got %+v", state)
+	}
+
+	// Destroying a skipped pull must not call the API.
+	requests = 0
+
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error on delete: %v", deleteResp.Diagnostics)
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no API requests for a skipped pull, got %d", requests)
+	}
+}
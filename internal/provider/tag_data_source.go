@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagDataSource{}
+
+func NewTagDataSource() datasource.DataSource {
+	return &TagDataSource{}
+}
+
+// TagDataSource defines the data source implementation.
+type TagDataSource struct {
+	client *client.Client
+}
+
+// TagDataSourceModel describes the data source data model.
+type TagDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+func (d *TagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+func (d *TagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tag data source for querying existing n8n workflow tags. You must specify either `id` or `name` to identify the tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the tag. Either id or name must be specified.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tag, matched exactly. Either id or name must be specified.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the tag was created",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the tag was last updated",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TagDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *TagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TagDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that exactly one of ID or name is specified
+	if data.ID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"Exactly one of 'id' or 'name' must be specified",
+		)
+		return
+	}
+
+	var tag *client.Tag
+	var err error
+
+	if !data.ID.IsNull() {
+		tag, err = d.client.GetTag(ctx, data.ID.ValueString())
+	} else {
+		tag, err = d.client.GetTagByName(ctx, data.Name.ValueString())
+	}
+
+	if client.IsNotFound(err) {
+		if !data.ID.IsNull() {
+			resp.Diagnostics.AddError("Tag Not Found", fmt.Sprintf("Tag with ID %q not found", data.ID.ValueString()))
+		} else {
+			resp.Diagnostics.AddError("Tag Not Found", fmt.Sprintf("Tag with name %q not found", data.Name.ValueString()))
+		}
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tag", err)
+		return
+	}
+
+	data.ID = types.StringValue(tag.ID)
+	data.Name = types.StringValue(tag.Name)
+	data.CreatedAt = types.StringValue(tag.CreatedAt.Format(time.RFC3339Nano))
+	data.UpdatedAt = types.StringValue(tag.UpdatedAt.Format(time.RFC3339Nano))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
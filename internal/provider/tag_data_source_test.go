@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccTagDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read the tag by ID and by name
+			{
+				Config: testAccTagDataSourceConfig(name),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_tag.by_id",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name),
+					),
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_tag.by_name",
+						tfjsonpath.New("id"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// Try to read a non-existent tag by name
+			{
+				Config: `
+data "n8ncloud_tag" "test" {
+  name = "non-existent-tag"
+}
+`,
+				ExpectError: regexp.MustCompile(`Tag with name "non-existent-tag" not found`),
+			},
+		},
+	})
+}
+
+func testAccTagDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "n8ncloud_tag" "test" {
+  name = %[1]q
+}
+
+data "n8ncloud_tag" "by_id" {
+  id = n8ncloud_tag.test.id
+}
+
+data "n8ncloud_tag" "by_name" {
+  name = n8ncloud_tag.test.name
+}
+`, name)
+}
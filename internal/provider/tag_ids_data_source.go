@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagIDsDataSource{}
+
+func NewTagIDsDataSource() datasource.DataSource {
+	return &TagIDsDataSource{}
+}
+
+// TagIDsDataSource defines the data source implementation.
+type TagIDsDataSource struct {
+	client *client.Client
+}
+
+// TagIDsDataSourceModel describes the data source data model.
+type TagIDsDataSourceModel struct {
+	ID            types.String      `tfsdk:"id"`
+	Names         []string          `tfsdk:"names"`
+	CreateMissing types.Bool        `tfsdk:"create_missing"`
+	ExtraQuery    map[string]string `tfsdk:"extra_query"`
+	IDs           map[string]string `tfsdk:"ids"`
+}
+
+func (d *TagIDsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_ids"
+}
+
+func (d *TagIDsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tag IDs data source for resolving tag names to the IDs the API expects, e.g. when assigning tags to workflows.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the data source",
+				Computed:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The tag names to resolve. Names are matched exactly.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"create_missing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to create tags that do not exist yet instead of failing. Tags created this way are not managed by Terraform and are not deleted on destroy. Defaults to false.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"ids": schema.MapAttribute{
+				MarkdownDescription: "The tag IDs keyed by tag name",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TagIDsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *TagIDsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TagIDsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, missing, err := tagIDsByName(ctx, d.client, data.Names, data.CreateMissing.ValueBool(), data.ExtraQuery)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "resolve tag IDs", err)
+		return
+	}
+
+	if len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("names"),
+			"Tags Not Found",
+			fmt.Sprintf("No tags exist with the names: %s. Create them first or set create_missing to true.", strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("tag_ids")
+	data.IDs = ids
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// tagIDsByName maps each of names to the ID of the tag with that exact
+// name, listing the tags with the additional query parameters extraQuery.
+// Names no tag has are created when createMissing is set, and returned
+// without duplicates, in the order they were given, otherwise.
+func tagIDsByName(ctx context.Context, c *client.Client, names []string, createMissing bool, extraQuery map[string]string) (map[string]string, []string, error) {
+	tags, err := c.ListTags(ctx, &client.ListOptions{ExtraQuery: extraQuery})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag.ID
+	}
+
+	ids := make(map[string]string, len(names))
+	var missing []string
+	for _, name := range names {
+		if _, seen := ids[name]; seen {
+			continue
+		}
+
+		if id, ok := byName[name]; ok {
+			ids[name] = id
+			continue
+		}
+
+		if !createMissing {
+			if !containsString(missing, name) {
+				missing = append(missing, name)
+			}
+			continue
+		}
+
+		tflog.Debug(ctx, "Creating missing n8n cloud tag", map[string]interface{}{
+			"name": name,
+		})
+
+		tag, err := c.CreateTag(ctx, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create tag %q: %w", name, err)
+		}
+		ids[name] = tag.ID
+	}
+
+	return ids, missing, nil
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestTagIDsByName(t *testing.T) {
+	var created []string
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			query = r.URL.Query()
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","name":"prod"},{"id":"2","name":"billing"}],"nextCursor":null}`))
+		case http.MethodPost:
+			var body struct {
+				Name string `json:"name"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected error decoding request body: %s", err)
+			}
+			created = append(created, body.Name)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"new-` + body.Name + `","name":"` + body.Name + `"}`))
+		}
+	})
+
+	names := []string{"prod", "staging", "billing", "staging"}
+
+	t.Run("missing", func(t *testing.T) {
+		created = nil
+
+		ids, missing, err := tagIDsByName(context.Background(), c, names, false, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := []string{"staging"}; !reflect.DeepEqual(missing, want) {
+			t.Errorf("expected missing %v, got %v", want, missing)
+		}
+		if want := map[string]string{"prod": "1", "billing": "2"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("expected ids %v, got %v", want, ids)
+		}
+		if len(created) != 0 {
+			t.Errorf("expected no tags to be created, got %v", created)
+		}
+	})
+
+	t.Run("create_missing", func(t *testing.T) {
+		created = nil
+
+		ids, missing, err := tagIDsByName(context.Background(), c, names, true, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(missing) != 0 {
+			t.Errorf("expected no missing tags, got %v", missing)
+		}
+		if want := map[string]string{"prod": "1", "billing": "2", "staging": "new-staging"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("expected ids %v, got %v", want, ids)
+		}
+		if want := []string{"staging"}; !reflect.DeepEqual(created, want) {
+			t.Errorf("expected tags %v to be created once, got %v", want, created)
+		}
+	})
+
+	t.Run("extra_query", func(t *testing.T) {
+		if _, _, err := tagIDsByName(context.Background(), c, []string{"prod"}, false, map[string]string{"filter": "a&b=c"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := query.Get("filter"); got != "a&b=c" {
+			t.Errorf("expected the extra query parameter to be sent encoded, got query %v", query)
+		}
+	})
+}
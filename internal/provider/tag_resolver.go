@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// tagIDPattern matches the formats of tag IDs: numbers on older n8n
+// versions, and 16 character alphanumeric IDs on newer ones.
+var tagIDPattern = regexp.MustCompile(`^([0-9]+|[A-Za-z0-9]{16})$`)
+
+// resolveTag looks up a tag by ID or name. Values shaped like a tag ID are
+// looked up as an ID first, falling back to a name lookup since a name can
+// have the same shape; anything else is a name. Both the tag resource
+// import and the tag data source resolve tags through here.
+func resolveTag(ctx context.Context, c *client.Client, idOrName string) (*client.Tag, error) {
+	if tagIDPattern.MatchString(idOrName) {
+		tag, err := c.GetTag(ctx, idOrName)
+		if !client.IsNotFound(err) {
+			return tag, err
+		}
+	}
+
+	return c.GetTagByName(ctx, idOrName)
+}
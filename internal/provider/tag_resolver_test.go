@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestResolveTag(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/tags":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2tUt1wbLX592XDdX","name":"production"},{"id":"7","name":"2024"}],"nextCursor":null}`))
+		case "/api/v1/tags/2tUt1wbLX592XDdX":
+			_, _ = w.Write([]byte(`{"id":"2tUt1wbLX592XDdX","name":"production"}`))
+		case "/api/v1/tags/7":
+			_, _ = w.Write([]byte(`{"id":"7","name":"2024"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	})
+
+	testCases := map[string]struct {
+		idOrName     string
+		wantID       string
+		wantNotFound bool
+	}{
+		"id":                  {idOrName: "2tUt1wbLX592XDdX", wantID: "2tUt1wbLX592XDdX"},
+		"numeric id":          {idOrName: "7", wantID: "7"},
+		"name":                {idOrName: "production", wantID: "2tUt1wbLX592XDdX"},
+		"name shaped like id": {idOrName: "2024", wantID: "7"},
+		"unknown id":          {idOrName: "8", wantNotFound: true},
+		"unknown name":        {idOrName: "staging", wantNotFound: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tag, err := resolveTag(context.Background(), c, testCase.idOrName)
+
+			if testCase.wantNotFound {
+				if !client.IsNotFound(err) {
+					t.Fatalf("expected not found error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tag.ID != testCase.wantID {
+				t.Errorf("expected tag %s, got %s", testCase.wantID, tag.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TagResource{}
+var _ resource.ResourceWithImportState = &TagResource{}
+
+func NewTagResource() resource.Resource {
+	return &TagResource{}
+}
+
+// TagResource defines the resource implementation.
+type TagResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// TagResourceModel describes the resource data model.
+type TagResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *TagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+func (r *TagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tag resource for managing n8n workflow tags. Tags can be imported using their name or ID: `terraform import n8ncloud_tag.example production`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the tag",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tag, unique on the instance. Changing it renames the tag in place, keeping it on its workflows.",
+				Required:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the tag was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the tag was last updated",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *TagResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating n8n tag", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	tag, err := r.client.CreateTag(ctx, data.Name.ValueString())
+	if err != nil {
+		addTagWriteError(&resp.Diagnostics, "create", data.Name.ValueString(), err)
+		return
+	}
+
+	setTagAttributes(&data, tag)
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_tag", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Created n8n tag resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	tag, err := r.client.GetTag(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		// The tag was deleted outside of Terraform, so plan to recreate it
+		tflog.Warn(ctx, "n8n tag not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tag", err)
+		return
+	}
+
+	setTagAttributes(&data, tag)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TagResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	tag, err := r.client.UpdateTag(ctx, data.ID.ValueString(), data.Name.ValueString())
+	if err != nil {
+		addTagWriteError(&resp.Diagnostics, "rename", data.Name.ValueString(), err)
+		return
+	}
+
+	setTagAttributes(&data, tag)
+
+	r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_tag", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Updated n8n tag resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TagResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+	defer cancel()
+
+	err := r.client.DeleteTag(ctx, data.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete tag", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_tag", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n tag resource")
+}
+
+func (r *TagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The import ID may be the tag's name or ID, resolved the same way as in
+	// the tag data source
+	tag, err := resolveTag(ctx, r.client, req.ID)
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Tag Not Found", fmt.Sprintf("No tag with ID or name %q exists", req.ID))
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("get tag %s", req.ID), err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tag.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), tag.Name)...)
+}
+
+// addTagWriteError adds a diagnostic for a failed attempt to create or
+// rename a tag to name. Tag names are unique, so a conflict gets its own
+// diagnostic pointing at import.
+func addTagWriteError(diags *diag.Diagnostics, action, name string, err error) {
+	if client.IsConflictError(err) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Tag Already Exists",
+			fmt.Sprintf("Unable to %s tag %q because a tag with this name already exists on the n8n instance, and tag names must be unique. "+
+				"To manage the existing tag with Terraform, import it instead, e.g. with `terraform import n8ncloud_tag.<name> %s` or an import block. API error: %s", action, name, name, err),
+		)
+		return
+	}
+
+	addClientError(diags, action+" tag", err)
+}
+
+// setTagAttributes updates data from a tag read from the API.
+func setTagAttributes(data *TagResourceModel, tag *client.Tag) {
+	data.ID = types.StringValue(tag.ID)
+	data.Name = types.StringValue(tag.Name)
+	data.CreatedAt = types.StringValue(tag.CreatedAt.Format(time.RFC3339Nano))
+	data.UpdatedAt = types.StringValue(tag.UpdatedAt.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccTagResource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTagResourceConfig(name),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_tag.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name),
+					),
+				},
+			},
+			// ImportState testing by ID
+			{
+				ResourceName:      "n8ncloud_tag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing by name
+			{
+				ResourceName:      "n8ncloud_tag.test",
+				ImportState:       true,
+				ImportStateId:     name,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccTagResourceConfig(name + "-renamed"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_tag.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name+"-renamed"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccTagResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "n8ncloud_tag" "test" {
+  name = %[1]q
+}
+`, name)
+}
+
+func TestTagResourceCreate_conflict(t *testing.T) {
+	r := &TagResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"Tag already exists"}`))
+	})}
+
+	tagSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name": tftypes.NewValue(tftypes.String, "production"),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: tagSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: tagSchema, Raw: plan}}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Tag Already Exists" {
+		t.Fatalf("expected a Tag Already Exists error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestTagResourceImportState_byName(t *testing.T) {
+	r := &TagResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"2tUt1wbLX592XDdX","name":"production"}],"nextCursor":null}`))
+	})}
+
+	tagSchema, empty := resourceTestValue(t, r, map[string]tftypes.Value{})
+
+	resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: tagSchema, Raw: empty}}
+	r.ImportState(context.Background(), fwresource.ImportStateRequest{ID: "production"}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state TagResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.ID.ValueString() != "2tUt1wbLX592XDdX" {
+		t.Errorf("expected id 2tUt1wbLX592XDdX, got %s", state.ID)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultOperationTimeout bounds resource operations that have no timeout
+// configured in the timeouts block.
+const defaultOperationTimeout = 20 * time.Minute
+
+// timeoutsModel describes the timeouts block of a resource.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block of a resource whose
+// create operation defaults to createDefault. The other operations default
+// to defaultOperationTimeout.
+func timeoutsBlock(createDefault time.Duration) schema.Block {
+	attribute := func(operation string, defaultTimeout time.Duration) schema.Attribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How long %s may take, including retries and waiting on the n8n instance, as a duration string such as `30s` or `10m`. Defaults to `%s`.", operation, shortDuration(defaultTimeout)),
+			Optional:            true,
+			Validators: []validator.String{
+				durationValidator{},
+			},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Custom timeouts for the operations of the resource. Requests still running when an operation times out, or when Terraform is interrupted, are aborted.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("create", createDefault),
+			"read":   attribute("read", defaultOperationTimeout),
+			"update": attribute("update", defaultOperationTimeout),
+			"delete": attribute("delete", defaultOperationTimeout),
+		},
+	}
+}
+
+// createTimeout returns the configured create timeout, or defaultTimeout if
+// the timeouts block or its create attribute is not set.
+func (m *timeoutsModel) createTimeout(defaultTimeout time.Duration) time.Duration {
+	if m == nil {
+		return defaultTimeout
+	}
+
+	return parseTimeout(m.Create, defaultTimeout)
+}
+
+// readTimeout returns the configured read timeout, or
+// defaultOperationTimeout if it is not set.
+func (m *timeoutsModel) readTimeout() time.Duration {
+	if m == nil {
+		return defaultOperationTimeout
+	}
+
+	return parseTimeout(m.Read, defaultOperationTimeout)
+}
+
+// updateTimeout returns the configured update timeout, or
+// defaultOperationTimeout if it is not set.
+func (m *timeoutsModel) updateTimeout() time.Duration {
+	if m == nil {
+		return defaultOperationTimeout
+	}
+
+	return parseTimeout(m.Update, defaultOperationTimeout)
+}
+
+// deleteTimeout returns the configured delete timeout, or
+// defaultOperationTimeout if it is not set.
+func (m *timeoutsModel) deleteTimeout() time.Duration {
+	if m == nil {
+		return defaultOperationTimeout
+	}
+
+	return parseTimeout(m.Delete, defaultOperationTimeout)
+}
+
+// parseTimeout returns the duration value holds, or defaultTimeout if it is
+// null or unknown. Values are validated at plan time, so a parse failure
+// falls back to the default.
+func parseTimeout(value types.String, defaultTimeout time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
+// shortDuration formats d without trailing zero units, e.g. 30m instead of
+// 30m0s.
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a positive Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30s or 10m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("The value %q is not a positive duration. Use a value such as \"30s\", \"10m\" or \"1h\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
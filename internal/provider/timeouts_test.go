@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeoutsModel(t *testing.T) {
+	var unset *timeoutsModel
+	if got := unset.createTimeout(time.Hour); got != time.Hour {
+		t.Errorf("expected the create default without a timeouts block, got %s", got)
+	}
+	if got := unset.deleteTimeout(); got != defaultOperationTimeout {
+		t.Errorf("expected the operation default without a timeouts block, got %s", got)
+	}
+
+	timeouts := &timeoutsModel{
+		Create: types.StringValue("5m"),
+		Read:   types.StringNull(),
+		Update: types.StringValue("90s"),
+		Delete: types.StringUnknown(),
+	}
+
+	if got := timeouts.createTimeout(time.Hour); got != 5*time.Minute {
+		t.Errorf("expected create timeout 5m, got %s", got)
+	}
+	if got := timeouts.readTimeout(); got != defaultOperationTimeout {
+		t.Errorf("expected the default read timeout, got %s", got)
+	}
+	if got := timeouts.updateTimeout(); got != 90*time.Second {
+		t.Errorf("expected update timeout 90s, got %s", got)
+	}
+	if got := timeouts.deleteTimeout(); got != defaultOperationTimeout {
+		t.Errorf("expected the default delete timeout for an unknown value, got %s", got)
+	}
+}
+
+func TestShortDuration(t *testing.T) {
+	testCases := map[time.Duration]string{
+		30 * time.Minute:             "30m",
+		time.Hour:                    "1h",
+		90 * time.Minute:             "1h30m",
+		45 * time.Second:             "45s",
+		time.Minute + 30*time.Second: "1m30s",
+	}
+
+	for duration, want := range testCases {
+		if got := shortDuration(duration); got != want {
+			t.Errorf("expected %s to be formatted as %s, got %s", duration, want, got)
+		}
+	}
+}
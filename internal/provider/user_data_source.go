@@ -5,17 +5,21 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &UserDataSource{}
 
 func NewUserDataSource() datasource.DataSource {
 	return &UserDataSource{}
@@ -23,7 +27,8 @@ func NewUserDataSource() datasource.DataSource {
 
 // UserDataSource defines the data source implementation.
 type UserDataSource struct {
-	client *client.Client
+	client    *client.Client
+	inviteTTL time.Duration
 }
 
 // UserDataSourceModel describes the data source data model.
@@ -31,12 +36,60 @@ type UserDataSourceModel struct {
 	ID              types.String `tfsdk:"id"`
 	Email           types.String `tfsdk:"email"`
 	Role            types.String `tfsdk:"role"`
+	IsAdmin         types.Bool   `tfsdk:"is_admin"`
 	FirstName       types.String `tfsdk:"first_name"`
 	LastName        types.String `tfsdk:"last_name"`
 	IsPending       types.Bool   `tfsdk:"is_pending"`
+	InviteExpired   types.Bool   `tfsdk:"invite_expired"`
 	CreatedAt       types.String `tfsdk:"created_at"`
 	UpdatedAt       types.String `tfsdk:"updated_at"`
 	InviteAcceptURL types.String `tfsdk:"invite_accept_url"`
+	JSON            types.String `tfsdk:"json"`
+	APIKey          types.String `tfsdk:"api_key"`
+	FailIfAbsent    types.Bool   `tfsdk:"fail_if_absent"`
+	Found           types.Bool   `tfsdk:"found"`
+
+	IncludeProjectMemberships types.Bool                   `tfsdk:"include_project_memberships"`
+	ProjectMemberships        []UserProjectMembershipModel `tfsdk:"project_memberships"`
+}
+
+// UserProjectMembershipModel describes a project the user is a member of.
+type UserProjectMembershipModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	ProjectName types.String `tfsdk:"project_name"`
+}
+
+// userAuditRecord is the normalized user object exposed by the json
+// attribute. Its fields and their order are part of the attribute's
+// contract, and sensitive fields such as the invite URL are left out.
+type userAuditRecord struct {
+	ID        string  `json:"id"`
+	Email     string  `json:"email"`
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Role      string  `json:"role"`
+	IsPending bool    `json:"is_pending"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// userJSON returns the normalized JSON representation of user.
+func userJSON(user *client.User) (string, error) {
+	record, err := json.Marshal(userAuditRecord{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Role:      user.Role,
+		IsPending: user.IsPending,
+		CreatedAt: user.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: user.UpdatedAt.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(record), nil
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,21 +99,28 @@ func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRe
 func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "User data source for querying existing n8n cloud users. You must specify either `id` or `email` to identify the user.",
+		MarkdownDescription: "User data source for querying existing n8n cloud users. Specify exactly one of `id` or `email` to identify the user.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "The unique identifier of the user. Either id or email must be specified.",
+				MarkdownDescription: "The unique identifier of the user. Exactly one of id or email must be specified.",
 				Optional:            true,
 				Computed:            true,
 			},
 			"email": schema.StringAttribute{
-				MarkdownDescription: "The email address of the user. Either id or email must be specified.",
+				MarkdownDescription: "The email address of the user. Exactly one of id or email must be specified.",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					emailValidator{},
+				},
 			},
 			"role": schema.StringAttribute{
-				MarkdownDescription: "The role of the user",
+				MarkdownDescription: "The role of the user, such as `global:owner`, `global:admin` or `global:member`",
+				Computed:            true,
+			},
+			"is_admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user's role grants administrative access (`global:owner` or `global:admin`), derived from `role`",
 				Computed:            true,
 			},
 			"first_name": schema.StringAttribute{
@@ -75,6 +135,10 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Whether the user has not yet set up their account",
 				Computed:            true,
 			},
+			"invite_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether the invitation is likely to have expired: the user is still pending and was created longer ago than the provider `invite_ttl`. A heuristic to find stale invitations to re-send or clean up.",
+				Computed:            true,
+			},
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "The timestamp when the user was created",
 				Computed:            true,
@@ -87,28 +151,72 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "The URL for the user to accept their invitation",
 				Computed:            true,
 			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: apiKeyOverrideDescription,
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The user as a normalized JSON object with the keys `id`, `email`, `first_name`, `last_name`, `role`, `is_pending`, `created_at` and `updated_at`, e.g. for audit evidence collection. Sensitive fields such as the invite URL are excluded.",
+				Computed:            true,
+			},
+			"fail_if_absent": schema.BoolAttribute{
+				MarkdownDescription: "Whether reading fails when no user matches. Set to false to branch on `found` instead, e.g. to create the user only if it does not exist. Defaults to true.",
+				Optional:            true,
+			},
+			"found": schema.BoolAttribute{
+				MarkdownDescription: "Whether a matching user exists. When false, the other computed attributes are null.",
+				Computed:            true,
+			},
+			"include_project_memberships": schema.BoolAttribute{
+				MarkdownDescription: "Whether to look up the projects the user is a member of into `project_memberships`, e.g. for access audits. This lists the members of every project, one request per project. Defaults to false.",
+				Optional:            true,
+			},
+			"project_memberships": schema.ListNestedAttribute{
+				MarkdownDescription: "The projects the user is a member of, when `include_project_memberships` is true. Empty on instances without projects, which require an Enterprise license. The API does not report the user's role in each project.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"project_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the project",
+							Computed:            true,
+						},
+						"project_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the project",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func (d *UserDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		exactlyOneOfValidator{attributes: []string{"id", "email"}},
+	}
+}
+
 func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+	d.inviteTTL = providerData.InviteTTL
 }
 
 func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -121,36 +229,46 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	// Validate that either ID or email is specified
-	if data.ID.IsNull() && data.Email.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing Attribute",
-			"Either 'id' or 'email' must be specified",
-		)
-		return
-	}
+	apiClient := clientWithAPIKeyOverride(d.client, data.APIKey)
 
 	var user *client.User
 	var err error
 
-	// Query by ID or email
+	// Query by ID or email, exactly one of which is set
+	var lookup string
 	if !data.ID.IsNull() {
-		user, err = d.client.GetUser(ctx, data.ID.ValueString())
+		lookup = fmt.Sprintf("ID %q", data.ID.ValueString())
+		user, err = resolveUser(ctx, apiClient, data.ID.ValueString())
 	} else {
-		user, err = d.client.GetUserByEmail(ctx, data.Email.ValueString())
+		lookup = fmt.Sprintf("email %q", data.Email.ValueString())
+		user, err = resolveUser(ctx, apiClient, data.Email.ValueString())
 	}
 
+	if client.IsNotFound(err) {
+		if data.FailIfAbsent.IsNull() || data.FailIfAbsent.ValueBool() {
+			resp.Diagnostics.AddError("User Not Found", fmt.Sprintf("User with %s not found", lookup))
+			return
+		}
+
+		// Computed attributes are null in the configuration, so only the
+		// lookup values are kept
+		data.Found = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read user", err)
 		return
 	}
 
 	// Map response body to model
+	data.Found = types.BoolValue(true)
 	data.ID = types.StringValue(user.ID)
 	data.Email = types.StringValue(user.Email)
 	data.IsPending = types.BoolValue(user.IsPending)
-	data.CreatedAt = types.StringValue(user.CreatedAt.Format(time.RFC3339))
-	data.UpdatedAt = types.StringValue(user.UpdatedAt.Format(time.RFC3339))
+	data.InviteExpired = types.BoolValue(inviteExpired(user, d.inviteTTL, time.Now()))
+	data.CreatedAt = types.StringValue(user.CreatedAt.Format(time.RFC3339Nano))
+	data.UpdatedAt = types.StringValue(user.UpdatedAt.Format(time.RFC3339Nano))
 
 	// Set role from API response
 	if user.Role != "" {
@@ -158,6 +276,7 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	} else {
 		data.Role = types.StringNull()
 	}
+	data.IsAdmin = types.BoolValue(isAdminRole(user.Role))
 
 	if user.FirstName != nil {
 		data.FirstName = types.StringValue(*user.FirstName)
@@ -177,6 +296,59 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		data.InviteAcceptURL = types.StringNull()
 	}
 
+	userJSONValue, err := userJSON(user)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode user as JSON, got error: %s", err))
+		return
+	}
+	data.JSON = types.StringValue(userJSONValue)
+
+	if data.IncludeProjectMemberships.ValueBool() {
+		memberships, err := userProjectMemberships(ctx, apiClient, user.ID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read project memberships", err)
+			return
+		}
+		data.ProjectMemberships = memberships
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// userProjectMemberships returns the projects the user with the given ID is
+// a member of. The API has no endpoint for a user's projects, so the members
+// of every project are listed. Instances without projects have none.
+func userProjectMemberships(ctx context.Context, c *client.Client, userID string) ([]UserProjectMembershipModel, error) {
+	memberships := []UserProjectMembershipModel{}
+
+	projects, err := c.ListProjects(ctx, nil)
+	if client.IsFeatureUnavailableError(err) {
+		tflog.Debug(ctx, "Projects are not available on this n8n instance, leaving project memberships empty", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return memberships, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		members, err := c.ListProjectUsers(ctx, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list members of project %q: %w", project.ID, err)
+		}
+
+		for _, member := range members {
+			if member.ID == userID {
+				memberships = append(memberships, UserProjectMembershipModel{
+					ProjectID:   types.StringValue(project.ID),
+					ProjectName: types.StringValue(project.Name),
+				})
+				break
+			}
+		}
+	}
+
+	return memberships, nil
+}
@@ -4,15 +4,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
 	"regexp"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
 )
 
 func TestAccUserDataSource_basic(t *testing.T) {
@@ -98,6 +106,27 @@ func TestAccUserDataSource_notFound(t *testing.T) {
 				Config:      testAccUserDataSourceConfig_notFoundByEmail(),
 				ExpectError: regexp.MustCompile(`User with email "non-existent@example.com" not found`),
 			},
+			// Reject an ambiguous lookup before reading anything
+			{
+				Config:      testAccUserDataSourceConfig_idAndEmail(),
+				ExpectError: regexp.MustCompile(`Exactly one of id or email must be specified`),
+			},
+			// Report a missing user through found instead of failing
+			{
+				Config: testAccUserDataSourceConfig_notFoundNotFailing(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_user.test",
+						tfjsonpath.New("found"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_user.test",
+						tfjsonpath.New("id"),
+						knownvalue.Null(),
+					),
+				},
+			},
 		},
 	})
 }
@@ -140,6 +169,24 @@ data "n8ncloud_user" "test" {
 `
 }
 
+func testAccUserDataSourceConfig_notFoundNotFailing() string {
+	return `
+data "n8ncloud_user" "test" {
+  email          = "non-existent@example.com"
+  fail_if_absent = false
+}
+`
+}
+
+func testAccUserDataSourceConfig_idAndEmail() string {
+	return `
+data "n8ncloud_user" "test" {
+  id    = "non-existent-id"
+  email = "non-existent@example.com"
+}
+`
+}
+
 func testAccUserDataSourceConfig_notFoundByEmail() string {
 	return `
 data "n8ncloud_user" "test" {
@@ -147,3 +194,250 @@ data "n8ncloud_user" "test" {
 }
 `
 }
+
+func TestUserJSON(t *testing.T) {
+	firstName := "Ada"
+	user := &client.User{
+		ID:              "u1",
+		Email:           "ada@example.com",
+		FirstName:       &firstName,
+		Role:            "global:admin",
+		IsPending:       true,
+		CreatedAt:       client.Time{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		UpdatedAt:       client.Time{Time: time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)},
+		InviteAcceptUrl: "https://example.com/signup?token=secret",
+	}
+
+	got, err := userJSON(user)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `{"id":"u1","email":"ada@example.com","first_name":"Ada","last_name":null,"role":"global:admin","is_pending":true,"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-02-03T04:05:06Z"}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestUserDataSourceConfigValidators(t *testing.T) {
+	ctx := context.Background()
+	d := &UserDataSource{}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	testCases := map[string]struct {
+		id        interface{}
+		email     interface{}
+		wantError bool
+	}{
+		"id":            {id: "1"},
+		"email":         {email: "ada@example.com"},
+		"unknown email": {email: tftypes.UnknownValue},
+		"both":          {id: "1", email: "ada@example.com", wantError: true},
+		"unknown id":    {id: tftypes.UnknownValue, email: "ada@example.com", wantError: true},
+		"neither":       {wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for attribute, attributeType := range objectType.AttributeTypes {
+				attributes[attribute] = tftypes.NewValue(attributeType, nil)
+			}
+			attributes["id"] = tftypes.NewValue(tftypes.String, testCase.id)
+			attributes["email"] = tftypes.NewValue(tftypes.String, testCase.email)
+
+			req := datasource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}}
+			resp := &datasource.ValidateConfigResponse{}
+			for _, v := range d.ConfigValidators(ctx) {
+				v.ValidateDataSource(ctx, req, resp)
+			}
+
+			if resp.Diagnostics.HasError() != testCase.wantError {
+				t.Errorf("expected error %t, got: %v", testCase.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUserDataSourceRead_failIfAbsent(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+	})
+
+	ctx := context.Background()
+	d := &UserDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	testCases := map[string]struct {
+		failIfAbsent tftypes.Value
+		wantError    bool
+	}{
+		"default": {
+			failIfAbsent: tftypes.NewValue(tftypes.Bool, nil),
+			wantError:    true,
+		},
+		"fail": {
+			failIfAbsent: tftypes.NewValue(tftypes.Bool, true),
+			wantError:    true,
+		},
+		"report found": {
+			failIfAbsent: tftypes.NewValue(tftypes.Bool, false),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			attributes["email"] = tftypes.NewValue(tftypes.String, "missing@example.com")
+			attributes["fail_if_absent"] = testCase.failIfAbsent
+			config := tftypes.NewValue(objectType, attributes)
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+			d.Read(ctx, req, resp)
+
+			if testCase.wantError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected a User Not Found error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state UserDataSourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("unexpected error reading state: %v", diags)
+			}
+			if state.Found.IsNull() || state.Found.ValueBool() {
+				t.Errorf("expected found to be false, got %s", state.Found)
+			}
+			if !state.ID.IsNull() || !state.Role.IsNull() {
+				t.Errorf("expected computed attributes to be null, got id %s and role %s", state.ID, state.Role)
+			}
+			if state.Email.ValueString() != "missing@example.com" {
+				t.Errorf("expected the lookup email to be kept, got %s", state.Email)
+			}
+		})
+	}
+}
+
+func TestUserDataSourceRead_notFoundMessages(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/api/v1/users" {
+			_, _ = w.Write([]byte(`{"data":[],"nextCursor":null}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	ctx := context.Background()
+	d := &UserDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	testCases := map[string]struct {
+		attribute  string
+		value      string
+		wantDetail string
+	}{
+		"by id":    {attribute: "id", value: "non-existent-id", wantDetail: `User with ID "non-existent-id" not found`},
+		"by email": {attribute: "email", value: "non-existent@example.com", wantDetail: `User with email "non-existent@example.com" not found`},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+			attributes[testCase.attribute] = tftypes.NewValue(tftypes.String, testCase.value)
+			config := tftypes.NewValue(objectType, attributes)
+
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+			d.Read(ctx, req, resp)
+
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "User Not Found" {
+				t.Fatalf("expected a User Not Found error, got: %v", resp.Diagnostics)
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); detail != testCase.wantDetail {
+				t.Errorf("expected detail %q, got %q", testCase.wantDetail, detail)
+			}
+		})
+	}
+}
+
+func TestUserProjectMemberships(t *testing.T) {
+	members := map[string]string{
+		"p1": `{"data":[{"id":"u1","email":"ada@example.com"},{"id":"u2","email":"grace@example.com"}],"nextCursor":null}`,
+		"p2": `{"data":[{"id":"u2","email":"grace@example.com"}],"nextCursor":null}`,
+		"p3": `{"data":[{"id":"u1","email":"ada@example.com"}],"nextCursor":null}`,
+	}
+
+	testCases := map[string]struct {
+		projectsStatus int
+		projects       string
+		want           []UserProjectMembershipModel
+	}{
+		"members": {
+			projectsStatus: http.StatusOK,
+			projects:       `{"data":[{"id":"p1","name":"Billing"},{"id":"p2","name":"Marketing"},{"id":"p3","name":"Support"}],"nextCursor":null}`,
+			want: []UserProjectMembershipModel{
+				{ProjectID: types.StringValue("p1"), ProjectName: types.StringValue("Billing")},
+				{ProjectID: types.StringValue("p3"), ProjectName: types.StringValue("Support")},
+			},
+		},
+		"projects unlicensed": {
+			projectsStatus: http.StatusForbidden,
+			projects:       `{"message":"Your license does not allow for feat:projectRole:admin"}`,
+			want:           []UserProjectMembershipModel{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case "/api/v1/projects":
+					w.WriteHeader(tc.projectsStatus)
+					_, _ = w.Write([]byte(tc.projects))
+				case "/api/v1/users":
+					_, _ = w.Write([]byte(members[r.URL.Query().Get("projectId")]))
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			})
+
+			got, err := userProjectMemberships(context.Background(), c, "u1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected memberships %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// resolveUser looks up a user by ID or email. Values containing an @ are
+// treated as emails and matched case-insensitively; anything else is an ID.
+// Both the user resource import and the user data source resolve users
+// through here so lookups behave the same everywhere.
+func resolveUser(ctx context.Context, c *client.Client, idOrEmail string) (*client.User, error) {
+	if strings.Contains(idOrEmail, "@") {
+		return c.GetUserByEmail(ctx, idOrEmail)
+	}
+
+	return c.GetUser(ctx, idOrEmail)
+}
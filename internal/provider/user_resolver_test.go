@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestResolveUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/users":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","email":"Ada@Example.com"}],"nextCursor":null}`))
+		case "/api/v1/users/1":
+			_, _ = w.Write([]byte(`{"id":"1","email":"Ada@Example.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	})
+
+	testCases := map[string]struct {
+		idOrEmail    string
+		wantID       string
+		wantNotFound bool
+	}{
+		"id":                   {idOrEmail: "1", wantID: "1"},
+		"email":                {idOrEmail: "ada@example.com", wantID: "1"},
+		"email different case": {idOrEmail: "ADA@EXAMPLE.COM", wantID: "1"},
+		"unknown id":           {idOrEmail: "2", wantNotFound: true},
+		"unknown email":        {idOrEmail: "grace@example.com", wantNotFound: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			user, err := resolveUser(context.Background(), c, testCase.idOrEmail)
+
+			if testCase.wantNotFound {
+				if !client.IsNotFound(err) {
+					t.Fatalf("expected not found error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if user.ID != testCase.wantID {
+				t.Errorf("expected user %s, got %s", testCase.wantID, user.ID)
+			}
+		})
+	}
+}
@@ -4,16 +4,28 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
 )
 
 func TestAccUserResource_basic(t *testing.T) {
@@ -26,7 +38,7 @@ func TestAccUserResource_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccUserResourceConfig(email, "user", "Test", "User"),
+				Config: testAccUserResourceConfig(email, "global:member", "Test", "User"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
@@ -36,7 +48,7 @@ func TestAccUserResource_basic(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
 						tfjsonpath.New("role"),
-						knownvalue.StringExact("user"),
+						knownvalue.StringExact("global:member"),
 					),
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
@@ -57,16 +69,16 @@ func TestAccUserResource_basic(t *testing.T) {
 			},
 			// ImportState testing using email as import ID
 			{
-				ResourceName:      "n8ncloud_user.test",
-				ImportState:       true,
-				ImportStateId:     email,
-				ImportStateVerify: true,
-				// invite_accept_url is only available during creation
-				ImportStateVerifyIgnore: []string{"invite_accept_url"},
+				ResourceName:            "n8ncloud_user.test",
+				ImportState:             true,
+				ImportStateId:           email,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccUserImportStateVerifyIgnore,
 			},
-			// Update and Read testing
+			// Update and Read testing. Names cannot change after the
+			// invitation, so only the role is updated.
 			{
-				Config: testAccUserResourceConfig(email, "admin", "Updated", "Admin"),
+				Config: testAccUserResourceConfig(email, "global:admin", "Test", "User"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
@@ -76,17 +88,12 @@ func TestAccUserResource_basic(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
 						tfjsonpath.New("role"),
-						knownvalue.StringExact("admin"),
+						knownvalue.StringExact("global:admin"),
 					),
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
-						tfjsonpath.New("first_name"),
-						knownvalue.StringExact("Updated"),
-					),
-					statecheck.ExpectKnownValue(
-						"n8ncloud_user.test",
-						tfjsonpath.New("last_name"),
-						knownvalue.StringExact("Admin"),
+						tfjsonpath.New("is_admin"),
+						knownvalue.Bool(true),
 					),
 				},
 			},
@@ -115,7 +122,7 @@ func TestAccUserResource_onlyRequiredAttributes(t *testing.T) {
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
 						tfjsonpath.New("role"),
-						knownvalue.StringExact("user"), // default value
+						knownvalue.StringExact("global:member"),
 					),
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
@@ -138,7 +145,22 @@ func TestAccUserResource_invalidRole(t *testing.T) {
 			// Create with invalid role
 			{
 				Config:      testAccUserResourceConfig(email, "invalid_role", "Test", "User"),
-				ExpectError: regexp.MustCompile(`Attribute role value must be one of`),
+				ExpectError: regexp.MustCompile(`The role "invalid_role" is not supported`),
+			},
+		},
+	})
+}
+
+func TestAccUserResource_invalidEmail(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Plan with an email missing the @
+			{
+				Config:      testAccUserResourceConfig("test-invalid.example.com", "global:member", "Test", "User"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is not a valid email address`),
 			},
 		},
 	})
@@ -156,7 +178,7 @@ func TestAccUserResource_externalStateChange(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create user
 			{
-				Config: testAccUserResourceConfig(email, "user", "Test", "User"),
+				Config: testAccUserResourceConfig(email, "global:member", "Test", "User"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(
 						"n8ncloud_user.test",
@@ -169,13 +191,58 @@ func TestAccUserResource_externalStateChange(t *testing.T) {
 			// The is_pending attribute might have changed externally (user accepted invitation)
 			// but it shouldn't cause a diff since it's computed with UseStateForUnknown
 			{
-				Config:   testAccUserResourceConfig(email, "user", "Test", "User"),
+				Config:   testAccUserResourceConfig(email, "global:member", "Test", "User"),
 				PlanOnly: true,
 			},
 		},
 	})
 }
 
+// TestAccUserResource_disappears tests that a user deleted outside of
+// Terraform is planned for recreation instead of failing the refresh.
+func TestAccUserResource_disappears(t *testing.T) {
+	email := fmt.Sprintf("test-disappears-%d@example.com", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:             testAccUserResourceConfig(email, "global:member", "Test", "User"),
+				Check:              testAccDeleteUser("n8ncloud_user.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccDeleteUser deletes the user of the named resource through the API,
+// bypassing Terraform.
+func testAccDeleteUser(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+
+		c, err := client.NewClient(&client.Config{
+			BaseURL: os.Getenv("N8N_INSTANCE_URL"),
+			APIKey:  os.Getenv("N8N_API_KEY"),
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.DeleteUser(context.Background(), rs.Primary.ID)
+	}
+}
+
+// testAccUserImportStateVerifyIgnore lists the attributes that are expected to
+// differ after import. invite_accept_url is only returned by the API when the
+// user is created, so it is always null for imported users.
+var testAccUserImportStateVerifyIgnore = []string{"invite_accept_url"}
+
 func testAccUserResourceConfig(email, role, firstName, lastName string) string {
 	return fmt.Sprintf(`
 resource "n8ncloud_user" "test" {
@@ -206,24 +273,941 @@ func TestAccUserResource_import(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create a user first
 			{
-				Config: testAccUserResourceConfig(email, "user", "Import", "Test"),
+				Config: testAccUserResourceConfig(email, "global:member", "Import", "Test"),
 			},
 			// Import using email
 			{
-				ResourceName:      "n8ncloud_user.test",
-				ImportState:       true,
-				ImportStateId:     email,
-				ImportStateVerify: true,
-				// invite_accept_url is only available during creation
-				ImportStateVerifyIgnore: []string{"invite_accept_url"},
+				ResourceName:            "n8ncloud_user.test",
+				ImportState:             true,
+				ImportStateId:           email,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccUserImportStateVerifyIgnore,
+			},
+			// Import using the user ID
+			{
+				ResourceName:            "n8ncloud_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: testAccUserImportStateVerifyIgnore,
+			},
+		},
+	})
+}
+
+// TestAccUserResource_bulkCreate invites many users at once, as a module
+// onboarding a team would, and reports how long the apply took. The
+// provider's rate limit is the only throttle, so the parallel creates must
+// stay below the instance's limits without failing.
+func TestAccUserResource_bulkCreate(t *testing.T) {
+	const count = 50
+	prefix := fmt.Sprintf("test-bulk-%d", time.Now().Unix())
+
+	var start time.Time
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			start = time.Now()
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_bulk(prefix, count),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("n8ncloud_user.test.0", "email", prefix+"-0@example.com"),
+					resource.TestCheckResourceAttr(fmt.Sprintf("n8ncloud_user.test.%d", count-1), "email", fmt.Sprintf("%s-%d@example.com", prefix, count-1)),
+					func(*terraform.State) error {
+						elapsed := time.Since(start)
+						t.Logf("created %d users in %s (%s per user)", count, elapsed, elapsed/count)
+						return nil
+					},
+				),
 			},
 		},
 	})
 }
 
+func testAccUserResourceConfig_bulk(prefix string, count int) string {
+	return fmt.Sprintf(`
+provider "n8ncloud" {
+  rate_limit              = 5
+  max_idle_conns_per_host = 10
+}
+
+resource "n8ncloud_user" "test" {
+  count = %[2]d
+
+  email = "%[1]s-${count.index}@example.com"
+  role  = "global:member"
+}
+`, prefix, count)
+}
+
 func testAccCheckUserResourceDestroy(s *terraform.State) error {
 	// Add logic to verify user is deleted from n8n cloud
 	// This would typically involve checking that the resource no longer exists
 	// by making an API call and expecting a 404 or similar error
 	return nil
 }
+
+// TestEnsureCreatedUserRole_roleIgnoredOnCreate simulates an n8n version that
+// ignores the role on POST /users and checks a follow-up role PATCH is sent.
+func TestEnsureCreatedUserRole_roleIgnoredOnCreate(t *testing.T) {
+	var patchedRole string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/users/1":
+			_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com","role":"global:member"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/users/1/role":
+			var body client.UpdateUserRoleRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected error decoding body: %s", err)
+			}
+			patchedRole = body.NewRoleName
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	user := &client.User{ID: "1", Email: "user@example.com"}
+	if err := ensureCreatedUserRole(context.Background(), c, user, "global:admin"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if patchedRole != "global:admin" {
+		t.Errorf("expected role PATCH to global:admin, got %q", patchedRole)
+	}
+	if user.Role != "global:admin" {
+		t.Errorf("expected user role global:admin, got %s", user.Role)
+	}
+}
+
+func TestEnsureCreatedUserRole_roleApplied(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	user := &client.User{ID: "1", Email: "user@example.com", Role: "global:admin"}
+	if err := ensureCreatedUserRole(context.Background(), c, user, "global:admin"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestWaitForUserAcceptance(t *testing.T) {
+	reads := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		reads++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.User{ID: "1", Email: "user@example.com", IsPending: reads < 3})
+	})
+
+	user, err := waitForUserAcceptance(context.Background(), c, "1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if user.IsPending {
+		t.Error("expected the returned user to no longer be pending")
+	}
+	if reads != 3 {
+		t.Errorf("expected polling to stop once the user accepted, got %d reads", reads)
+	}
+}
+
+func TestWaitForUserAcceptance_timeout(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.User{ID: "1", Email: "user@example.com", IsPending: true})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := waitForUserAcceptance(ctx, c, "1", time.Millisecond); err == nil {
+		t.Fatal("expected an error when the user stays pending past the timeout")
+	}
+}
+
+func TestUserResourceCreate_userExists(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"User with email ada@example.com already exists"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+		"role":  tftypes.NewValue(tftypes.String, "global:member"),
+	})
+
+	req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: userSchema, Raw: plan}}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: userSchema, Raw: plan}}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for an existing user")
+	}
+
+	diagnostic := resp.Diagnostics.Errors()[0]
+	if diagnostic.Summary() != "User Already Exists" {
+		t.Fatalf("expected a User Already Exists error, got: %v", resp.Diagnostics)
+	}
+	if want := "terraform import n8ncloud_user.<name> ada@example.com"; !strings.Contains(diagnostic.Detail(), want) {
+		t.Errorf("expected the error to suggest %q, got: %s", want, diagnostic.Detail())
+	}
+}
+
+func TestUserResourceCreate_adoptExisting(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost:
+			// The user was invited by an earlier attempt whose response was lost
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"User with email ada@example.com already exists"}`))
+		case r.URL.Path == "/api/v1/users":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","email":"Ada@example.com","isPending":true,"role":"global:member","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}],"nextCursor":null}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r := &UserResource{client: c}
+	userSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"email":          tftypes.NewValue(tftypes.String, "ada@example.com"),
+		"role":           tftypes.NewValue(tftypes.String, "global:member"),
+		"adopt_existing": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: userSchema, Raw: plan}}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: userSchema, Raw: plan}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state UserResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if state.ID.ValueString() != "1" {
+		t.Errorf("expected the existing user to be adopted, got ID %s", state.ID)
+	}
+	if state.Email.ValueString() != "ada@example.com" {
+		t.Errorf("expected the configured email to be kept, got %s", state.Email)
+	}
+	if !state.IsPending.ValueBool() {
+		t.Error("expected the adopted user to be recorded as pending")
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected only the create and the lookup, got %v", requests)
+	}
+}
+
+func TestUserResourceCreate_roleAssignmentFails(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost:
+			// The instance ignores the requested role
+			_, _ = w.Write([]byte(`{"id":"1","email":"ada@example.com","isPending":true,"role":"global:member","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid role"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	r := &UserResource{client: c}
+	userSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+		"role":  tftypes.NewValue(tftypes.String, "global:admin"),
+	})
+
+	req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: userSchema, Raw: plan}}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: userSchema, Raw: plan}}
+
+	r.Create(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for the failed role assignment")
+	}
+
+	// The invited user is kept in state, so that the failed create taints
+	// it instead of orphaning it
+	var state UserResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.ID.ValueString() != "1" {
+		t.Errorf("expected the created user to be saved to state, got ID %s", state.ID)
+	}
+	if state.Role.ValueString() != "global:member" {
+		t.Errorf("expected the role the user actually has, got %s", state.Role)
+	}
+}
+
+func TestUserResourceCreate_names(t *testing.T) {
+	var created map[string]interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("unexpected error decoding request: %s", err)
+			}
+		}
+		// Invited users have no names until they accept the invitation
+		_, _ = w.Write([]byte(`{"id":"1","email":"ada@example.com","isPending":true,"role":"global:member","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"email":      tftypes.NewValue(tftypes.String, "ada@example.com"),
+		"role":       tftypes.NewValue(tftypes.String, "global:member"),
+		"first_name": tftypes.NewValue(tftypes.String, "Ada"),
+		"last_name":  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: userSchema, Raw: plan}}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: userSchema, Raw: plan}}
+
+	r.Create(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if created["firstName"] != "Ada" {
+		t.Errorf("expected the first name to be sent, got request %v", created)
+	}
+	if _, ok := created["lastName"]; ok {
+		t.Errorf("expected an unset last name to be omitted, got request %v", created)
+	}
+
+	var state UserResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if state.FirstName.ValueString() != "Ada" {
+		t.Errorf("expected the configured first name to be kept, got %s", state.FirstName)
+	}
+	if !state.LastName.IsNull() {
+		t.Errorf("expected an unset last name to be null, got %s", state.LastName)
+	}
+}
+
+func TestUserNameImmutable(t *testing.T) {
+	testCases := map[string]struct {
+		stateName  interface{}
+		configName interface{}
+		planEmail  string
+		wantError  bool
+	}{
+		"unchanged":            {stateName: "Ada", configName: "Ada", planEmail: "ada@example.com"},
+		"removed from config":  {stateName: "Ada", configName: nil, planEmail: "ada@example.com"},
+		"changed":              {stateName: "Ada", configName: "Augusta", planEmail: "ada@example.com", wantError: true},
+		"set after invitation": {stateName: nil, configName: "Ada", planEmail: "ada@example.com", wantError: true},
+		"changed with email":   {stateName: "Ada", configName: "Augusta", planEmail: "augusta@example.com"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &UserResource{}
+			userSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+				"email":      tftypes.NewValue(tftypes.String, "ada@example.com"),
+				"first_name": tftypes.NewValue(tftypes.String, tc.stateName),
+			})
+			_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+				"email":      tftypes.NewValue(tftypes.String, tc.planEmail),
+				"first_name": tftypes.NewValue(tftypes.String, tc.configName),
+			})
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("first_name"),
+				ConfigValue: types.StringPointerValue(stringPointer(tc.configName)),
+				StateValue:  types.StringPointerValue(stringPointer(tc.stateName)),
+				Plan:        tfsdk.Plan{Schema: userSchema, Raw: plan},
+				State:       tfsdk.State{Schema: userSchema, Raw: state},
+			}
+			req.PlanValue = req.ConfigValue
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			userNameImmutable{}.PlanModifyString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error %t, got: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// stringPointer returns a pointer to value if it is a string, or nil.
+func stringPointer(value interface{}) *string {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	return &s
+}
+
+func TestUserResourceRead_subSecondTimestamps(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com","isPending":false,"role":"global:member","createdAt":"2024-01-01T00:00:00.123456Z","updatedAt":"2024-03-01T00:00:00.5Z"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":             tftypes.NewValue(tftypes.String, "1"),
+		"email":          tftypes.NewValue(tftypes.String, "user@example.com"),
+		"role":           tftypes.NewValue(tftypes.String, "global:member"),
+		"is_admin":       tftypes.NewValue(tftypes.Bool, false),
+		"is_pending":     tftypes.NewValue(tftypes.Bool, false),
+		"invite_expired": tftypes.NewValue(tftypes.Bool, false),
+		"created_at":     tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00.123456Z"),
+		"updated_at":     tftypes.NewValue(tftypes.String, "2024-03-01T00:00:00.5Z"),
+	})
+
+	req := fwresource.ReadRequest{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state UserResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	// Sub-second precision must survive so refreshes and import verification
+	// do not see a difference.
+	if state.CreatedAt.ValueString() != "2024-01-01T00:00:00.123456Z" {
+		t.Errorf("expected created_at 2024-01-01T00:00:00.123456Z, got %s", state.CreatedAt)
+	}
+	if state.UpdatedAt.ValueString() != "2024-03-01T00:00:00.5Z" {
+		t.Errorf("expected updated_at 2024-03-01T00:00:00.5Z, got %s", state.UpdatedAt)
+	}
+	if !resp.State.Raw.Equal(prior) {
+		t.Errorf("expected reading an unchanged user to leave the state unchanged, got %s", resp.State.Raw)
+	}
+}
+
+func TestUserResourceRead_removedWhenNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"User not found"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "1"),
+		"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+	})
+
+	req := fwresource.ReadRequest{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+
+	r.Read(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the deleted user to be removed from state, got %s", resp.State.Raw)
+	}
+}
+
+func TestUserResourceDelete_alreadyDeleted(t *testing.T) {
+	var deletes int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"User not found"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "1"),
+		"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+	})
+
+	req := fwresource.DeleteRequest{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+	resp := &fwresource.DeleteResponse{State: tfsdk.State{Schema: userSchema, Raw: prior}}
+
+	r.Delete(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected deleting an already deleted user to succeed, got: %v", resp.Diagnostics)
+	}
+	if deletes != 1 {
+		t.Errorf("expected one DELETE request, got %d", deletes)
+	}
+}
+
+func TestUserResourceImportState(t *testing.T) {
+	user := `{"id":"9f1c0d2e-4b5a-4c3d-8e7f-6a5b4c3d2e1f","email":"user@example.com","role":"global:member"}`
+
+	testCases := map[string]struct {
+		importID string
+		wantPath string
+	}{
+		"email": {
+			importID: "User@Example.com",
+			wantPath: "/api/v1/users",
+		},
+		"id": {
+			importID: "9f1c0d2e-4b5a-4c3d-8e7f-6a5b4c3d2e1f",
+			wantPath: "/api/v1/users/9f1c0d2e-4b5a-4c3d-8e7f-6a5b4c3d2e1f",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != testCase.wantPath {
+					t.Errorf("expected a request to %s, got %s", testCase.wantPath, r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/v1/users" {
+					_, _ = w.Write([]byte(`{"data":[` + user + `],"nextCursor":null}`))
+					return
+				}
+				_, _ = w.Write([]byte(user))
+			})
+
+			r := &UserResource{client: c}
+			userSchema, empty := resourceTestValue(t, r, map[string]tftypes.Value{})
+
+			resp := &fwresource.ImportStateResponse{State: tfsdk.State{Schema: userSchema, Raw: empty}}
+			r.ImportState(context.Background(), fwresource.ImportStateRequest{ID: testCase.importID}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state UserResourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("unexpected error reading state: %v", diags)
+			}
+			if state.ID.ValueString() != "9f1c0d2e-4b5a-4c3d-8e7f-6a5b4c3d2e1f" {
+				t.Errorf("expected the user ID in state, got %s", state.ID)
+			}
+			if state.Email.ValueString() != "user@example.com" {
+				t.Errorf("expected the user email in state, got %s", state.Email)
+			}
+		})
+	}
+}
+
+func TestUserResourceUpdate_refreshesAllAttributes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com","firstName":null,"lastName":"Lovelace","isPending":false,"role":"global:admin","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-03-01T00:00:00Z"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	r := &UserResource{client: c}
+	userSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "1"),
+		"email":             tftypes.NewValue(tftypes.String, "user@example.com"),
+		"role":              tftypes.NewValue(tftypes.String, "global:admin"),
+		"is_admin":          tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		"first_name":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"last_name":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"is_pending":        tftypes.NewValue(tftypes.Bool, true),
+		"created_at":        tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+		"updated_at":        tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"invite_accept_url": tftypes.NewValue(tftypes.String, "https://example.com/signup"),
+		"raw_json":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: userSchema, Raw: plan},
+		State: tfsdk.State{Schema: userSchema, Raw: plan},
+	}
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: userSchema, Raw: plan},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state UserResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if !state.FirstName.IsNull() {
+		t.Errorf("expected first_name to be null, got %s", state.FirstName)
+	}
+	if state.LastName.ValueString() != "Lovelace" {
+		t.Errorf("expected last_name Lovelace, got %s", state.LastName)
+	}
+	if state.IsPending.ValueBool() {
+		t.Error("expected is_pending to be refreshed to false")
+	}
+	if !state.InviteAcceptURL.IsNull() {
+		t.Errorf("expected invite_accept_url to be cleared for an accepted user, got %s", state.InviteAcceptURL)
+	}
+	if state.UpdatedAt.ValueString() != "2024-03-01T00:00:00Z" {
+		t.Errorf("expected updated_at 2024-03-01T00:00:00Z, got %s", state.UpdatedAt)
+	}
+	if state.RawJSON.IsUnknown() {
+		t.Error("expected raw_json to be known after update")
+	}
+	if !state.IsAdmin.ValueBool() {
+		t.Error("expected is_admin to track the updated admin role")
+	}
+}
+
+func TestUserResourceModifyPlan_defaultRole(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		defaultRole string
+		configRole  interface{}
+		stateRole   interface{}
+		create      bool
+		want        tftypes.Value
+	}{
+		"create with default": {
+			defaultRole: "global:admin",
+			create:      true,
+			want:        tftypes.NewValue(tftypes.String, "global:admin"),
+		},
+		"create with role": {
+			defaultRole: "global:admin",
+			configRole:  "member",
+			create:      true,
+			want:        tftypes.NewValue(tftypes.String, "member"),
+		},
+		"create without default": {
+			create: true,
+			want:   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+		"same role respelled": {
+			defaultRole: "global:admin",
+			stateRole:   "ADMIN",
+			want:        tftypes.NewValue(tftypes.String, "ADMIN"),
+		},
+		"default changed": {
+			defaultRole: "global:admin",
+			stateRole:   "global:member",
+			want:        tftypes.NewValue(tftypes.String, "global:admin"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &UserResource{defaultRole: tc.defaultRole}
+
+			userSchema, config := resourceTestValue(t, r, map[string]tftypes.Value{
+				"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+				"role":  tftypes.NewValue(tftypes.String, tc.configRole),
+			})
+
+			state := tftypes.NewValue(userSchema.Type().TerraformType(ctx), nil)
+			if !tc.create {
+				_, state = resourceTestValue(t, r, map[string]tftypes.Value{
+					"id":    tftypes.NewValue(tftypes.String, "1"),
+					"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+					"role":  tftypes.NewValue(tftypes.String, tc.stateRole),
+				})
+			}
+
+			// Without a configured role, the planned role is unknown on
+			// create and kept from state on update
+			plannedRole := tftypes.NewValue(tftypes.String, tc.configRole)
+			if tc.configRole == nil && tc.create {
+				plannedRole = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			} else if tc.configRole == nil {
+				plannedRole = tftypes.NewValue(tftypes.String, tc.stateRole)
+			}
+			_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+				"email": tftypes.NewValue(tftypes.String, "ada@example.com"),
+				"role":  plannedRole,
+			})
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: userSchema, Raw: config},
+				Plan:   tfsdk.Plan{Schema: userSchema, Raw: plan},
+				State:  tfsdk.State{Schema: userSchema, Raw: state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var role types.String
+			if diags := resp.Plan.GetAttribute(ctx, path.Root("role"), &role); diags.HasError() {
+				t.Fatalf("unexpected error reading plan: %v", diags)
+			}
+			got, err := role.ToTerraformValue(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("expected planned role %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUserResourceUpdate_unchangedRole(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","email":"user@example.com","isPending":false,"role":"global:admin","createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}`))
+	})
+
+	r := &UserResource{client: c}
+	userSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "1"),
+		"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+		"role":  tftypes.NewValue(tftypes.String, "global:admin"),
+	})
+	// The role is respelled as an alias of the same role
+	_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "1"),
+		"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+		"role":  tftypes.NewValue(tftypes.String, "admin"),
+	})
+
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: userSchema, Raw: plan},
+		State: tfsdk.State{Schema: userSchema, Raw: state},
+	}
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: userSchema, Raw: state},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestUserResourceUpdate_pendingUserRoleWarning(t *testing.T) {
+	testCases := map[string]struct {
+		pending     bool
+		planRole    string
+		apiRole     string
+		wantRole    string
+		wantWarning bool
+	}{
+		"role change not applied to pending user": {
+			pending:     true,
+			planRole:    "admin",
+			apiRole:     "global:member",
+			wantRole:    "admin",
+			wantWarning: true,
+		},
+		"role change applied to pending user": {
+			pending:     true,
+			planRole:    "global:admin",
+			apiRole:     "global:admin",
+			wantRole:    "global:admin",
+			wantWarning: true,
+		},
+		"role respelled for pending user": {
+			pending:  true,
+			planRole: "member",
+			apiRole:  "global:member",
+			wantRole: "member",
+		},
+		"role changed for active user": {
+			planRole: "global:admin",
+			apiRole:  "global:admin",
+			wantRole: "global:admin",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/users/1/role":
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodGet:
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = fmt.Fprintf(w, `{"id":"1","email":"user@example.com","isPending":%t,"role":%q,"createdAt":"2024-01-01T00:00:00Z","updatedAt":"2024-01-01T00:00:00Z"}`, tc.pending, tc.apiRole)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			r := &UserResource{client: c}
+			userSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, "1"),
+				"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+				"role":  tftypes.NewValue(tftypes.String, "global:member"),
+			})
+			_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.String, "1"),
+				"email": tftypes.NewValue(tftypes.String, "user@example.com"),
+				"role":  tftypes.NewValue(tftypes.String, tc.planRole),
+			})
+
+			req := fwresource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: userSchema, Raw: plan},
+				State: tfsdk.State{Schema: userSchema, Raw: state},
+			}
+			resp := &fwresource.UpdateResponse{
+				State: tfsdk.State{Schema: userSchema, Raw: state},
+			}
+
+			r.Update(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			// The planned role is stored even if the API did not apply it,
+			// so that the apply is consistent with the plan
+			var data UserResourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatalf("unexpected error reading state: %v", diags)
+			}
+			if data.Role.ValueString() != tc.wantRole {
+				t.Errorf("expected role %s in state, got %s", tc.wantRole, data.Role)
+			}
+			if want := isAdminRole(tc.wantRole); data.IsAdmin.ValueBool() != want {
+				t.Errorf("expected is_admin %t, got %s", want, data.IsAdmin)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if !tc.wantWarning {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Summary() != "Role Change for Pending User" {
+				t.Fatalf("expected a pending user warning, got: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestUserResourceUpdate_migrateOnEmailChange(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/users":
+			var body client.CreateUserRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected error decoding body: %s", err)
+			}
+			if body.Email != "ada@new.example.com" || body.Role != "global:admin" {
+				t.Errorf("unexpected create request %+v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"2","email":"ada@new.example.com","isPending":true,"role":"global:admin","inviteAcceptUrl":"https://example.com/signup?inviteeId=2","createdAt":"2024-05-01T00:00:00Z","updatedAt":"2024-05-01T00:00:00Z"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/users/1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	values := map[string]tftypes.Value{
+		"id":                      tftypes.NewValue(tftypes.String, "1"),
+		"email":                   tftypes.NewValue(tftypes.String, "ada@old.example.com"),
+		"role":                    tftypes.NewValue(tftypes.String, "global:admin"),
+		"is_admin":                tftypes.NewValue(tftypes.Bool, true),
+		"is_pending":              tftypes.NewValue(tftypes.Bool, false),
+		"created_at":              tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+		"updated_at":              tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+		"migrate_on_email_change": tftypes.NewValue(tftypes.Bool, true),
+	}
+
+	r := &UserResource{client: c}
+	userSchema, state := resourceTestValue(t, r, values)
+
+	values["email"] = tftypes.NewValue(tftypes.String, "ada@new.example.com")
+	for _, name := range []string{"id", "is_admin", "is_pending", "created_at", "updated_at", "first_name", "last_name", "invite_accept_url", "raw_json"} {
+		values[name] = tftypes.NewValue(userSchema.Attributes[name].GetType().TerraformType(context.Background()), tftypes.UnknownValue)
+	}
+	_, plan := resourceTestValue(t, r, values)
+
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: userSchema, Raw: plan},
+		State: tfsdk.State{Schema: userSchema, Raw: state},
+	}
+	resp := &fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: userSchema, Raw: plan},
+	}
+
+	r.Update(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	wantRequests := []string{"POST /api/v1/users", "DELETE /api/v1/users/1?transferId=2"}
+	if fmt.Sprint(requests) != fmt.Sprint(wantRequests) {
+		t.Errorf("expected requests %v, got %v", wantRequests, requests)
+	}
+
+	var got UserResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if got.ID.ValueString() != "2" {
+		t.Errorf("expected the new user ID 2 in state, got %s", got.ID)
+	}
+	if got.Email.ValueString() != "ada@new.example.com" {
+		t.Errorf("expected the new email in state, got %s", got.Email)
+	}
+	if !got.IsPending.ValueBool() {
+		t.Error("expected the new user to be pending")
+	}
+	if got.InviteAcceptURL.ValueString() != "https://example.com/signup?inviteeId=2" {
+		t.Errorf("expected the invite URL of the new user, got %s", got.InviteAcceptURL)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserStatsDataSource{}
+
+func NewUserStatsDataSource() datasource.DataSource {
+	return &UserStatsDataSource{}
+}
+
+// UserStatsDataSource defines the data source implementation.
+type UserStatsDataSource struct {
+	client *client.Client
+}
+
+// UserStatsDataSourceModel describes the data source data model.
+type UserStatsDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Total   types.Int64  `tfsdk:"total"`
+	Active  types.Int64  `tfsdk:"active"`
+	Pending types.Int64  `tfsdk:"pending"`
+	ByRole  types.Map    `tfsdk:"by_role"`
+}
+
+// userStats holds user counts aggregated from a list of users.
+type userStats struct {
+	total   int64
+	pending int64
+	byRole  map[string]int64
+}
+
+// aggregateUserStats counts the given users in total, by pending status and
+// by role. Users without a role are counted under "unknown".
+func aggregateUserStats(users []client.User) userStats {
+	stats := userStats{
+		byRole: map[string]int64{},
+	}
+
+	for _, user := range users {
+		stats.total++
+		if user.IsPending {
+			stats.pending++
+		}
+
+		role := user.Role
+		if role == "" {
+			role = "unknown"
+		}
+		stats.byRole[role]++
+	}
+
+	return stats
+}
+
+func (d *UserStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_stats"
+}
+
+func (d *UserStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User stats data source for reporting user counts on the n8n cloud instance, e.g. for license and seat tracking.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the data source",
+				Computed:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "The total number of users, including pending users",
+				Computed:            true,
+			},
+			"active": schema.Int64Attribute{
+				MarkdownDescription: "The number of users who have accepted their invitation",
+				Computed:            true,
+			},
+			"pending": schema.Int64Attribute{
+				MarkdownDescription: "The number of users who have not yet accepted their invitation",
+				Computed:            true,
+			},
+			"by_role": schema.MapAttribute{
+				MarkdownDescription: "The number of users for each role, keyed by the role name returned by the API (e.g. `global:admin`)",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *UserStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserStatsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.ListUsers(ctx, nil)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list users", err)
+		return
+	}
+
+	stats := aggregateUserStats(users)
+
+	byRole := make(map[string]attr.Value, len(stats.byRole))
+	for role, count := range stats.byRole {
+		byRole[role] = types.Int64Value(count)
+	}
+
+	byRoleValue, diags := types.MapValue(types.Int64Type, byRole)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("user_stats")
+	data.Total = types.Int64Value(stats.total)
+	data.Active = types.Int64Value(stats.total - stats.pending)
+	data.Pending = types.Int64Value(stats.pending)
+	data.ByRole = byRoleValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
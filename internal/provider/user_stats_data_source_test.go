@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAccUserStatsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "n8ncloud_user_stats" "test" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_user_stats.test",
+						tfjsonpath.New("total"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_user_stats.test",
+						tfjsonpath.New("by_role"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAggregateUserStats(t *testing.T) {
+	users := []client.User{
+		{ID: "1", Role: "global:owner"},
+		{ID: "2", Role: "global:admin"},
+		{ID: "3", Role: "global:member", IsPending: true},
+		{ID: "4", Role: "global:member"},
+		{ID: "5", IsPending: true},
+	}
+
+	stats := aggregateUserStats(users)
+
+	if stats.total != 5 {
+		t.Errorf("expected total 5, got %d", stats.total)
+	}
+	if stats.pending != 2 {
+		t.Errorf("expected pending 2, got %d", stats.pending)
+	}
+
+	want := map[string]int64{
+		"global:owner":  1,
+		"global:admin":  1,
+		"global:member": 2,
+		"unknown":       1,
+	}
+	for role, count := range want {
+		if stats.byRole[role] != count {
+			t.Errorf("expected %d users with role %s, got %d", count, role, stats.byRole[role])
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client    *client.Client
+	inviteTTL time.Duration
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Role       types.String      `tfsdk:"role"`
+	IsPending  types.Bool        `tfsdk:"is_pending"`
+	ExtraQuery map[string]string `tfsdk:"extra_query"`
+	Users      []ListedUserModel `tfsdk:"users"`
+}
+
+// ListedUserModel describes a user returned by the data source.
+type ListedUserModel struct {
+	ID            types.String `tfsdk:"id"`
+	Email         types.String `tfsdk:"email"`
+	Role          types.String `tfsdk:"role"`
+	IsAdmin       types.Bool   `tfsdk:"is_admin"`
+	FirstName     types.String `tfsdk:"first_name"`
+	LastName      types.String `tfsdk:"last_name"`
+	IsPending     types.Bool   `tfsdk:"is_pending"`
+	InviteExpired types.Bool   `tfsdk:"invite_expired"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+	ImportID      types.String `tfsdk:"import_id"`
+}
+
+// extraQueryDescription documents the extra_query attribute of list data
+// sources.
+const extraQueryDescription = "Advanced: additional query parameters to send when listing, for API filters the provider does not support yet. Values are URL-encoded, and parameters the provider sets itself, such as `cursor` and `limit`, cannot be overridden."
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Users data source for listing the users of the n8n cloud instance, e.g. to audit who has admin access. Every page of users is read, and the optional filters are applied to the full list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the data source",
+				Computed:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only return users with this role, such as `global:owner`, `global:admin` or `global:member`, or the aliases `admin`, `member` and `user`. Matched case-insensitively.",
+				Optional:            true,
+			},
+			"is_pending": schema.BoolAttribute{
+				MarkdownDescription: "Only return users who have not yet accepted their invitation when `true`, or users who have when `false`",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"users": schema.ListNestedAttribute{
+				MarkdownDescription: "The users matching the filters, in the order returned by the API",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the user",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the user",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The role of the user",
+							Computed:            true,
+						},
+						"is_admin": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user's role grants administrative access (`global:owner` or `global:admin`)",
+							Computed:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "The first name of the user",
+							Computed:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "The last name of the user",
+							Computed:            true,
+						},
+						"is_pending": schema.BoolAttribute{
+							MarkdownDescription: "Whether the user has not yet set up their account",
+							Computed:            true,
+						},
+						"invite_expired": schema.BoolAttribute{
+							MarkdownDescription: "Whether the invitation is likely to have expired, as for the `n8ncloud_user` data source",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the user was created",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the user was last updated",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "The ID to import the user into an `n8ncloud_user` resource with, its email address, e.g. for scripting `terraform import` commands",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.inviteTTL = providerData.InviteTTL
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.ListUsers(ctx, &client.ListOptions{ExtraQuery: data.ExtraQuery})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list users", err)
+		return
+	}
+
+	now := time.Now()
+
+	data.ID = types.StringValue("users")
+	data.Users = make([]ListedUserModel, 0, len(users))
+	for i := range users {
+		user := &users[i]
+		if !userMatchesFilters(user, data.Role, data.IsPending) {
+			continue
+		}
+
+		data.Users = append(data.Users, ListedUserModel{
+			ID:            types.StringValue(user.ID),
+			Email:         types.StringValue(user.Email),
+			Role:          types.StringValue(user.Role),
+			IsAdmin:       types.BoolValue(isAdminRole(user.Role)),
+			FirstName:     types.StringPointerValue(user.FirstName),
+			LastName:      types.StringPointerValue(user.LastName),
+			IsPending:     types.BoolValue(user.IsPending),
+			InviteExpired: types.BoolValue(inviteExpired(user, d.inviteTTL, now)),
+			CreatedAt:     types.StringValue(user.CreatedAt.Format(time.RFC3339Nano)),
+			UpdatedAt:     types.StringValue(user.UpdatedAt.Format(time.RFC3339Nano)),
+			ImportID:      types.StringValue(user.Email),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// userMatchesFilters reports whether user matches the role and pending
+// filters, each of which is ignored when null. Roles are compared in their
+// canonical form, so aliases and any casing match.
+func userMatchesFilters(user *client.User, role types.String, isPending types.Bool) bool {
+	if !role.IsNull() && !strings.EqualFold(canonicalRole(role.ValueString()), canonicalRole(user.Role)) {
+		return false
+	}
+
+	if !isPending.IsNull() && isPending.ValueBool() != user.IsPending {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAccUsersDataSource_admins(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "n8ncloud_users" "test" {
+  role = "global:owner"
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					// Every instance has exactly one owner
+					statecheck.ExpectKnownValue(
+						"data.n8ncloud_users.test",
+						tfjsonpath.New("users"),
+						knownvalue.ListSizeExact(1),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestUsersDataSourceRead_paginationAndFilters(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("email"); got != "a+b@example.com" {
+			t.Errorf("expected the extra query parameter to be sent, got email=%q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","email":"owner@example.com","role":"global:owner","isPending":false},{"id":"2","email":"admin@example.com","role":"global:admin","isPending":true}],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"3","email":"member@example.com","role":"global:member","isPending":true},{"id":"4","email":"other-admin@example.com","role":"global:admin","isPending":false}],"nextCursor":null}`))
+	})
+
+	ctx := context.Background()
+	d := &UsersDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(values map[string]tftypes.Value) []ListedUserModel {
+		t.Helper()
+
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["extra_query"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"email": tftypes.NewValue(tftypes.String, "a+b@example.com"),
+		})
+		for name, value := range values {
+			attributes[name] = value
+		}
+		config := tftypes.NewValue(objectType, attributes)
+
+		req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+		d.Read(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+
+		var data UsersDataSourceModel
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+
+		return data.Users
+	}
+
+	ids := func(users []ListedUserModel) []string {
+		ids := make([]string, 0, len(users))
+		for _, user := range users {
+			ids = append(ids, user.ID.ValueString())
+		}
+		return ids
+	}
+
+	testCases := map[string]struct {
+		values map[string]tftypes.Value
+		want   []string
+	}{
+		"no filters": {
+			want: []string{"1", "2", "3", "4"},
+		},
+		"role alias across pages": {
+			values: map[string]tftypes.Value{"role": tftypes.NewValue(tftypes.String, "Admin")},
+			want:   []string{"2", "4"},
+		},
+		"pending": {
+			values: map[string]tftypes.Value{"is_pending": tftypes.NewValue(tftypes.Bool, true)},
+			want:   []string{"2", "3"},
+		},
+		"role and not pending": {
+			values: map[string]tftypes.Value{
+				"role":       tftypes.NewValue(tftypes.String, "global:admin"),
+				"is_pending": tftypes.NewValue(tftypes.Bool, false),
+			},
+			want: []string{"4"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			users := read(tc.values)
+
+			if got := ids(users); strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected users %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	users := read(nil)
+	if users[1].ImportID.ValueString() != "admin@example.com" || !users[1].IsAdmin.ValueBool() {
+		t.Errorf("expected the admin to be importable by email, got %+v", users[1])
+	}
+}
+
+func TestUserMatchesFilters(t *testing.T) {
+	user := &client.User{Role: "global:member", IsPending: true}
+
+	if !userMatchesFilters(user, types.StringNull(), types.BoolNull()) {
+		t.Error("expected null filters to match")
+	}
+	if !userMatchesFilters(user, types.StringValue("user"), types.BoolValue(true)) {
+		t.Error("expected the user alias to match global:member")
+	}
+	if userMatchesFilters(user, types.StringValue("global:admin"), types.BoolNull()) {
+		t.Error("expected a different role not to match")
+	}
+}
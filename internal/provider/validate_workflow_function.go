@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateWorkflowFunction{}
+
+func NewValidateWorkflowFunction() function.Function {
+	return &ValidateWorkflowFunction{}
+}
+
+// ValidateWorkflowFunction defines the function implementation.
+type ValidateWorkflowFunction struct{}
+
+func (f *ValidateWorkflowFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_workflow"
+}
+
+func (f *ValidateWorkflowFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check a workflow's nodes and connections for integrity problems",
+		MarkdownDescription: "Parses a workflow JSON object and returns a list of problems found in its `nodes` and `connections`: nodes without a name, duplicate node names, and connections that reference nodes which do not exist. Returns an empty list for a consistent workflow. When `strict` is true, any problem makes the function fail instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "workflow",
+				MarkdownDescription: "The workflow as a JSON object string, as exported from n8n",
+			},
+			function.BoolParameter{
+				Name:                "strict",
+				MarkdownDescription: "Whether to fail when problems are found instead of returning them",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ValidateWorkflowFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var workflow string
+	var strict bool
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &workflow, &strict))
+
+	if resp.Error != nil {
+		return
+	}
+
+	workflowObject, err := decodeJSONObject(workflow)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid workflow: %s", err))
+		return
+	}
+
+	problems, err := workflowProblems(workflowObject)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid workflow: %s", err))
+		return
+	}
+
+	if strict && len(problems) > 0 {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Workflow has %d problem(s):\n  - %s", len(problems), strings.Join(problems, "\n  - ")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, problems))
+}
+
+// workflowProblems returns the integrity problems of the nodes and
+// connections of workflow, in a stable order. An error is returned when
+// the nodes or connections do not have the shape n8n uses.
+func workflowProblems(workflow map[string]interface{}) ([]string, error) {
+	problems := []string{}
+
+	var nodes []interface{}
+	if value, ok := workflow["nodes"]; ok && value != nil {
+		nodes, ok = value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected nodes to be an array")
+		}
+	}
+
+	nodeNames := make(map[string]bool, len(nodes))
+	for i, value := range nodes {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected node at index %d to be an object", i)
+		}
+
+		name, _ := node["name"].(string)
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("node at index %d has no name", i))
+			continue
+		}
+
+		if nodeNames[name] {
+			problems = append(problems, fmt.Sprintf("duplicate node name %q", name))
+			continue
+		}
+		nodeNames[name] = true
+	}
+
+	var connections map[string]interface{}
+	if value, ok := workflow["connections"]; ok && value != nil {
+		connections, ok = value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected connections to be an object")
+		}
+	}
+
+	sources := make([]string, 0, len(connections))
+	for source := range connections {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		if !nodeNames[source] {
+			problems = append(problems, fmt.Sprintf("connections reference missing source node %q", source))
+		}
+
+		targets, err := connectionTargets(connections[source])
+		if err != nil {
+			return nil, fmt.Errorf("invalid connections of node %q: %w", source, err)
+		}
+
+		for _, target := range targets {
+			if !nodeNames[target] {
+				problems = append(problems, fmt.Sprintf("connection from %q references missing node %q", source, target))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+// connectionTargets returns the names of the nodes a node's connections
+// point to. n8n stores them by connection type, then output index, as
+// {"main": [[{"node": "Target", "type": "main", "index": 0}]]}.
+func connectionTargets(value interface{}) ([]string, error) {
+	byType, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object keyed by connection type")
+	}
+
+	connectionTypes := make([]string, 0, len(byType))
+	for connectionType := range byType {
+		connectionTypes = append(connectionTypes, connectionType)
+	}
+	sort.Strings(connectionTypes)
+
+	var targets []string
+	for _, connectionType := range connectionTypes {
+		if byType[connectionType] == nil {
+			continue
+		}
+
+		outputs, ok := byType[connectionType].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected %s connections to be an array of outputs", connectionType)
+		}
+
+		for _, output := range outputs {
+			if output == nil {
+				continue
+			}
+
+			links, ok := output.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected each %s output to be an array of connections", connectionType)
+			}
+
+			for _, link := range links {
+				connection, ok := link.(map[string]interface{})
+				if !ok {
+					return nil, fmt.Errorf("expected each %s connection to be an object", connectionType)
+				}
+
+				target, _ := connection["node"].(string)
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateWorkflowFunction_Run(t *testing.T) {
+	testCases := map[string]struct {
+		workflow string
+		strict   bool
+		want     []string
+		wantErr  bool
+	}{
+		"valid": {
+			workflow: `{"nodes":[{"name":"Trigger"},{"name":"Jira"}],"connections":{"Trigger":{"main":[[{"node":"Jira","type":"main","index":0}]]}}}`,
+			want:     []string{},
+		},
+		"no connections": {
+			workflow: `{"nodes":[{"name":"Trigger"}]}`,
+			want:     []string{},
+		},
+		"problems": {
+			workflow: `{
+				"nodes": [{"name":"Trigger"},{"name":"Trigger"},{"type":"n8n-nodes-base.set"}],
+				"connections": {
+					"Trigger": {"main": [[{"node":"Jira","type":"main","index":0}], null]},
+					"Removed": {"main": [[{"node":"Trigger","type":"main","index":0}]]}
+				}
+			}`,
+			want: []string{
+				`duplicate node name "Trigger"`,
+				"node at index 2 has no name",
+				`connections reference missing source node "Removed"`,
+				`connection from "Trigger" references missing node "Jira"`,
+			},
+		},
+		"strict with problems": {
+			workflow: `{"nodes":[],"connections":{"Trigger":{"main":[]}}}`,
+			strict:   true,
+			wantErr:  true,
+		},
+		"strict without problems": {
+			workflow: `{"nodes":[{"name":"Trigger"}],"connections":{}}`,
+			strict:   true,
+			want:     []string{},
+		},
+		"invalid json": {
+			workflow: `{`,
+			wantErr:  true,
+		},
+		"invalid nodes": {
+			workflow: `{"nodes":{}}`,
+			wantErr:  true,
+		},
+		"invalid connections": {
+			workflow: `{"nodes":[{"name":"Trigger"}],"connections":{"Trigger":{"main":[{"node":"Jira"}]}}}`,
+			wantErr:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(testCase.workflow),
+					types.BoolValue(testCase.strict),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewValidateWorkflowFunction().Run(context.Background(), req, resp)
+
+			if testCase.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			got, ok := resp.Result.Value().(types.List)
+			if !ok {
+				t.Fatalf("unexpected result type %T", resp.Result.Value())
+			}
+
+			problems := []string{}
+			if diags := got.ElementsAs(context.Background(), &problems, false); diags.HasError() {
+				t.Fatalf("unexpected error converting result: %v", diags)
+			}
+			if !reflect.DeepEqual(problems, testCase.want) {
+				t.Errorf("expected %q, got %q", testCase.want, problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VariablesDataSource{}
+
+func NewVariablesDataSource() datasource.DataSource {
+	return &VariablesDataSource{}
+}
+
+// VariablesDataSource defines the data source implementation.
+type VariablesDataSource struct {
+	client *client.Client
+}
+
+// VariablesDataSourceModel describes the data source data model.
+type VariablesDataSourceModel struct {
+	ID         types.String          `tfsdk:"id"`
+	KeyPrefix  types.String          `tfsdk:"key_prefix"`
+	ExtraQuery map[string]string     `tfsdk:"extra_query"`
+	Variables  []ListedVariableModel `tfsdk:"variables"`
+}
+
+// ListedVariableModel describes a variable returned by the data source.
+type ListedVariableModel struct {
+	ID    types.String `tfsdk:"id"`
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (d *VariablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+func (d *VariablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Variables data source for listing the variables of the instance, e.g. to find out which exist before creating more or to audit them for secrets stored in plaintext. Variables require an Enterprise license.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to `variables`",
+				Computed:            true,
+			},
+			"key_prefix": schema.StringAttribute{
+				MarkdownDescription: "Restricts the variables to those whose key starts with this prefix, matched case-sensitively. The API cannot filter variables, so every variable is read and filtered by the provider.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"variables": schema.ListNestedAttribute{
+				MarkdownDescription: "The variables, sorted by key",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the variable",
+							Computed:            true,
+						},
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key of the variable, as referenced by `$vars` in workflows",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the variable",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *VariablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *VariablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VariablesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables, err := d.client.ListVariables(ctx, &client.ListOptions{ExtraQuery: data.ExtraQuery})
+	if client.IsFeatureUnavailableError(err) {
+		resp.Diagnostics.AddError(
+			"Variables Unavailable",
+			fmt.Sprintf("Unable to list variables because the n8n instance does not support them. Variables require an Enterprise license. API error: %s", err),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list variables", err)
+		return
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Key < variables[j].Key
+	})
+
+	prefix := data.KeyPrefix.ValueString()
+	data.Variables = make([]ListedVariableModel, 0, len(variables))
+	for _, variable := range variables {
+		if !strings.HasPrefix(variable.Key, prefix) {
+			continue
+		}
+
+		data.Variables = append(data.Variables, ListedVariableModel{
+			ID:    types.StringValue(variable.ID),
+			Key:   types.StringValue(variable.Key),
+			Value: types.StringValue(variable.Value),
+		})
+	}
+
+	data.ID = types.StringValue("variables")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readVariables reads d with the given key_prefix, or none if it is empty,
+// and returns the resulting state and diagnostics.
+func readVariables(t *testing.T, d *VariablesDataSource, keyPrefix string) (VariablesDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	if keyPrefix != "" {
+		attributes["key_prefix"] = tftypes.NewValue(tftypes.String, keyPrefix)
+	}
+	config := tftypes.NewValue(objectType, attributes)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+	d.Read(ctx, req, resp)
+
+	var data VariablesDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+	}
+
+	return data, resp.Diagnostics
+}
+
+func TestVariablesDataSourceRead(t *testing.T) {
+	var pages int
+	d := &VariablesDataSource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"v3","key":"STRIPE_KEY","value":"sk_live"},{"id":"v1","key":"APP_ENV","value":"production"}],"nextCursor":"page2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"v2","key":"APP_URL","value":"https://example.com"}],"nextCursor":null}`))
+	})}
+
+	testCases := map[string]struct {
+		keyPrefix string
+		want      []string
+	}{
+		"all":    {want: []string{"APP_ENV", "APP_URL", "STRIPE_KEY"}},
+		"prefix": {keyPrefix: "APP_", want: []string{"APP_ENV", "APP_URL"}},
+		"case":   {keyPrefix: "app_"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pages = 0
+			data, diags := readVariables(t, d, tc.keyPrefix)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var keys []string
+			for _, variable := range data.Variables {
+				keys = append(keys, variable.Key.ValueString())
+			}
+			if !slices.Equal(keys, tc.want) {
+				t.Errorf("expected variables %v, got %v", tc.want, keys)
+			}
+			if pages != 2 {
+				t.Errorf("expected every page to be read, got %d", pages)
+			}
+		})
+	}
+}
+
+func TestVariablesDataSourceRead_unavailable(t *testing.T) {
+	d := &VariablesDataSource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Your license does not allow for feat:variables."}`))
+	})}
+
+	_, diags := readVariables(t, d, "")
+
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Variables Unavailable" {
+		t.Fatalf("expected a Variables Unavailable error, got: %v", diags)
+	}
+}
+
+func TestVariablesDataSourceSchema_sensitiveValues(t *testing.T) {
+	resp := &datasource.SchemaResponse{}
+	(&VariablesDataSource{}).Schema(context.Background(), datasource.SchemaRequest{}, resp)
+
+	variables := resp.Schema.Attributes["variables"].(schema.ListNestedAttribute)
+	if !variables.NestedObject.Attributes["value"].IsSensitive() {
+		t.Error("expected variable values to be sensitive")
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VariablesResource{}
+
+func NewVariablesResource() resource.Resource {
+	return &VariablesResource{}
+}
+
+// VariablesResource defines the resource implementation.
+type VariablesResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// VariablesResourceModel describes the resource data model.
+type VariablesResourceModel struct {
+	ID                types.String      `tfsdk:"id"`
+	Variables         map[string]string `tfsdk:"variables"`
+	SkipIfUnavailable types.Bool        `tfsdk:"skip_if_unavailable"`
+	Skipped           types.Bool        `tfsdk:"skipped"`
+	RequestHeaders    map[string]string `tfsdk:"request_headers"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *VariablesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+func (r *VariablesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Variables resource for managing n8n variables as a single map instead of one resource per variable. Only the keys in `variables` are managed: variables created outside Terraform are left untouched, and a variable that already exists with a managed key is adopted and overwritten. Use at most one instance of this resource per n8n instance.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Placeholder identifier for the resource",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "The variable values keyed by variable key. Keys removed from the map are deleted from the instance.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Sensitive:           true,
+			},
+			"skip_if_unavailable": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip the resource with a warning instead of failing when the instance does not support variables, e.g. a Community edition instance without the feature in its license. A skipped resource records its planned `variables` in state without touching the instance, is not refreshed, and is removed from state on destroy without any API call. It is retried on the next change to `variables`; replace it to retry sooner once the feature is available. Defaults to `false`.",
+				Optional:            true,
+			},
+			"request_headers": schema.MapAttribute{
+				MarkdownDescription: requestHeadersDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Map{
+					requestHeadersValidator{},
+				},
+			},
+			"skipped": schema.BoolAttribute{
+				MarkdownDescription: "Whether the resource was skipped because the instance does not support variables. Only set when `skip_if_unavailable` is true.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *VariablesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *VariablesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VariablesResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	data.ID = types.StringValue("variables")
+	data.Skipped = types.BoolValue(false)
+
+	if err := reconcileVariables(ctx, clientWithRequestHeaders(r.client, data.RequestHeaders), nil, data.Variables); err != nil {
+		if !skipUnavailableVariables(&data, &resp.Diagnostics, err) {
+			addClientError(&resp.Diagnostics, "create variables", err)
+			return
+		}
+	}
+
+	if !data.Skipped.ValueBool() {
+		r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_variables", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Created n8n cloud variables resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VariablesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data VariablesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	// A skipped resource has nothing on the instance to refresh
+	if data.Skipped.ValueBool() {
+		return
+	}
+
+	live, err := clientWithRequestHeaders(r.client, data.RequestHeaders).ListVariables(ctx, nil)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list variables", err)
+		return
+	}
+
+	// Refresh the managed keys only, so values changed outside Terraform
+	// are reverted and deleted variables are recreated on the next apply
+	data.Variables = managedVariableValues(live, data.Variables)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VariablesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state VariablesResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	// Nothing was created for a skipped resource, so retry from scratch
+	prior := state.Variables
+	if state.Skipped.ValueBool() {
+		prior = nil
+	}
+
+	data.Skipped = types.BoolValue(false)
+
+	if err := reconcileVariables(ctx, clientWithRequestHeaders(r.client, data.RequestHeaders), prior, data.Variables); err != nil {
+		if !skipUnavailableVariables(&data, &resp.Diagnostics, err) {
+			addClientError(&resp.Diagnostics, "update variables", err)
+			return
+		}
+	}
+
+	if !data.Skipped.ValueBool() {
+		r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_variables", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Updated n8n cloud variables resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VariablesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data VariablesResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+	defer cancel()
+
+	// Nothing was created for a skipped resource, so there is nothing to delete
+	if data.Skipped.ValueBool() {
+		tflog.Trace(ctx, "Removed skipped n8n cloud variables resource")
+		return
+	}
+
+	if err := reconcileVariables(ctx, clientWithRequestHeaders(r.client, data.RequestHeaders), data.Variables, nil); err != nil {
+		addClientError(&resp.Diagnostics, "delete variables", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_variables", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n cloud variables resource")
+}
+
+// skipUnavailableVariables marks data as skipped and adds a warning to diags
+// when err reports that the instance does not support variables and the
+// configuration opted into skipping. It reports whether the resource was
+// skipped.
+func skipUnavailableVariables(data *VariablesResourceModel, diags *diag.Diagnostics, err error) bool {
+	if !data.SkipIfUnavailable.ValueBool() || !client.IsFeatureUnavailableError(err) {
+		return false
+	}
+
+	data.Skipped = types.BoolValue(true)
+
+	diags.AddWarning(
+		"Variables Unavailable",
+		fmt.Sprintf("The n8n instance does not support variables, so the resource was skipped because skip_if_unavailable is set. No variables were written. Replace the resource once the feature is available to create them.\n\nAPI response: %s", err),
+	)
+
+	return true
+}
+
+// reconcileVariables applies the change from the managed variables in prior
+// to those in desired against the live variables: keys in desired are
+// created, or updated when their live value differs, and keys only in prior
+// are deleted if they still exist. Keys are processed in sorted order so
+// failures are reproducible.
+func reconcileVariables(ctx context.Context, c *client.Client, prior, desired map[string]string) error {
+	live, err := c.ListVariables(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to list variables: %w", err)
+	}
+
+	liveByKey := make(map[string]client.Variable, len(live))
+	for _, variable := range live {
+		liveByKey[variable.Key] = variable
+	}
+
+	for _, key := range sortedKeys(desired) {
+		value := desired[key]
+		variable, exists := liveByKey[key]
+
+		switch {
+		case !exists:
+			tflog.Debug(ctx, "Creating n8n cloud variable", map[string]interface{}{"key": key})
+			if err := c.CreateVariable(ctx, &client.VariableRequest{Key: key, Value: value}); err != nil {
+				return fmt.Errorf("unable to create variable %q: %w", key, err)
+			}
+		case variable.Value != value:
+			tflog.Debug(ctx, "Updating n8n cloud variable", map[string]interface{}{"key": key})
+			if err := c.UpdateVariable(ctx, variable.ID, &client.VariableRequest{Key: key, Value: value}); err != nil {
+				return fmt.Errorf("unable to update variable %q: %w", key, err)
+			}
+		}
+	}
+
+	for _, key := range sortedKeys(prior) {
+		if _, keep := desired[key]; keep {
+			continue
+		}
+
+		variable, exists := liveByKey[key]
+		if !exists {
+			continue
+		}
+
+		tflog.Debug(ctx, "Deleting n8n cloud variable", map[string]interface{}{"key": key})
+		if err := c.DeleteVariable(ctx, variable.ID); err != nil && !client.IsNotFound(err) {
+			return fmt.Errorf("unable to delete variable %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// managedVariableValues returns the live values of the keys in managed,
+// omitting keys that no longer exist.
+func managedVariableValues(live []client.Variable, managed map[string]string) map[string]string {
+	values := make(map[string]string, len(managed))
+	for _, variable := range live {
+		if _, ok := managed[variable.Key]; ok {
+			values[variable.Key] = variable.Value
+		}
+	}
+
+	return values
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAccVariablesResource_basic(t *testing.T) {
+	prefix := fmt.Sprintf("TF_ACC_%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccVariablesResourceConfig(map[string]string{
+					prefix + "_A": "one",
+					prefix + "_B": "two",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_variables.test",
+						tfjsonpath.New("variables"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							prefix + "_A": knownvalue.StringExact("one"),
+							prefix + "_B": knownvalue.StringExact("two"),
+						}),
+					),
+				},
+			},
+			// Add, change and remove keys in one apply
+			{
+				Config: testAccVariablesResourceConfig(map[string]string{
+					prefix + "_A": "uno",
+					prefix + "_C": "three",
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_variables.test",
+						tfjsonpath.New("variables"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							prefix + "_A": knownvalue.StringExact("uno"),
+							prefix + "_C": knownvalue.StringExact("three"),
+						}),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccVariablesResourceConfig(variables map[string]string) string {
+	var entries strings.Builder
+	for _, key := range sortedKeys(variables) {
+		fmt.Fprintf(&entries, "    %s = %q\n", key, variables[key])
+	}
+
+	return fmt.Sprintf(`
+resource "n8ncloud_variables" "test" {
+  variables = {
+%s  }
+}
+`, entries.String())
+}
+
+// fakeVariablesServer is an in-memory implementation of the variables
+// endpoints, for testing reconciliation without an n8n instance.
+type fakeVariablesServer struct {
+	mu        sync.Mutex
+	nextID    int
+	variables map[string]client.Variable
+}
+
+func newFakeVariablesServer(initial map[string]string) *fakeVariablesServer {
+	server := &fakeVariablesServer{variables: map[string]client.Variable{}}
+	for _, key := range sortedKeys(initial) {
+		server.create(key, initial[key])
+	}
+
+	return server
+}
+
+func (s *fakeVariablesServer) create(key, value string) {
+	s.nextID++
+	id := fmt.Sprintf("var-%d", s.nextID)
+	s.variables[id] = client.Variable{ID: id, Key: key, Value: value}
+}
+
+// values returns the stored variables keyed by key.
+func (s *fakeVariablesServer) values() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := make(map[string]string, len(s.variables))
+	for _, variable := range s.variables {
+		values[variable.Key] = variable.Value
+	}
+
+	return values
+}
+
+func (s *fakeVariablesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/variables/")
+
+	var body client.VariableRequest
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case r.Method == http.MethodGet:
+		data := make([]client.Variable, 0, len(s.variables))
+		for _, variable := range s.variables {
+			data = append(data, variable)
+		}
+		_ = json.NewEncoder(w).Encode(client.VariablesResponse{Data: data})
+	case r.Method == http.MethodPost:
+		s.create(body.Key, body.Value)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && s.variables[id].ID != "":
+		s.variables[id] = client.Variable{ID: id, Key: body.Key, Value: body.Value}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && s.variables[id].ID != "":
+		delete(s.variables, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func TestReconcileVariables(t *testing.T) {
+	server := newFakeVariablesServer(map[string]string{"UNMANAGED": "keep", "B": "stale"})
+	c := newTestClient(t, server.ServeHTTP)
+	ctx := context.Background()
+
+	// Create adopts B and creates A.
+	applied := map[string]string{"A": "one", "B": "two"}
+	if err := reconcileVariables(ctx, c, nil, applied); err != nil {
+		t.Fatalf("unexpected error on create: %s", err)
+	}
+	if want := map[string]string{"UNMANAGED": "keep", "A": "one", "B": "two"}; !reflect.DeepEqual(server.values(), want) {
+		t.Fatalf("expected variables %v after create, got %v", want, server.values())
+	}
+
+	// Update changes A, removes B and adds C.
+	desired := map[string]string{"A": "uno", "C": "three"}
+	if err := reconcileVariables(ctx, c, applied, desired); err != nil {
+		t.Fatalf("unexpected error on update: %s", err)
+	}
+	if want := map[string]string{"UNMANAGED": "keep", "A": "uno", "C": "three"}; !reflect.DeepEqual(server.values(), want) {
+		t.Fatalf("expected variables %v after update, got %v", want, server.values())
+	}
+
+	// Delete removes the managed variables only.
+	if err := reconcileVariables(ctx, c, desired, nil); err != nil {
+		t.Fatalf("unexpected error on delete: %s", err)
+	}
+	if want := map[string]string{"UNMANAGED": "keep"}; !reflect.DeepEqual(server.values(), want) {
+		t.Fatalf("expected variables %v after delete, got %v", want, server.values())
+	}
+}
+
+func TestManagedVariableValues(t *testing.T) {
+	live := []client.Variable{
+		{ID: "1", Key: "A", Value: "changed outside terraform"},
+		{ID: "2", Key: "UNMANAGED", Value: "keep"},
+	}
+
+	got := managedVariableValues(live, map[string]string{"A": "one", "DELETED": "two"})
+
+	if want := map[string]string{"A": "changed outside terraform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// unlicensedVariablesHandler responds like an instance whose license does not
+// include variables, counting the requests it receives.
+func unlicensedVariablesHandler(requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Your license does not allow for feat:variables. To enable feat:variables, please upgrade to a license that supports this feature."}`))
+	}
+}
+
+func TestVariablesResource_skipIfUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int
+	r := &VariablesResource{client: newTestClient(t, unlicensedVariablesHandler(&requests))}
+	variablesSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"A": tftypes.NewValue(tftypes.String, "one"),
+		}),
+		"skip_if_unavailable": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	createResp := &fwresource.CreateResponse{State: tfsdk.State{Schema: variablesSchema, Raw: plan}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: variablesSchema, Raw: plan}}, createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", createResp.Diagnostics)
+	}
+	if len(createResp.Diagnostics.Warnings()) != 1 || createResp.Diagnostics.Warnings()[0].Summary() != "Variables Unavailable" {
+		t.Fatalf("expected a Variables Unavailable warning, got: %v", createResp.Diagnostics)
+	}
+
+	var state VariablesResourceModel
+	if diags := createResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if !state.Skipped.ValueBool() {
+		t.Fatal("expected the resource to be recorded as skipped")
+	}
+	if want := map[string]string{"A": "one"}; !reflect.DeepEqual(state.Variables, want) {
+		t.Errorf("expected the planned variables %v in state, got %v", want, state.Variables)
+	}
+
+	// Refreshing and destroying a skipped resource must not call the API.
+	requests = 0
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error on read: %v", readResp.Diagnostics)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error on delete: %v", deleteResp.Diagnostics)
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no API requests for a skipped resource, got %d", requests)
+	}
+}
+
+func TestVariablesResourceCreate_unavailableWithoutSkip(t *testing.T) {
+	var requests int
+	r := &VariablesResource{client: newTestClient(t, unlicensedVariablesHandler(&requests))}
+	variablesSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"A": tftypes.NewValue(tftypes.String, "one"),
+		}),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: variablesSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: variablesSchema, Raw: plan}}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when skip_if_unavailable is not set")
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowActivationResource{}
+var _ resource.ResourceWithImportState = &WorkflowActivationResource{}
+var _ resource.ResourceWithModifyPlan = &WorkflowActivationResource{}
+
+func NewWorkflowActivationResource() resource.Resource {
+	return &WorkflowActivationResource{}
+}
+
+// WorkflowActivationResource defines the resource implementation.
+type WorkflowActivationResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// WorkflowActivationResourceModel describes the resource data model.
+type WorkflowActivationResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	WorkflowID          types.String `tfsdk:"workflow_id"`
+	Active              types.Bool   `tfsdk:"active"`
+	DeactivateOnDestroy types.Bool   `tfsdk:"deactivate_on_destroy"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *WorkflowActivationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_activation"
+}
+
+func (r *WorkflowActivationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow activation resource for managing whether a workflow is active, for workflows whose content is edited in the n8n UI rather than managed with `n8ncloud_workflow`. " +
+			"Do not use it together with the `active` attribute of an `n8ncloud_workflow` for the same workflow. Activations can be imported using the workflow ID: `terraform import n8ncloud_workflow_activation.example 2tUt1wbLX592XDdX`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the activation, set to the workflow ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the workflow is active. Activating a workflow registers its triggers, so it fails for workflows without a trigger node.",
+				Required:            true,
+			},
+			"deactivate_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to deactivate the workflow when the resource is destroyed. Otherwise destroying the resource leaves the workflow as it is. Defaults to false.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *WorkflowActivationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only a deactivation of an existing activation is warned about
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state WorkflowActivationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.Active.ValueBool() || plan.Active.IsUnknown() || plan.Active.ValueBool() {
+		return
+	}
+
+	// The nodes are edited outside Terraform, so they are read to count the
+	// triggers. The warning is informational, so the plan goes ahead if
+	// they cannot be read.
+	workflow, err := r.client.GetWorkflow(ctx, state.WorkflowID.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read n8n workflow to count its triggers", map[string]interface{}{
+			"workflow_id": state.WorkflowID.ValueString(),
+			"error":       err.Error(),
+		})
+		return
+	}
+
+	_, triggerCount, err := workflowNodeCounts(string(workflow.Nodes))
+	if err != nil || triggerCount == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("active"),
+		"Workflow Triggers Will Stop",
+		fmt.Sprintf("Deactivating workflow %s stops its %d trigger node(s), such as webhooks and schedules, so external integrations relying on them may break.", workflow.Name, triggerCount),
+	)
+}
+
+func (r *WorkflowActivationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *WorkflowActivationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WorkflowActivationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	workflow, err := r.client.GetWorkflow(ctx, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workflow_id"),
+			"Workflow Not Found",
+			fmt.Sprintf("Workflow with ID %q not found", data.WorkflowID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+
+	if action, err := r.setActive(ctx, workflow, data.Active.ValueBool()); err != nil {
+		addClientError(&resp.Diagnostics, action, err)
+		return
+	}
+
+	data.ID = types.StringValue(workflow.ID)
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_workflow_activation", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Created n8n workflow activation resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowActivationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WorkflowActivationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	workflow, err := r.client.GetWorkflow(ctx, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		// The workflow was deleted outside of Terraform
+		tflog.Warn(ctx, "n8n workflow not found, removing activation from state", map[string]interface{}{
+			"workflow_id": data.WorkflowID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+
+	data.ID = types.StringValue(workflow.ID)
+	data.Active = types.BoolValue(workflow.Active)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowActivationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WorkflowActivationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	workflow, err := r.client.GetWorkflow(ctx, data.WorkflowID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+
+	if action, err := r.setActive(ctx, workflow, data.Active.ValueBool()); err != nil {
+		addClientError(&resp.Diagnostics, action, err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_workflow_activation", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Updated n8n workflow activation resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowActivationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WorkflowActivationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Destroying the resource leaves the workflow as it is unless asked
+	// otherwise
+	if data.DeactivateOnDestroy.ValueBool() {
+		ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+		defer cancel()
+
+		_, err := r.client.DeactivateWorkflow(ctx, data.WorkflowID.ValueString())
+		if err != nil && !client.IsNotFound(err) {
+			addClientError(&resp.Diagnostics, "deactivate workflow", err)
+			return
+		}
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_workflow_activation", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n workflow activation resource")
+}
+
+func (r *WorkflowActivationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workflow_id"), req.ID)...)
+}
+
+// setActive activates or deactivates workflow unless it already is in the
+// requested state. On failure, it returns the failed action for the
+// diagnostic.
+func (r *WorkflowActivationResource) setActive(ctx context.Context, workflow *client.Workflow, active bool) (string, error) {
+	if workflow.Active == active {
+		return "", nil
+	}
+
+	if active {
+		_, err := r.client.ActivateWorkflow(ctx, workflow.ID)
+		return "activate workflow", err
+	}
+
+	_, err := r.client.DeactivateWorkflow(ctx, workflow.ID)
+	return "deactivate workflow", err
+}
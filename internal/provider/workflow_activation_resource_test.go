@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccWorkflowActivationResource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-activation-%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkflowActivationResourceConfig(name, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow_activation.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:            "n8ncloud_workflow_activation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deactivate_on_destroy"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkflowActivationResourceConfig(name, false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow_activation.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccWorkflowActivationResourceConfig(name string, active bool) string {
+	return fmt.Sprintf(`
+resource "n8ncloud_workflow" "test" {
+  name = %[1]q
+
+  nodes = jsonencode([
+    {
+      name        = "Schedule"
+      type        = "n8n-nodes-base.scheduleTrigger"
+      typeVersion = 1
+      position    = [0, 0]
+      parameters  = {}
+    },
+  ])
+
+  connections = jsonencode({})
+
+  lifecycle {
+    ignore_changes = [active]
+  }
+}
+
+resource "n8ncloud_workflow_activation" "test" {
+  workflow_id           = n8ncloud_workflow.test.id
+  active                = %[2]t
+  deactivate_on_destroy = true
+}
+`, name, active)
+}
+
+// runWorkflowActivationCreate creates an activation of workflowID through r
+// and returns the response.
+func runWorkflowActivationCreate(t *testing.T, r *WorkflowActivationResource, workflowID string, active bool) *fwresource.CreateResponse {
+	t.Helper()
+
+	activationSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"workflow_id": tftypes.NewValue(tftypes.String, workflowID),
+		"active":      tftypes.NewValue(tftypes.Bool, active),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: activationSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: activationSchema, Raw: plan}}, resp)
+
+	return resp
+}
+
+func TestWorkflowActivationResourceCreate(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	r := &WorkflowActivationResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowActivationCreate(t, r, workflow.ID, true)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !server.hasRequest("POST /workflows/"+workflow.ID+"/activate") || !workflow.Active {
+		t.Error("expected the workflow to be activated")
+	}
+	if server.hasRequest("PUT /workflows/" + workflow.ID) {
+		t.Error("expected the workflow content to be left alone")
+	}
+
+	var state WorkflowActivationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.ID.ValueString() != workflow.ID {
+		t.Errorf("expected id %s, got %s", workflow.ID, state.ID)
+	}
+}
+
+func TestWorkflowActivationResourceCreate_alreadyActive(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Active = true
+	r := &WorkflowActivationResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowActivationCreate(t, r, workflow.ID, true)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.hasRequest("POST /workflows/" + workflow.ID + "/activate") {
+		t.Error("expected no activation request for an active workflow")
+	}
+}
+
+func TestWorkflowActivationResourceCreate_workflowNotFound(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	r := &WorkflowActivationResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowActivationCreate(t, r, "missing", true)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Workflow Not Found" {
+		t.Fatalf("expected a Workflow Not Found error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkflowActivationResourceModifyPlan_deactivationWarning(t *testing.T) {
+	testCases := map[string]struct {
+		nodes       string
+		wantWarning bool
+	}{
+		"with trigger": {
+			nodes:       testWorkflowNodes,
+			wantWarning: true,
+		},
+		"manual trigger only": {
+			nodes: `[{"name":"Start","type":"n8n-nodes-base.manualTrigger"}]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeWorkflowServer(t)
+			workflow := server.add("Orders", tc.nodes, `{}`)
+			workflow.Active = true
+			r := &WorkflowActivationResource{client: newTestClient(t, server.ServeHTTP)}
+
+			activationSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, workflow.ID),
+				"workflow_id": tftypes.NewValue(tftypes.String, workflow.ID),
+				"active":      tftypes.NewValue(tftypes.Bool, true),
+			})
+			_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, workflow.ID),
+				"workflow_id": tftypes.NewValue(tftypes.String, workflow.ID),
+				"active":      tftypes.NewValue(tftypes.Bool, false),
+			})
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: activationSchema, Raw: plan},
+				Plan:   tfsdk.Plan{Schema: activationSchema, Raw: plan},
+				State:  tfsdk.State{Schema: activationSchema, Raw: state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			warned := len(resp.Diagnostics.Warnings()) == 1 && resp.Diagnostics.Warnings()[0].Summary() == "Workflow Triggers Will Stop"
+			if warned != tc.wantWarning {
+				t.Errorf("expected warning %t, got diagnostics %v", tc.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestWorkflowActivationResourceDelete(t *testing.T) {
+	for name, deactivate := range map[string]bool{"deactivate on destroy": true, "leave active": false} {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeWorkflowServer(t)
+			workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+			workflow.Active = true
+			r := &WorkflowActivationResource{client: newTestClient(t, server.ServeHTTP)}
+
+			activationSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+				"id":                    tftypes.NewValue(tftypes.String, workflow.ID),
+				"workflow_id":           tftypes.NewValue(tftypes.String, workflow.ID),
+				"active":                tftypes.NewValue(tftypes.Bool, true),
+				"deactivate_on_destroy": tftypes.NewValue(tftypes.Bool, deactivate),
+			})
+
+			resp := &fwresource.DeleteResponse{State: tfsdk.State{Schema: activationSchema, Raw: state}}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: tfsdk.State{Schema: activationSchema, Raw: state}}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if workflow.Active == deactivate {
+				t.Errorf("expected the workflow to be active %t after destroy, got %t", !deactivate, workflow.Active)
+			}
+			if server.workflows[workflow.ID] == nil {
+				t.Error("expected the workflow to be kept")
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowExportDataSource{}
+
+func NewWorkflowExportDataSource() datasource.DataSource {
+	return &WorkflowExportDataSource{}
+}
+
+// WorkflowExportDataSource defines the data source implementation.
+type WorkflowExportDataSource struct {
+	client *client.Client
+}
+
+// WorkflowExportDataSourceModel describes the data source data model.
+type WorkflowExportDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	Name       types.String `tfsdk:"name"`
+	ProjectID  types.String `tfsdk:"project_id"`
+	Active     types.Bool   `tfsdk:"active"`
+	JSON       types.String `tfsdk:"json"`
+
+	VersionID    types.String `tfsdk:"version_id"`
+	NodeCount    types.Int64  `tfsdk:"node_count"`
+	TriggerCount types.Int64  `tfsdk:"trigger_count"`
+}
+
+func (d *WorkflowExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_export"
+}
+
+func (d *WorkflowExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow export data source for backing up a workflow, e.g. by writing `json` to a file with `local_file`. The export is normalized so that reading an unchanged workflow always produces the same output. You must specify either `workflow_id` or `name` to identify the workflow.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to the workflow ID",
+				Computed:            true,
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow to export. Either workflow_id or name must be specified.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The exact name of the workflow to export. Either workflow_id or name must be specified. Names are not unique: if several workflows match, the data source fails and lists their IDs, so narrow the match with `project_id` or `active`, or use `workflow_id`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Restricts the name match to the workflows of a project. Only used with `name`.",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Restricts the name match to active or inactive workflows. Only used with `name`.",
+				Optional:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The workflow as returned by the API, including its nodes, connections and settings but not its pinned data, as a JSON string with object keys in sorted order",
+				Computed:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "The version of the workflow, which changes on every edit",
+				Computed:            true,
+			},
+			"node_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of nodes in the workflow",
+				Computed:            true,
+			},
+			"trigger_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of trigger nodes in the workflow that start executions on their own, such as webhooks and schedules. The manual trigger is not counted.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WorkflowExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *WorkflowExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that exactly one of workflow ID or name is specified
+	if data.WorkflowID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"Exactly one of 'workflow_id' or 'name' must be specified",
+		)
+		return
+	}
+
+	if !data.Name.IsNull() {
+		workflowID, ok := d.resolveWorkflowName(ctx, &data, resp)
+		if !ok {
+			return
+		}
+		data.WorkflowID = types.StringValue(workflowID)
+	} else if !data.ProjectID.IsNull() || !data.Active.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"The 'project_id' and 'active' attributes only narrow a lookup by 'name' and cannot be used with 'workflow_id'",
+		)
+		return
+	}
+
+	workflow, err := d.client.GetWorkflow(ctx, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workflow_id"),
+			"Workflow Not Found",
+			fmt.Sprintf("Workflow with ID %q not found", data.WorkflowID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+
+	// Normalizing sorts object keys, so the export does not depend on the
+	// order the API serializes fields in.
+	exported, err := normalizeJSON(string(workflow.Raw))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode workflow as JSON, got error: %s", err))
+		return
+	}
+
+	nodeCount, triggerCount := 0, 0
+	if len(workflow.Nodes) > 0 {
+		nodeCount, triggerCount, err = workflowNodeCounts(string(workflow.Nodes))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow nodes, got error: %s", err))
+			return
+		}
+	}
+
+	data.JSON = types.StringValue(exported)
+	data.VersionID = types.StringValue(workflow.VersionID)
+	data.NodeCount = types.Int64Value(int64(nodeCount))
+	data.TriggerCount = types.Int64Value(int64(triggerCount))
+	data.ID = types.StringValue(workflow.ID)
+	data.Name = types.StringValue(workflow.Name)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// resolveWorkflowName returns the ID of the only workflow matching the name
+// in data. Diagnostics are added to resp when no workflow or several
+// workflows match.
+func (d *WorkflowExportDataSource) resolveWorkflowName(ctx context.Context, data *WorkflowExportDataSourceModel, resp *datasource.ReadResponse) (string, bool) {
+	name := data.Name.ValueString()
+
+	matches, err := findWorkflowsByName(ctx, d.client, name, data.ProjectID.ValueString(), data.Active)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list workflows", err)
+		return "", false
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Workflow Not Found",
+			fmt.Sprintf("No workflow named %q found", name),
+		)
+		return "", false
+	case 1:
+		return matches[0].ID, true
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("name"),
+		"Multiple Workflows Found",
+		fmt.Sprintf("%d workflows are named %q, with IDs %s. Narrow the match with project_id or active, or set workflow_id instead.",
+			len(matches), name, strings.Join(workflowIDs(matches), ", ")),
+	)
+	return "", false
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestWorkflowExportDataSourceRead_deterministic(t *testing.T) {
+	// The same workflow serialized with fields in a different order.
+	responses := []string{
+		`{"id":"wf1","name":"Backup","nodes":[{"name":"Start","type":"n8n-nodes-base.manualTrigger","parameters":{}}],"connections":{},"settings":{"timezone":"UTC","executionOrder":"v1"}}`,
+		`{"settings":{"executionOrder":"v1","timezone":"UTC"},"connections":{},"nodes":[{"parameters":{},"type":"n8n-nodes-base.manualTrigger","name":"Start"}],"name":"Backup","id":"wf1"}`,
+	}
+	reads := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[reads%len(responses)]))
+		reads++
+	})
+
+	ctx := context.Background()
+	d := &WorkflowExportDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func() WorkflowExportDataSourceModel {
+		t.Helper()
+
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["workflow_id"] = tftypes.NewValue(tftypes.String, "wf1")
+		config := tftypes.NewValue(objectType, attributes)
+
+		req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+		d.Read(ctx, req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+
+		var data WorkflowExportDataSourceModel
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+
+		return data
+	}
+
+	first, second := read(), read()
+
+	if first.JSON.ValueString() != second.JSON.ValueString() {
+		t.Errorf("expected repeated reads to produce the same export, got:\n%s\n%s", first.JSON.ValueString(), second.JSON.ValueString())
+	}
+
+	want := `{"connections":{},"id":"wf1","name":"Backup","nodes":[{"name":"Start","parameters":{},"type":"n8n-nodes-base.manualTrigger"}],"settings":{"executionOrder":"v1","timezone":"UTC"}}`
+	if first.JSON.ValueString() != want {
+		t.Errorf("expected export %s, got %s", want, first.JSON.ValueString())
+	}
+	if first.ID.ValueString() != "wf1" || first.Name.ValueString() != "Backup" {
+		t.Errorf("unexpected id %s and name %s", first.ID, first.Name)
+	}
+}
+
+func TestWorkflowExportDataSourceRead_byName(t *testing.T) {
+	workflows := []client.Workflow{
+		{ID: "wf1", Name: "Billing", Active: true},
+		{ID: "wf2", Name: "Billing", Active: false},
+	}
+	c := newTestClient(t, stubWorkflowsHandler(t, workflows, nil))
+
+	ctx := context.Background()
+	d := &WorkflowExportDataSource{client: c}
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	read := func(values map[string]tftypes.Value) *datasource.ReadResponse {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range values {
+			attributes[name] = value
+		}
+		config := tftypes.NewValue(objectType, attributes)
+
+		req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+		d.Read(ctx, req, resp)
+
+		return resp
+	}
+
+	// A name shared by several workflows fails with the candidate IDs.
+	resp := read(map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "Billing")})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a name shared by several workflows")
+	}
+	diagnostic := resp.Diagnostics.Errors()[0]
+	if diagnostic.Summary() != "Multiple Workflows Found" || !strings.Contains(diagnostic.Detail(), "wf1, wf2") {
+		t.Fatalf("expected a Multiple Workflows Found error listing wf1, wf2, got: %v", resp.Diagnostics)
+	}
+
+	// Narrowing the match resolves the workflow.
+	resp = read(map[string]tftypes.Value{
+		"name":   tftypes.NewValue(tftypes.String, "Billing"),
+		"active": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data WorkflowExportDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if data.ID.ValueString() != "wf2" || data.WorkflowID.ValueString() != "wf2" {
+		t.Errorf("expected workflow wf2, got id %s and workflow_id %s", data.ID, data.WorkflowID)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// decodeJSON decodes s into a generic value. Numbers are kept as json.Number
+// so re-encoding doesn't change their representation.
+func decodeJSON(s string) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+
+	return value, nil
+}
+
+// decodeJSONObject decodes s and ensures it is a JSON object.
+func decodeJSONObject(s string) (map[string]interface{}, error) {
+	value, err := decodeJSON(s)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	return object, nil
+}
+
+// encodeJSON encodes value with object keys in sorted order, producing the
+// same output for semantically equal values.
+func encodeJSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// normalizeJSON re-encodes the JSON value s with object keys in sorted
+// order, so that semantically equal documents produce the same string.
+func normalizeJSON(s string) (string, error) {
+	value, err := decodeJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSON(value)
+}
+
+// jsonContains reports whether value contains subset: objects must have the
+// keys of subset with contained values, arrays must have the same length
+// with contained elements, and other values must be equal. A null in subset
+// also matches a missing key. Numbers are compared by value.
+func jsonContains(value, subset interface{}) bool {
+	switch subset := subset.(type) {
+	case map[string]interface{}:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, subsetValue := range subset {
+			objectValue, ok := object[key]
+			if !ok {
+				if subsetValue == nil {
+					continue
+				}
+				return false
+			}
+
+			if !jsonContains(objectValue, subsetValue) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		array, ok := value.([]interface{})
+		if !ok || len(array) != len(subset) {
+			return false
+		}
+
+		for i := range subset {
+			if !jsonContains(array[i], subset[i]) {
+				return false
+			}
+		}
+
+		return true
+	case json.Number:
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		if number == subset {
+			return true
+		}
+
+		a, errA := number.Float64()
+		b, errB := subset.Float64()
+		return errA == nil && errB == nil && a == b
+	default:
+		return value == subset
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// untriggeredNodeTypes lists node types named like triggers that do not run
+// a workflow on their own.
+var untriggeredNodeTypes = map[string]bool{
+	"n8n-nodes-base.manualTrigger": true,
+}
+
+// legacyTriggerNodeTypes lists trigger node types from before n8n named
+// triggers with a Trigger suffix.
+var legacyTriggerNodeTypes = map[string]bool{
+	"n8n-nodes-base.webhook":  true,
+	"n8n-nodes-base.cron":     true,
+	"n8n-nodes-base.interval": true,
+}
+
+// isTriggerNodeType reports whether nodes of the given type start workflow
+// runs on their own, such as webhooks and schedules, and so only work while
+// the workflow is active.
+func isTriggerNodeType(nodeType string) bool {
+	if untriggeredNodeTypes[nodeType] {
+		return false
+	}
+
+	return legacyTriggerNodeTypes[nodeType] || strings.HasSuffix(strings.ToLower(nodeType), "trigger")
+}
+
+// workflowNodeCounts returns the number of nodes and of trigger nodes in the
+// JSON array of workflow nodes.
+func workflowNodeCounts(nodesJSON string) (int, int, error) {
+	value, err := decodeJSON(nodesJSON)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	nodes, ok := value.([]interface{})
+	if !ok {
+		return 0, 0, fmt.Errorf("expected nodes to be an array")
+	}
+
+	triggers := 0
+	for _, value := range nodes {
+		node, _ := value.(map[string]interface{})
+		nodeType, _ := node["type"].(string)
+		if isTriggerNodeType(nodeType) {
+			triggers++
+		}
+	}
+
+	return len(nodes), triggers, nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// findWorkflowsByName returns the workflows named exactly name, optionally
+// narrowed to a project and to active or inactive workflows. Workflow names
+// are not unique, so callers must handle more than one match.
+func findWorkflowsByName(ctx context.Context, c *client.Client, name, projectID string, active types.Bool) ([]client.Workflow, error) {
+	opts := &client.ListWorkflowsOptions{
+		Name:      name,
+		ProjectID: projectID,
+	}
+	if !active.IsNull() && !active.IsUnknown() {
+		value := active.ValueBool()
+		opts.Active = &value
+	}
+
+	workflows, err := c.ListWorkflows(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API name filter is not guaranteed to be exact
+	matches := make([]client.Workflow, 0, 1)
+	for _, workflow := range workflows {
+		if workflow.Name == name {
+			matches = append(matches, workflow)
+		}
+	}
+
+	return matches, nil
+}
+
+// workflowIDs returns the IDs of workflows, for listing candidates in
+// diagnostics.
+func workflowIDs(workflows []client.Workflow) []string {
+	ids := make([]string, 0, len(workflows))
+	for _, workflow := range workflows {
+		ids = append(ids, workflow.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// stubWorkflowsHandler serves GET /workflows from workflows, applying the
+// name, projectId and active filters like the API. The name filter matches
+// partially, so exact matching is left to the caller. GET /workflows/{id}
+// returns the workflow with that ID.
+func stubWorkflowsHandler(t *testing.T, workflows []client.Workflow, projects map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if id := strings.TrimPrefix(r.URL.Path, "/api/v1/workflows/"); id != r.URL.Path {
+			for _, workflow := range workflows {
+				if workflow.ID == id {
+					_ = json.NewEncoder(w).Encode(workflow)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query()
+		data := []client.Workflow{}
+		for _, workflow := range workflows {
+			if name := query.Get("name"); name != "" && !strings.Contains(workflow.Name, name) {
+				continue
+			}
+			if projectID := query.Get("projectId"); projectID != "" && projects[workflow.ID] != projectID {
+				continue
+			}
+			if active := query.Get("active"); active != "" && strconv.FormatBool(workflow.Active) != active {
+				continue
+			}
+			data = append(data, workflow)
+		}
+
+		if err := json.NewEncoder(w).Encode(client.WorkflowsResponse{Data: data}); err != nil {
+			t.Errorf("unable to encode workflows: %s", err)
+		}
+	}
+}
+
+func TestFindWorkflowsByName(t *testing.T) {
+	workflows := []client.Workflow{
+		{ID: "wf1", Name: "Billing", Active: true},
+		{ID: "wf2", Name: "Billing", Active: false},
+		{ID: "wf3", Name: "Billing v2", Active: true},
+	}
+	projects := map[string]string{"wf1": "p1", "wf2": "p2", "wf3": "p1"}
+	c := newTestClient(t, stubWorkflowsHandler(t, workflows, projects))
+
+	testCases := map[string]struct {
+		name      string
+		projectID string
+		active    types.Bool
+		want      []string
+	}{
+		"collision":           {name: "Billing", active: types.BoolNull(), want: []string{"wf1", "wf2"}},
+		"narrowed by project": {name: "Billing", projectID: "p2", active: types.BoolNull(), want: []string{"wf2"}},
+		"narrowed by active":  {name: "Billing", active: types.BoolValue(true), want: []string{"wf1"}},
+		"exact match only":    {name: "Billing v2", active: types.BoolNull(), want: []string{"wf3"}},
+		"no match":            {name: "Billing", projectID: "p3", active: types.BoolNull(), want: []string{}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			matches, err := findWorkflowsByName(context.Background(), c, testCase.name, testCase.projectID, testCase.active)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := workflowIDs(matches); !reflect.DeepEqual(got, testCase.want) {
+				t.Errorf("expected workflows %v, got %v", testCase.want, got)
+			}
+		})
+	}
+}
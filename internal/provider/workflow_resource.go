@@ -0,0 +1,969 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowResource{}
+var _ resource.ResourceWithImportState = &WorkflowResource{}
+var _ resource.ResourceWithValidateConfig = &WorkflowResource{}
+var _ resource.ResourceWithModifyPlan = &WorkflowResource{}
+
+func NewWorkflowResource() resource.Resource {
+	return &WorkflowResource{}
+}
+
+// WorkflowResource defines the resource implementation.
+type WorkflowResource struct {
+	client       *client.Client
+	exposeRaw    bool
+	changeReport *changeReport
+	instanceURL  string
+	markerTag    string
+}
+
+// WorkflowResourceModel describes the resource data model.
+type WorkflowResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Nodes            types.String `tfsdk:"nodes"`
+	Connections      types.String `tfsdk:"connections"`
+	Settings         types.String `tfsdk:"settings"`
+	ErrorWorkflowID  types.String `tfsdk:"error_workflow_id"`
+	Active           types.Bool   `tfsdk:"active"`
+	TagIDs           []string     `tfsdk:"tag_ids"`
+	IgnoreNodeFields []string     `tfsdk:"ignore_node_fields"`
+	VersionID        types.String `tfsdk:"version_id"`
+	NodeCount        types.Int64  `tfsdk:"node_count"`
+	TriggerCount     types.Int64  `tfsdk:"trigger_count"`
+	SelfURL          types.String `tfsdk:"self_url"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+	RawJSON          types.String `tfsdk:"raw_json"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// errorWorkflowSetting is the workflow setting holding the ID of the
+// workflow to run when an execution fails.
+const errorWorkflowSetting = "errorWorkflow"
+
+func (r *WorkflowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow"
+}
+
+func (r *WorkflowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow resource for managing n8n workflows. The JSON attributes are compared semantically, and fields n8n adds to the configured nodes, connections and settings, such as node IDs, are not reported as changes. " +
+			"Workflows can be imported using their ID: `terraform import n8ncloud_workflow.example 2tUt1wbLX592XDdX`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the workflow",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the workflow",
+				Required:            true,
+			},
+			"nodes": schema.StringAttribute{
+				MarkdownDescription: "The nodes of the workflow as a JSON array, e.g. from `jsonencode` or the `nodes` of an exported workflow",
+				Required:            true,
+				Validators: []validator.String{
+					workflowJSONValidator{array: true},
+					workflowNodesValidator{},
+				},
+			},
+			"connections": schema.StringAttribute{
+				MarkdownDescription: "The connections between the nodes of the workflow as a JSON object keyed by source node name",
+				Required:            true,
+				Validators: []validator.String{
+					workflowJSONValidator{},
+					workflowConnectionsValidator{},
+				},
+			},
+			"ignore_node_fields": schema.SetAttribute{
+				MarkdownDescription: "The fields of the nodes that n8n or the editor manage, such as `position` or `webhookId`, to leave out when comparing `nodes` with the workflow. Changes to them made outside Terraform, e.g. moving a node in the editor, are then not reported as drift. Configured values are still sent.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"settings": schema.StringAttribute{
+				MarkdownDescription: "The settings of the workflow as a JSON object, such as `{\"executionOrder\":\"v1\"}`. Defaults to the settings n8n assigns.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					workflowJSONValidator{},
+				},
+			},
+			"error_workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow to run when an execution of this workflow fails, stored in the `errorWorkflow` setting. The workflow must exist. Only managed when set.",
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the workflow is active, so that its triggers start executions. Activation fails for workflows without a trigger node. Defaults to the current state of the workflow, inactive for new workflows.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the tags attached to the workflow. Only managed when set. The provider `managed_marker_tag` is attached in addition and not listed.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"version_id": schema.StringAttribute{
+				MarkdownDescription: "The version of the workflow, which changes on every edit. Updates fail if the workflow was edited since it was last read, instead of overwriting the edit.",
+				Computed:            true,
+			},
+			"node_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of nodes in the workflow",
+				Computed:            true,
+			},
+			"trigger_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of trigger nodes in the workflow that start executions on their own, such as webhooks and schedules. The manual trigger is not counted.",
+				Computed:            true,
+			},
+			"self_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the workflow in the n8n editor",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the workflow was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the workflow was last updated",
+				Computed:            true,
+			},
+			"raw_json": schema.StringAttribute{
+				MarkdownDescription: "The full API response for the workflow as JSON, excluding static data. Only populated when the provider `expose_raw` attribute is enabled.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *WorkflowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data WorkflowResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.ErrorWorkflowID.IsNull() || data.Settings.IsNull() || data.Settings.IsUnknown() {
+		return
+	}
+
+	settings, err := decodeJSONObject(data.Settings.ValueString())
+	if err != nil {
+		// Reported by the settings validator
+		return
+	}
+
+	if _, ok := settings[errorWorkflowSetting]; ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("error_workflow_id"),
+			"Conflicting Error Workflow",
+			fmt.Sprintf("The error workflow is set both by error_workflow_id and by the %s key of settings. Set only one of them.", errorWorkflowSetting),
+		)
+	}
+}
+
+func (r *WorkflowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WorkflowResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggerCount := -1
+	if !plan.Nodes.IsUnknown() {
+		nodeCount, triggers, err := workflowNodeCounts(plan.Nodes.ValueString())
+		if err != nil {
+			// Reported by the nodes validator
+			return
+		}
+
+		triggerCount = triggers
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("node_count"), int64(nodeCount))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("trigger_count"), int64(triggers))...)
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state WorkflowResourceModel
+	var configSettings types.String
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("settings"), &configSettings)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The error workflow is stored in the settings, so unconfigured settings
+	// kept from state change with it
+	if configSettings.IsNull() && !plan.ErrorWorkflowID.Equal(state.ErrorWorkflowID) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("settings"), types.StringUnknown())...)
+	}
+
+	if state.Active.ValueBool() && !plan.Active.IsUnknown() && !plan.Active.ValueBool() && triggerCount > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("active"),
+			"Workflow Triggers Will Stop",
+			fmt.Sprintf("Deactivating workflow %s stops its %d trigger node(s), such as webhooks and schedules, so external integrations relying on them may break.", state.Name.ValueString(), triggerCount),
+		)
+	}
+}
+
+func (r *WorkflowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.exposeRaw = providerData.ExposeRaw
+	r.changeReport = providerData.ChangeReport
+	r.instanceURL = providerData.InstanceURL
+	r.markerTag = providerData.ManagedMarkerTag
+}
+
+func (r *WorkflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WorkflowResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	if !r.checkErrorWorkflow(ctx, &data, nil, &resp.Diagnostics) {
+		return
+	}
+
+	createReq, err := workflowRequest(&data, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Workflow", fmt.Sprintf("Unable to encode workflow %s: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	tflog.Debug(ctx, "Creating n8n workflow", map[string]interface{}{
+		"name": createReq.Name,
+	})
+
+	workflow, err := r.client.CreateWorkflow(ctx, createReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create workflow", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_workflow", workflow.ID)
+
+	// The workflow exists from here on, so it is saved to state even if
+	// tagging or activating it fails, which marks it as tainted.
+	r.finishWrite(ctx, &data, workflow, workflow.Tags, &resp.State, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Created n8n workflow resource")
+}
+
+func (r *WorkflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WorkflowResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	workflow, err := r.client.GetWorkflow(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		// The workflow was deleted outside of Terraform, so plan to recreate it
+		tflog.Warn(ctx, "n8n workflow not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+
+	// Archiving is how the editor deletes workflows, so treat archived
+	// workflows as deleted
+	if workflow.IsArchived {
+		tflog.Warn(ctx, "n8n workflow archived, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err := setWorkflowAttributes(&data, workflow, r.instanceURL, r.markerTag, r.exposeRaw); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read workflow response, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state WorkflowResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	if !r.checkErrorWorkflow(ctx, &data, &state, &resp.Diagnostics) {
+		return
+	}
+
+	current, err := r.client.GetWorkflow(ctx, state.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+	workflow := current
+
+	// Refuse to overwrite edits made since the workflow was last read, e.g.
+	// in the editor between plan and apply
+	if state.VersionID.ValueString() != "" && current.VersionID != "" && current.VersionID != state.VersionID.ValueString() {
+		resp.Diagnostics.AddError(
+			"Workflow Changed Externally",
+			fmt.Sprintf("Workflow %s was edited outside of Terraform since it was last read: its version changed from %s to %s. "+
+				"Run terraform plan again to review the changes before applying, so that the edit is not overwritten.",
+				state.ID.ValueString(), state.VersionID.ValueString(), current.VersionID),
+		)
+		return
+	}
+
+	// The active state and tags have their own endpoints, so the workflow
+	// itself is only replaced when its content changes
+	if workflowContentChanged(&data, &state) {
+		updateReq, err := workflowRequest(&data, &state)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Workflow", fmt.Sprintf("Unable to encode workflow %s: %s", data.Name.ValueString(), err))
+			return
+		}
+
+		tflog.Debug(ctx, "Updating n8n workflow", map[string]interface{}{
+			"id":   state.ID.ValueString(),
+			"name": updateReq.Name,
+		})
+
+		workflow, err = r.client.UpdateWorkflow(ctx, state.ID.ValueString(), updateReq)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "update workflow", err)
+			return
+		}
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_workflow", state.ID.ValueString())
+
+	// The update response may not include the tags, so compare them with
+	// the tags read before it
+	r.finishWrite(ctx, &data, workflow, current.Tags, &resp.State, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Updated n8n workflow resource")
+}
+
+func (r *WorkflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WorkflowResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+	defer cancel()
+
+	// Versions that archive workflows refuse to remove active ones, so
+	// deactivate the workflow first
+	if r.client.SupportsWorkflowArchival() && data.Active.ValueBool() {
+		if _, err := r.client.DeactivateWorkflow(ctx, data.ID.ValueString()); err != nil && !client.IsNotFound(err) {
+			addClientError(&resp.Diagnostics, "deactivate workflow", err)
+			return
+		}
+	}
+
+	err := r.client.DeleteWorkflow(ctx, data.ID.ValueString())
+	if err != nil && !client.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "delete workflow", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_workflow", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n workflow resource")
+}
+
+func (r *WorkflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// checkErrorWorkflow reports an error and returns false when a newly set
+// error_workflow_id does not reference an existing workflow. n8n accepts any
+// value, so a typo would otherwise only surface when an execution fails.
+func (r *WorkflowResource) checkErrorWorkflow(ctx context.Context, data, state *WorkflowResourceModel, diags *diag.Diagnostics) bool {
+	if data.ErrorWorkflowID.IsNull() || data.ErrorWorkflowID.IsUnknown() {
+		return true
+	}
+	if state != nil && data.ErrorWorkflowID.Equal(state.ErrorWorkflowID) {
+		return true
+	}
+
+	_, err := r.client.GetWorkflow(ctx, data.ErrorWorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("error_workflow_id"),
+			"Error Workflow Not Found",
+			fmt.Sprintf("Workflow with ID %q not found, so it cannot be used as the error workflow", data.ErrorWorkflowID.ValueString()),
+		)
+		return false
+	}
+	if err != nil {
+		addClientError(diags, "read error workflow", err)
+		return false
+	}
+
+	return true
+}
+
+// finishWrite applies the tags and active state of data to a workflow just
+// created or updated, given the tags it currently carries, then reads it
+// back into state. The workflow is saved to state even when a request
+// fails.
+func (r *WorkflowResource) finishWrite(ctx context.Context, data *WorkflowResourceModel, workflow *client.Workflow, tags []client.Tag, state *tfsdk.State, diags *diag.Diagnostics) {
+	action, err := r.applyTagsAndActive(ctx, data, workflow, tags)
+	if err == nil {
+		// Read the workflow back for the version and tags after the
+		// follow-up requests
+		action = "read workflow"
+
+		var refreshed *client.Workflow
+		if refreshed, err = r.client.GetWorkflow(ctx, workflow.ID); err == nil {
+			workflow = refreshed
+		}
+	}
+	if err != nil {
+		addClientError(diags, action, err)
+	}
+
+	if err := setWorkflowAttributes(data, workflow, r.instanceURL, r.markerTag, r.exposeRaw); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read workflow response, got error: %s", err))
+	}
+
+	diags.Append(state.Set(ctx, data)...)
+}
+
+// applyTagsAndActive updates the tags and active state of workflow to match
+// data, returning the failed action on error. Tags are only replaced when
+// they differ from tags.
+func (r *WorkflowResource) applyTagsAndActive(ctx context.Context, data *WorkflowResourceModel, workflow *client.Workflow, tags []client.Tag) (string, error) {
+	var marker *client.Tag
+	if r.markerTag != "" {
+		tag, err := ensureMarkerTag(ctx, r.client, r.markerTag)
+		if err != nil {
+			return "ensure managed marker tag", err
+		}
+		marker = tag
+	}
+
+	if tagIDs, managed := desiredWorkflowTagIDs(data.TagIDs, tags, marker); managed && !slices.Equal(tagIDs, workflowTagIDs(tags)) {
+		if _, err := r.client.UpdateWorkflowTags(ctx, workflow.ID, tagIDs); err != nil {
+			return "update workflow tags", err
+		}
+	}
+
+	if data.Active.IsUnknown() || data.Active.ValueBool() == workflow.Active {
+		return "", nil
+	}
+
+	if data.Active.ValueBool() {
+		_, err := r.client.ActivateWorkflow(ctx, workflow.ID)
+		return "activate workflow", err
+	}
+
+	_, err := r.client.DeactivateWorkflow(ctx, workflow.ID)
+	return "deactivate workflow", err
+}
+
+// workflowRequest builds the request replacing the content of the workflow
+// in data. The configured error workflow is merged into the settings, and
+// one removed since state is dropped from them.
+func workflowRequest(data, state *WorkflowResourceModel) (*client.WorkflowRequest, error) {
+	settings := map[string]interface{}{}
+	if !data.Settings.IsNull() && !data.Settings.IsUnknown() {
+		object, err := decodeJSONObject(data.Settings.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("invalid settings: %w", err)
+		}
+		settings = object
+	}
+
+	if !data.ErrorWorkflowID.IsNull() {
+		settings[errorWorkflowSetting] = data.ErrorWorkflowID.ValueString()
+	} else if state != nil && !state.ErrorWorkflowID.IsNull() {
+		delete(settings, errorWorkflowSetting)
+	}
+
+	encodedSettings, err := encodeJSON(settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+
+	return &client.WorkflowRequest{
+		Name:        data.Name.ValueString(),
+		Nodes:       json.RawMessage(data.Nodes.ValueString()),
+		Connections: json.RawMessage(data.Connections.ValueString()),
+		Settings:    json.RawMessage(encodedSettings),
+	}, nil
+}
+
+// workflowContentChanged reports whether the plan changes the parts of the
+// workflow replaced by UpdateWorkflow.
+func workflowContentChanged(plan, state *WorkflowResourceModel) bool {
+	return !plan.Name.Equal(state.Name) ||
+		!plan.Nodes.Equal(state.Nodes) ||
+		!plan.Connections.Equal(state.Connections) ||
+		!(plan.Settings.IsUnknown() || plan.Settings.Equal(state.Settings)) ||
+		!plan.ErrorWorkflowID.Equal(state.ErrorWorkflowID)
+}
+
+// desiredWorkflowTagIDs returns the IDs of the tags the workflow should
+// carry, sorted, and whether they are managed at all. Unmanaged tags are
+// kept as they are, apart from the marker tag being added.
+func desiredWorkflowTagIDs(configured []string, current []client.Tag, marker *client.Tag) ([]string, bool) {
+	if configured == nil && marker == nil {
+		return nil, false
+	}
+
+	ids := map[string]bool{}
+	if configured != nil {
+		for _, id := range configured {
+			ids[id] = true
+		}
+	} else {
+		for _, tag := range current {
+			ids[tag.ID] = true
+		}
+	}
+	if marker != nil {
+		ids[marker.ID] = true
+	}
+
+	return sortedKeys(ids), true
+}
+
+// workflowTagIDs returns the sorted IDs of tags.
+func workflowTagIDs(tags []client.Tag) []string {
+	ids := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		ids[tag.ID] = true
+	}
+
+	return sortedKeys(ids)
+}
+
+// ensureMarkerTag returns the tag with the given name, creating it when it
+// does not exist yet.
+func ensureMarkerTag(ctx context.Context, c *client.Client, name string) (*client.Tag, error) {
+	tag, err := c.GetTagByName(ctx, name)
+	if client.IsNotFound(err) {
+		return c.CreateTag(ctx, name)
+	}
+
+	return tag, err
+}
+
+// workflowSensitiveFields lists the workflow response fields that must never
+// be copied into raw_json. Static data holds state kept by trigger nodes,
+// which may include tokens.
+var workflowSensitiveFields = []string{"staticData"}
+
+// setWorkflowAttributes updates data from a workflow read from the API. The
+// JSON attributes keep their prior value while the API value contains it.
+// The marker tag is left out of tag_ids unless it was listed there.
+func setWorkflowAttributes(data *WorkflowResourceModel, workflow *client.Workflow, instanceURL, markerTag string, exposeRaw bool) error {
+	data.ID = types.StringValue(workflow.ID)
+	data.Name = types.StringValue(workflow.Name)
+	data.Active = types.BoolValue(workflow.Active)
+	data.VersionID = types.StringValue(workflow.VersionID)
+	data.CreatedAt = types.StringValue(workflow.CreatedAt.Format(time.RFC3339Nano))
+	data.UpdatedAt = types.StringValue(workflow.UpdatedAt.Format(time.RFC3339Nano))
+	data.SelfURL = types.StringValue(workflowURL(instanceURL, workflow.ID))
+
+	var err error
+	if data.Nodes, err = workflowNodesStateValue(data.Nodes, workflow.Nodes, data.IgnoreNodeFields); err != nil {
+		return fmt.Errorf("invalid nodes: %w", err)
+	}
+	if data.Connections, err = workflowJSONStateValue(data.Connections, workflow.Connections); err != nil {
+		return fmt.Errorf("invalid connections: %w", err)
+	}
+	if data.Settings, err = workflowJSONStateValue(data.Settings, workflow.Settings); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	if !data.ErrorWorkflowID.IsNull() {
+		data.ErrorWorkflowID = types.StringNull()
+		if settings, err := decodeJSONObject(string(workflow.Settings)); err == nil {
+			if id, ok := settings[errorWorkflowSetting].(string); ok && id != "" {
+				data.ErrorWorkflowID = types.StringValue(id)
+			}
+		}
+	}
+
+	if data.TagIDs != nil {
+		listed := make(map[string]bool, len(data.TagIDs))
+		for _, id := range data.TagIDs {
+			listed[id] = true
+		}
+
+		tagIDs := []string{}
+		for _, tag := range workflow.Tags {
+			if markerTag != "" && tag.Name == markerTag && !listed[tag.ID] {
+				continue
+			}
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		data.TagIDs = tagIDs
+	}
+
+	nodeCount, triggerCount := 0, 0
+	if !data.Nodes.IsNull() {
+		if nodeCount, triggerCount, err = workflowNodeCounts(data.Nodes.ValueString()); err != nil {
+			return fmt.Errorf("invalid nodes: %w", err)
+		}
+	}
+	data.NodeCount = types.Int64Value(int64(nodeCount))
+	data.TriggerCount = types.Int64Value(int64(triggerCount))
+
+	if data.RawJSON, err = rawJSONValue(exposeRaw, workflow.Raw, workflowSensitiveFields); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// workflowJSONStateValue returns the state value of a JSON attribute from
+// its prior value and the value returned by the API. The prior value is
+// kept while the API value contains it, so that fields n8n adds are not
+// reported as changes; otherwise the normalized API value is used.
+func workflowJSONStateValue(prior types.String, apiValue json.RawMessage) (types.String, error) {
+	if len(apiValue) == 0 || string(apiValue) == "null" {
+		if prior.IsUnknown() {
+			return types.StringNull(), nil
+		}
+		return prior, nil
+	}
+
+	value, err := decodeJSON(string(apiValue))
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorValue, err := decodeJSON(prior.ValueString())
+		if err == nil && jsonContains(value, priorValue) {
+			return prior, nil
+		}
+	}
+
+	normalized, err := encodeJSON(value)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	return types.StringValue(normalized), nil
+}
+
+// workflowNodesStateValue returns the state value of the nodes like
+// workflowJSONStateValue, but leaves the ignored fields of each node out of
+// the comparison, so that the prior value is also kept when only they
+// differ.
+func workflowNodesStateValue(prior types.String, apiValue json.RawMessage, ignoredFields []string) (types.String, error) {
+	if len(ignoredFields) > 0 && !prior.IsNull() && !prior.IsUnknown() {
+		value, err := decodeJSON(string(apiValue))
+		priorValue, priorErr := decodeJSON(prior.ValueString())
+		if err == nil && priorErr == nil && jsonContains(withoutNodeFields(value, ignoredFields), withoutNodeFields(priorValue, ignoredFields)) {
+			return prior, nil
+		}
+	}
+
+	return workflowJSONStateValue(prior, apiValue)
+}
+
+// withoutNodeFields returns a copy of the nodes without the given fields.
+// Values other than arrays of objects are returned as they are.
+func withoutNodeFields(nodes interface{}, fields []string) interface{} {
+	array, ok := nodes.([]interface{})
+	if !ok {
+		return nodes
+	}
+
+	result := make([]interface{}, len(array))
+	for i, node := range array {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			result[i] = node
+			continue
+		}
+
+		copied := make(map[string]interface{}, len(object))
+		for key, value := range object {
+			copied[key] = value
+		}
+		for _, field := range fields {
+			delete(copied, field)
+		}
+		result[i] = copied
+	}
+
+	return result
+}
+
+// workflowURL returns the URL of a workflow in the editor of the instance.
+func workflowURL(instanceURL, id string) string {
+	return strings.TrimRight(instanceURL, "/") + "/workflow/" + id
+}
+
+var _ validator.String = workflowJSONValidator{}
+
+// workflowJSONValidator validates that a string is a JSON object, or a JSON
+// array when array is set.
+type workflowJSONValidator struct {
+	array bool
+}
+
+func (v workflowJSONValidator) Description(ctx context.Context) string {
+	if v.array {
+		return "value must be a JSON array"
+	}
+	return "value must be a JSON object"
+}
+
+func (v workflowJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v workflowJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value, err := decodeJSON(req.ConfigValue.ValueString())
+	if err == nil {
+		switch value.(type) {
+		case []interface{}:
+			if v.array {
+				return
+			}
+		case map[string]interface{}:
+			if !v.array {
+				return
+			}
+		}
+	}
+
+	kind := "object"
+	if v.array {
+		kind = "array"
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Workflow JSON",
+		fmt.Sprintf("The value of %s must be a JSON %s, such as the output of jsonencode.", req.Path, kind),
+	)
+}
+
+var _ validator.String = workflowNodesValidator{}
+
+// workflowNodesValidator validates that each node of a JSON array of nodes
+// is an object with the name and type n8n requires, so mistakes are
+// reported at plan time instead of as a 400 response at apply time. Values
+// that are not a JSON array are left to workflowJSONValidator.
+type workflowNodesValidator struct{}
+
+func (v workflowNodesValidator) Description(ctx context.Context) string {
+	return "each node must be a JSON object with a name and a type"
+}
+
+func (v workflowNodesValidator) MarkdownDescription(ctx context.Context) string {
+	return "each node must be a JSON object with a `name` and a `type`"
+}
+
+func (v workflowNodesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value, err := decodeJSON(req.ConfigValue.ValueString())
+	if err != nil {
+		return
+	}
+	nodes, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+
+	for i, value := range nodes {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Workflow Node",
+				fmt.Sprintf("The node at index %d of %s must be a JSON object, got %s.", i, req.Path, jsonKind(value)),
+			)
+			continue
+		}
+
+		name, _ := node["name"].(string)
+		label := fmt.Sprintf("The node at index %d", i)
+		if name != "" {
+			label = fmt.Sprintf("The node %q at index %d", name, i)
+		}
+
+		for _, key := range []string{"name", "type"} {
+			if nodeValue, _ := node[key].(string); nodeValue == "" {
+				resp.Diagnostics.AddAttributeError(
+					req.Path,
+					"Invalid Workflow Node",
+					fmt.Sprintf("%s of %s has no %s. Each node needs a non-empty string name and type, such as n8n-nodes-base.set.", label, req.Path, key),
+				)
+			}
+		}
+	}
+}
+
+var _ validator.String = workflowConnectionsValidator{}
+
+// workflowConnectionsValidator validates that a JSON object of connections
+// has the shape n8n uses, keyed by source node, then connection type and
+// output index. Values that are not a JSON object are left to
+// workflowJSONValidator.
+type workflowConnectionsValidator struct{}
+
+func (v workflowConnectionsValidator) Description(ctx context.Context) string {
+	return "connections must map each source node to its outputs by connection type"
+}
+
+func (v workflowConnectionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v workflowConnectionsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	connections, err := decodeJSONObject(req.ConfigValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	for _, source := range sortedKeys(connections) {
+		if _, err := connectionTargets(connections[source]); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Workflow Connections",
+				fmt.Sprintf("The connections of node %q in %s are invalid: %s. Connections look like {\"Source\": {\"main\": [[{\"node\": \"Target\", \"type\": \"main\", \"index\": 0}]]}}.", source, req.Path, err),
+			)
+		}
+	}
+}
+
+// jsonKind names the kind of a decoded JSON value for diagnostics.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
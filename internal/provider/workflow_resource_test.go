@@ -0,0 +1,893 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAccWorkflowResource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkflowResourceConfig(name, "First"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name),
+					),
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow.test",
+						tfjsonpath.New("active"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow.test",
+						tfjsonpath.New("node_count"),
+						knownvalue.Int64Exact(2),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "n8ncloud_workflow.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The imported JSON is normalized, while the configured
+				// JSON is kept as written.
+				ImportStateVerifyIgnore: []string{"nodes", "connections"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkflowResourceConfig(name+"-renamed", "Second"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact(name+"-renamed"),
+					),
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkflowResourceConfig(name, setName string) string {
+	return fmt.Sprintf(`
+resource "n8ncloud_workflow" "test" {
+  name = %[1]q
+
+  nodes = jsonencode([
+    {
+      name        = "Start"
+      type        = "n8n-nodes-base.manualTrigger"
+      typeVersion = 1
+      position    = [0, 0]
+      parameters  = {}
+    },
+    {
+      name        = %[2]q
+      type        = "n8n-nodes-base.noOp"
+      typeVersion = 1
+      position    = [200, 0]
+      parameters  = {}
+    },
+  ])
+
+  connections = jsonencode({
+    Start = {
+      main = [[{ node = %[2]q, type = "main", index = 0 }]]
+    }
+  })
+}
+`, name, setName)
+}
+
+func TestAccWorkflowResource_invalidNodes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "n8ncloud_workflow" "test" {
+  name        = "invalid"
+  nodes       = jsonencode({ name = "Start" })
+  connections = jsonencode({})
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a JSON array`),
+			},
+		},
+	})
+}
+
+// fakeWorkflow is a workflow stored by fakeWorkflowServer.
+type fakeWorkflow struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Active      bool            `json:"active"`
+	VersionID   string          `json:"versionId"`
+	Nodes       json.RawMessage `json:"nodes"`
+	Connections json.RawMessage `json:"connections"`
+	Settings    json.RawMessage `json:"settings"`
+	Tags        []client.Tag    `json:"tags"`
+	CreatedAt   string          `json:"createdAt"`
+	UpdatedAt   string          `json:"updatedAt"`
+}
+
+// fakeWorkflowServer is an in-memory implementation of the workflow and tag
+// endpoints. Like n8n, it adds IDs to created nodes and a version to every
+// edit.
+type fakeWorkflowServer struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	nextID    int
+	version   string
+	workflows map[string]*fakeWorkflow
+	tags      []client.Tag
+	requests  []string
+
+	// strictTags makes tag updates fail like n8n does for tags that do not
+	// exist. Otherwise any tag ID is accepted.
+	strictTags bool
+	bodies     map[string]map[string]interface{}
+}
+
+func newFakeWorkflowServer(t *testing.T) *fakeWorkflowServer {
+	return &fakeWorkflowServer{
+		t:         t,
+		workflows: map[string]*fakeWorkflow{},
+		bodies:    map[string]map[string]interface{}{},
+	}
+}
+
+// add stores a workflow and returns it.
+func (s *fakeWorkflowServer) add(name string, nodes, connections string) *fakeWorkflow {
+	s.nextID++
+	workflow := &fakeWorkflow{
+		ID:          fmt.Sprintf("wf%d", s.nextID),
+		Name:        name,
+		VersionID:   fmt.Sprintf("v%d", s.nextID),
+		Nodes:       json.RawMessage(nodes),
+		Connections: json.RawMessage(connections),
+		Settings:    json.RawMessage(`{"executionOrder":"v1"}`),
+		Tags:        []client.Tag{},
+		CreatedAt:   "2024-01-01T00:00:00Z",
+		UpdatedAt:   "2024-01-01T00:00:00Z",
+	}
+	s.workflows[workflow.ID] = workflow
+
+	return workflow
+}
+
+// edit changes the version of a workflow, as an edit in the editor does.
+func (s *fakeWorkflowServer) edit(workflow *fakeWorkflow) {
+	s.nextID++
+	workflow.VersionID = fmt.Sprintf("v%d", s.nextID)
+	workflow.UpdatedAt = "2024-02-01T00:00:00Z"
+}
+
+// reset forgets the recorded requests.
+func (s *fakeWorkflowServer) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.bodies = map[string]map[string]interface{}{}
+}
+
+func (s *fakeWorkflowServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/api/v1")
+	s.requests = append(s.requests, route)
+
+	var body interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	if object, ok := body.(map[string]interface{}); ok {
+		s.bodies[route] = object
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1"), "/"), "/")
+
+	switch {
+	case route == "GET /rest/settings":
+		_, _ = fmt.Fprintf(w, `{"data":{"versionCli":%q}}`, s.version)
+	case route == "GET /tags":
+		_ = json.NewEncoder(w).Encode(client.TagsResponse{Data: s.tags})
+	case route == "POST /tags":
+		name, _ := body.(map[string]interface{})["name"].(string)
+		tag := client.Tag{ID: fmt.Sprintf("tag-%s", name), Name: name}
+		s.tags = append(s.tags, tag)
+		_ = json.NewEncoder(w).Encode(tag)
+	case len(parts) == 2 && parts[0] == "tags" && r.Method == http.MethodGet && s.findTag(parts[1]) != nil:
+		_ = json.NewEncoder(w).Encode(s.findTag(parts[1]))
+	case route == "POST /workflows":
+		request, _ := body.(map[string]interface{})
+		nodes, _ := request["nodes"].([]interface{})
+		for i, node := range nodes {
+			node.(map[string]interface{})["id"] = fmt.Sprintf("node-%d", i)
+		}
+		encodedNodes, _ := json.Marshal(nodes)
+		encodedConnections, _ := json.Marshal(request["connections"])
+		name, _ := request["name"].(string)
+		workflow := s.add(name, string(encodedNodes), string(encodedConnections))
+		workflow.Settings, _ = json.Marshal(request["settings"])
+		_ = json.NewEncoder(w).Encode(workflow)
+	case len(parts) >= 2 && parts[0] == "workflows" && s.workflows[parts[1]] != nil:
+		workflow := s.workflows[parts[1]]
+		switch {
+		case len(parts) == 2 && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(workflow)
+		case len(parts) == 2 && r.Method == http.MethodPut:
+			request, _ := body.(map[string]interface{})
+			workflow.Name, _ = request["name"].(string)
+			workflow.Nodes, _ = json.Marshal(request["nodes"])
+			workflow.Connections, _ = json.Marshal(request["connections"])
+			workflow.Settings, _ = json.Marshal(request["settings"])
+			s.edit(workflow)
+			// n8n leaves the tags out of the update response
+			response := *workflow
+			response.Tags = nil
+			_ = json.NewEncoder(w).Encode(response)
+		case len(parts) == 2 && r.Method == http.MethodDelete:
+			delete(s.workflows, workflow.ID)
+			_ = json.NewEncoder(w).Encode(workflow)
+		case len(parts) == 3 && parts[2] == "activate":
+			workflow.Active = true
+			_ = json.NewEncoder(w).Encode(workflow)
+		case len(parts) == 3 && parts[2] == "deactivate":
+			workflow.Active = false
+			_ = json.NewEncoder(w).Encode(workflow)
+		case len(parts) == 3 && parts[2] == "tags" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(workflow.Tags)
+		case len(parts) == 3 && parts[2] == "tags" && r.Method == http.MethodPut:
+			tags := []client.Tag{}
+			references, _ := body.([]interface{})
+			for _, reference := range references {
+				id, _ := reference.(map[string]interface{})["id"].(string)
+				if s.strictTags && s.findTag(id) == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message":"Some tags not found"}`))
+					return
+				}
+				tags = append(tags, client.Tag{ID: id, Name: strings.TrimPrefix(id, "tag-")})
+			}
+			workflow.Tags = tags
+			_ = json.NewEncoder(w).Encode(workflow.Tags)
+		default:
+			s.t.Errorf("unexpected request %s", route)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+// findTag returns the stored tag with the given ID, or nil.
+func (s *fakeWorkflowServer) findTag(id string) *client.Tag {
+	for i := range s.tags {
+		if s.tags[i].ID == id {
+			return &s.tags[i]
+		}
+	}
+
+	return nil
+}
+
+// hasRequest reports whether a request for route was received.
+func (s *fakeWorkflowServer) hasRequest(route string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, request := range s.requests {
+		if request == route {
+			return true
+		}
+	}
+
+	return false
+}
+
+const testWorkflowNodes = `[{"name":"Webhook","type":"n8n-nodes-base.webhook","parameters":{"path":"hook"}},{"name":"Set","type":"n8n-nodes-base.set","parameters":{}}]`
+
+const testWorkflowConnections = `{"Webhook":{"main":[[{"index":0,"node":"Set","type":"main"}]]}}`
+
+// workflowTestState returns the state of an existing workflow, with values
+// overriding the attributes read from the server.
+func workflowTestState(t *testing.T, r *WorkflowResource, workflow *fakeWorkflow, values map[string]tftypes.Value) (tfsdk.State, tftypes.Value) {
+	t.Helper()
+
+	state := map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, workflow.ID),
+		"name":        tftypes.NewValue(tftypes.String, workflow.Name),
+		"nodes":       tftypes.NewValue(tftypes.String, string(workflow.Nodes)),
+		"connections": tftypes.NewValue(tftypes.String, string(workflow.Connections)),
+		"settings":    tftypes.NewValue(tftypes.String, string(workflow.Settings)),
+		"active":      tftypes.NewValue(tftypes.Bool, workflow.Active),
+		"version_id":  tftypes.NewValue(tftypes.String, workflow.VersionID),
+		"created_at":  tftypes.NewValue(tftypes.String, workflow.CreatedAt),
+		"self_url":    tftypes.NewValue(tftypes.String, "https://example.app.n8n.cloud/workflow/"+workflow.ID),
+	}
+	for name, value := range values {
+		state[name] = value
+	}
+
+	workflowSchema, raw := resourceTestValue(t, r, state)
+	return tfsdk.State{Schema: workflowSchema, Raw: raw}, raw
+}
+
+// updateTestWorkflow runs Update from state to a plan with the given
+// attributes changed.
+func updateTestWorkflow(t *testing.T, r *WorkflowResource, workflow *fakeWorkflow, changes map[string]tftypes.Value) *fwresource.UpdateResponse {
+	t.Helper()
+
+	state, _ := workflowTestState(t, r, workflow, nil)
+	plan, _ := workflowTestState(t, r, workflow, changes)
+
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		State: state,
+	}
+	resp := &fwresource.UpdateResponse{State: state}
+
+	r.Update(context.Background(), req, resp)
+
+	return resp
+}
+
+func TestWorkflowResourceCreate(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	r := &WorkflowResource{
+		client:      newTestClient(t, server.ServeHTTP),
+		instanceURL: "https://example.app.n8n.cloud",
+		markerTag:   "managed-by-terraform",
+	}
+	server.tags = []client.Tag{{ID: "tag-billing", Name: "billing"}}
+
+	workflowSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":        tftypes.NewValue(tftypes.String, "Orders"),
+		"nodes":       tftypes.NewValue(tftypes.String, testWorkflowNodes),
+		"connections": tftypes.NewValue(tftypes.String, testWorkflowConnections),
+		"settings":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"active":      tftypes.NewValue(tftypes.Bool, true),
+		"tag_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "tag-billing"),
+		}),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: workflowSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: workflowSchema, Raw: plan}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state WorkflowResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if state.ID.ValueString() != "wf1" {
+		t.Errorf("expected id wf1, got %s", state.ID)
+	}
+	if state.Nodes.ValueString() != testWorkflowNodes {
+		t.Errorf("expected the configured nodes to be kept despite the added node IDs, got %s", state.Nodes)
+	}
+	if state.Settings.ValueString() != `{}` {
+		t.Errorf("expected the default settings, got %s", state.Settings)
+	}
+	if !state.Active.ValueBool() || !server.workflows["wf1"].Active {
+		t.Error("expected the workflow to be activated")
+	}
+	if state.NodeCount.ValueInt64() != 2 || state.TriggerCount.ValueInt64() != 1 {
+		t.Errorf("expected 2 nodes with 1 trigger, got %s nodes with %s triggers", state.NodeCount, state.TriggerCount)
+	}
+	if state.SelfURL.ValueString() != "https://example.app.n8n.cloud/workflow/wf1" {
+		t.Errorf("unexpected self_url %s", state.SelfURL)
+	}
+	if state.VersionID.ValueString() != "v1" {
+		t.Errorf("expected version_id v1, got %s", state.VersionID)
+	}
+
+	// The marker tag is created and attached, but not reported in tag_ids
+	var tagNames []string
+	for _, tag := range server.workflows["wf1"].Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	sort.Strings(tagNames)
+	if want := []string{"billing", "managed-by-terraform"}; !reflect.DeepEqual(tagNames, want) {
+		t.Errorf("expected tags %v on the workflow, got %v", want, tagNames)
+	}
+	if want := []string{"tag-billing"}; !reflect.DeepEqual(state.TagIDs, want) {
+		t.Errorf("expected tag_ids %v, got %v", want, state.TagIDs)
+	}
+}
+
+func TestWorkflowResourceCreate_errorWorkflowNotFound(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	workflowSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"name":              tftypes.NewValue(tftypes.String, "Orders"),
+		"nodes":             tftypes.NewValue(tftypes.String, testWorkflowNodes),
+		"connections":       tftypes.NewValue(tftypes.String, testWorkflowConnections),
+		"error_workflow_id": tftypes.NewValue(tftypes.String, "missing"),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: workflowSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: workflowSchema, Raw: plan}}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error Workflow Not Found" {
+		t.Fatalf("expected an Error Workflow Not Found error, got: %v", resp.Diagnostics)
+	}
+	if server.hasRequest("POST /workflows") {
+		t.Error("expected no workflow to be created")
+	}
+}
+
+func TestWorkflowResourceCreate_errorWorkflow(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	handler := server.add("Errors", `[]`, `{}`)
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	workflowSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"name":              tftypes.NewValue(tftypes.String, "Orders"),
+		"nodes":             tftypes.NewValue(tftypes.String, testWorkflowNodes),
+		"connections":       tftypes.NewValue(tftypes.String, testWorkflowConnections),
+		"settings":          tftypes.NewValue(tftypes.String, `{"executionOrder":"v1"}`),
+		"error_workflow_id": tftypes.NewValue(tftypes.String, handler.ID),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: workflowSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: workflowSchema, Raw: plan}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	settings := server.bodies["POST /workflows"]["settings"]
+	if want := map[string]interface{}{"executionOrder": "v1", "errorWorkflow": handler.ID}; !reflect.DeepEqual(settings, want) {
+		t.Errorf("expected settings %v to be sent, got %v", want, settings)
+	}
+
+	var state WorkflowResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.Settings.ValueString() != `{"executionOrder":"v1"}` {
+		t.Errorf("expected the configured settings to be kept, got %s", state.Settings)
+	}
+	if state.ErrorWorkflowID.ValueString() != handler.ID {
+		t.Errorf("expected error_workflow_id %s, got %s", handler.ID, state.ErrorWorkflowID)
+	}
+}
+
+func TestWorkflowResourceUpdate_nameOnly(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Active = true
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := updateTestWorkflow(t, r, workflow, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "Orders v2"),
+		"version_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	body, ok := server.bodies["PUT /workflows/"+workflow.ID]
+	if !ok {
+		t.Fatalf("expected the workflow to be updated, got requests %v", server.requests)
+	}
+
+	var fields []string
+	for field := range body {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	if want := []string{"connections", "name", "nodes", "settings"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("expected the update to only send %v, got %v", want, fields)
+	}
+
+	for _, route := range []string{"PUT /workflows/wf1/tags", "POST /workflows/wf1/activate", "POST /workflows/wf1/deactivate"} {
+		if server.hasRequest(route) {
+			t.Errorf("expected no %s request for a name change", route)
+		}
+	}
+
+	var state WorkflowResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.Name.ValueString() != "Orders v2" || state.VersionID.ValueString() == "v1" {
+		t.Errorf("expected the renamed workflow with a new version, got %s at %s", state.Name, state.VersionID)
+	}
+}
+
+func TestWorkflowResourceUpdate_activeOnly(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Active = true
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := updateTestWorkflow(t, r, workflow, map[string]tftypes.Value{
+		"active": tftypes.NewValue(tftypes.Bool, false),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.hasRequest("PUT /workflows/" + workflow.ID) {
+		t.Error("expected no workflow update for an active change")
+	}
+	if !server.hasRequest("POST /workflows/"+workflow.ID+"/deactivate") || workflow.Active {
+		t.Error("expected the workflow to be deactivated")
+	}
+}
+
+func TestWorkflowResourceUpdate_changedExternally(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	state, _ := workflowTestState(t, r, workflow, nil)
+	plan, _ := workflowTestState(t, r, workflow, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "Orders v2"),
+	})
+
+	// The workflow is edited in the editor between plan and apply
+	server.edit(workflow)
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw}, State: state}, resp)
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Workflow Changed Externally" {
+		t.Fatalf("expected a Workflow Changed Externally error, got: %v", resp.Diagnostics)
+	}
+	if server.hasRequest("PUT /workflows/" + workflow.ID) {
+		t.Error("expected the edited workflow not to be overwritten")
+	}
+}
+
+func TestWorkflowResourceRead_import(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", `[{"type":"n8n-nodes-base.webhook","name":"Webhook"}]`, `{}`)
+	workflow.Active = true
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	// Import only sets the ID
+	workflowSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.String, workflow.ID),
+	})
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: workflowSchema, Raw: prior}}
+	r.Read(context.Background(), fwresource.ReadRequest{State: tfsdk.State{Schema: workflowSchema, Raw: prior}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state WorkflowResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+
+	if !state.Active.ValueBool() {
+		t.Error("expected the imported workflow to be recorded as active")
+	}
+	if state.Name.ValueString() != "Orders" {
+		t.Errorf("expected name Orders, got %s", state.Name)
+	}
+	if state.Nodes.ValueString() != `[{"name":"Webhook","type":"n8n-nodes-base.webhook"}]` {
+		t.Errorf("expected the normalized nodes, got %s", state.Nodes)
+	}
+	if state.TriggerCount.ValueInt64() != 1 {
+		t.Errorf("expected 1 trigger, got %s", state.TriggerCount)
+	}
+	if state.TagIDs != nil || !state.ErrorWorkflowID.IsNull() {
+		t.Errorf("expected unmanaged tag_ids and error_workflow_id to stay null, got %v and %s", state.TagIDs, state.ErrorWorkflowID)
+	}
+}
+
+func TestWorkflowResourceRead_detectsChangedNodes(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	r := &WorkflowResource{client: newTestClient(t, server.ServeHTTP)}
+
+	state, prior := workflowTestState(t, r, workflow, nil)
+
+	// The node parameters are changed in the editor
+	workflow.Nodes = json.RawMessage(strings.Replace(testWorkflowNodes, `"hook"`, `"changed"`, 1))
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: state.Schema, Raw: prior}}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var nodes string
+	if diags := resp.State.GetAttribute(context.Background(), path.Root("nodes"), &nodes); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if !strings.Contains(nodes, `"changed"`) {
+		t.Errorf("expected the changed nodes in state, got %s", nodes)
+	}
+}
+
+func TestWorkflowResourceDelete_deactivatesOnArchivalVersions(t *testing.T) {
+	for name, version := range map[string]string{"with archival": "1.94.0", "without archival": "1.93.0"} {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeWorkflowServer(t)
+			server.version = version
+			workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+			workflow.Active = true
+
+			c := newTestClient(t, server.ServeHTTP)
+			if _, err := c.DetectVersion(context.Background()); err != nil {
+				t.Fatalf("unexpected error detecting version: %s", err)
+			}
+			r := &WorkflowResource{client: c}
+
+			state, _ := workflowTestState(t, r, workflow, nil)
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if server.workflows[workflow.ID] != nil {
+				t.Error("expected the workflow to be deleted")
+			}
+			if got, want := server.hasRequest("POST /workflows/"+workflow.ID+"/deactivate"), version == "1.94.0"; got != want {
+				t.Errorf("expected deactivation before delete to be %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestWorkflowResourceModifyPlan_deactivationWarning(t *testing.T) {
+	testCases := map[string]struct {
+		nodes       string
+		wantWarning bool
+	}{
+		"with trigger": {
+			nodes:       testWorkflowNodes,
+			wantWarning: true,
+		},
+		"manual trigger only": {
+			nodes: `[{"name":"Start","type":"n8n-nodes-base.manualTrigger"}]`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := &WorkflowResource{}
+			workflow := &fakeWorkflow{ID: "wf1", Name: "Orders", Active: true, Nodes: json.RawMessage(tc.nodes), Connections: json.RawMessage(`{}`), Settings: json.RawMessage(`{}`)}
+
+			state, _ := workflowTestState(t, r, workflow, nil)
+			plan, _ := workflowTestState(t, r, workflow, map[string]tftypes.Value{
+				"active": tftypes.NewValue(tftypes.Bool, false),
+			})
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State:  state,
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			warned := len(resp.Diagnostics.Warnings()) == 1 && resp.Diagnostics.Warnings()[0].Summary() == "Workflow Triggers Will Stop"
+			if warned != tc.wantWarning {
+				t.Errorf("expected warning %t, got diagnostics %v", tc.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestWorkflowNodeCounts(t *testing.T) {
+	nodes := `[
+		{"name":"Webhook","type":"n8n-nodes-base.webhook"},
+		{"name":"Schedule","type":"n8n-nodes-base.scheduleTrigger"},
+		{"name":"Chat","type":"@n8n/n8n-nodes-langchain.chatTrigger"},
+		{"name":"Start","type":"n8n-nodes-base.manualTrigger"},
+		{"name":"Set","type":"n8n-nodes-base.set"}
+	]`
+
+	nodeCount, triggerCount, err := workflowNodeCounts(nodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if nodeCount != 5 || triggerCount != 3 {
+		t.Errorf("expected 5 nodes with 3 triggers, got %d nodes with %d triggers", nodeCount, triggerCount)
+	}
+
+	if _, _, err := workflowNodeCounts(`{}`); err == nil {
+		t.Error("expected an error for nodes that are not an array")
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	testCases := map[string]struct {
+		value  string
+		subset string
+		want   bool
+	}{
+		"equal":              {value: `{"a":1}`, subset: `{"a":1}`, want: true},
+		"added key":          {value: `{"a":1,"id":"x"}`, subset: `{"a":1}`, want: true},
+		"nested added key":   {value: `[{"a":1,"id":"x"}]`, subset: `[{"a":1}]`, want: true},
+		"changed value":      {value: `{"a":2}`, subset: `{"a":1}`, want: false},
+		"missing key":        {value: `{}`, subset: `{"a":1}`, want: false},
+		"null for missing":   {value: `{}`, subset: `{"a":null}`, want: true},
+		"added element":      {value: `[1,2]`, subset: `[1]`, want: false},
+		"number format":      {value: `{"a":1.0}`, subset: `{"a":1}`, want: true},
+		"different type":     {value: `{"a":"1"}`, subset: `{"a":1}`, want: false},
+		"object and scalar":  {value: `{"a":{}}`, subset: `{"a":"x"}`, want: false},
+		"reordered elements": {value: `[2,1]`, subset: `[1,2]`, want: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			value, err := decodeJSON(tc.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			subset, err := decodeJSON(tc.subset)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := jsonContains(value, subset); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWorkflowNodesStateValue(t *testing.T) {
+	prior := `[{"name":"Webhook","position":[0,0]}]`
+
+	testCases := map[string]struct {
+		api     string
+		ignored []string
+		want    string
+	}{
+		"moved node":            {api: `[{"name":"Webhook","position":[200,300],"webhookId":"w1"}]`, ignored: []string{"position"}, want: prior},
+		"moved without ignored": {api: `[{"name":"Webhook","position":[200,300]}]`, want: `[{"name":"Webhook","position":[200,300]}]`},
+		"renamed node":          {api: `[{"name":"Hook","position":[200,300]}]`, ignored: []string{"position"}, want: `[{"name":"Hook","position":[200,300]}]`},
+		"reordered keys":        {api: `[{"position":[0,0],"name":"Webhook"}]`, want: prior},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := workflowNodesStateValue(types.StringValue(prior), json.RawMessage(tc.api), tc.ignored)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.ValueString() != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWorkflowNodesValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value      types.String
+		wantErrors int
+	}{
+		"valid":         {value: types.StringValue(testWorkflowNodes)},
+		"empty":         {value: types.StringValue(`[]`)},
+		"null":          {value: types.StringNull()},
+		"unknown":       {value: types.StringUnknown()},
+		"not an array":  {value: types.StringValue(`{"name":"Start"}`)},
+		"missing type":  {value: types.StringValue(`[{"name":"Start"}]`), wantErrors: 1},
+		"missing both":  {value: types.StringValue(`[{"parameters":{}}]`), wantErrors: 2},
+		"empty name":    {value: types.StringValue(`[{"name":"","type":"n8n-nodes-base.set"}]`), wantErrors: 1},
+		"not an object": {value: types.StringValue(`[{"name":"Start","type":"n8n-nodes-base.start"},"Set"]`), wantErrors: 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			workflowNodesValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("nodes"),
+				ConfigValue: tc.value,
+			}, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tc.wantErrors {
+				t.Errorf("expected %d errors, got: %v", tc.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestWorkflowNodesValidator_detail(t *testing.T) {
+	resp := &validator.StringResponse{}
+	workflowNodesValidator{}.ValidateString(context.Background(), validator.StringRequest{
+		Path:        path.Root("nodes"),
+		ConfigValue: types.StringValue(`[{"name":"Start","type":"n8n-nodes-base.start"},{"name":"Set","typ":"n8n-nodes-base.set"}]`),
+	}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected exactly one error, got: %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, `The node "Set" at index 1 of nodes has no type`) {
+		t.Errorf("expected the diagnostic to name the node and the missing key, got: %s", detail)
+	}
+}
+
+func TestWorkflowConnectionsValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value      types.String
+		wantErrors int
+	}{
+		"valid":            {value: types.StringValue(testWorkflowConnections)},
+		"empty":            {value: types.StringValue(`{}`)},
+		"null":             {value: types.StringNull()},
+		"not an object":    {value: types.StringValue(`[]`)},
+		"missing outputs":  {value: types.StringValue(`{"Webhook":[{"node":"Set"}]}`), wantErrors: 1},
+		"flat connections": {value: types.StringValue(`{"Webhook":{"main":[{"node":"Set","type":"main","index":0}]}}`), wantErrors: 1},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			workflowConnectionsValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("connections"),
+				ConfigValue: tc.value,
+			}, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tc.wantErrors {
+				t.Errorf("expected %d errors, got: %v", tc.wantErrors, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowTagDiffDataSource{}
+
+func NewWorkflowTagDiffDataSource() datasource.DataSource {
+	return &WorkflowTagDiffDataSource{}
+}
+
+// WorkflowTagDiffDataSource defines the data source implementation.
+type WorkflowTagDiffDataSource struct {
+	client *client.Client
+}
+
+// WorkflowTagDiffDataSourceModel describes the data source data model.
+type WorkflowTagDiffDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	TagNames   []string     `tfsdk:"tag_names"`
+	ToAdd      []string     `tfsdk:"to_add"`
+	ToRemove   []string     `tfsdk:"to_remove"`
+	Missing    []string     `tfsdk:"missing"`
+}
+
+func (d *WorkflowTagDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_tag_diff"
+}
+
+func (d *WorkflowTagDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow tag diff data source for previewing how the tags of a workflow would change, by comparing its current tags with a desired list of tag names.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to the workflow ID",
+				Computed:            true,
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow",
+				Required:            true,
+			},
+			"tag_names": schema.ListAttribute{
+				MarkdownDescription: "The desired tag names of the workflow. Names are matched exactly.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"to_add": schema.ListAttribute{
+				MarkdownDescription: "The desired tag names the workflow does not carry yet, in the order of `tag_names`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"to_remove": schema.ListAttribute{
+				MarkdownDescription: "The names of the tags the workflow carries that are not desired",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"missing": schema.ListAttribute{
+				MarkdownDescription: "The names in `to_add` no tag on the instance has, which need to be created before they can be attached",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *WorkflowTagDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *WorkflowTagDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowTagDiffDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := d.client.GetWorkflowTags(ctx, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("workflow_id"),
+			"Workflow Not Found",
+			fmt.Sprintf("Workflow with ID %q not found", data.WorkflowID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow tags", err)
+		return
+	}
+
+	ids, missing, err := tagIDsByName(ctx, d.client, data.TagNames, false, nil)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "resolve tag IDs", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.WorkflowID.ValueString())
+	data.ToAdd, data.ToRemove = workflowTagDiff(current, data.TagNames, ids)
+	data.Missing = missing
+	if data.Missing == nil {
+		data.Missing = []string{}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// workflowTagDiff compares the current tags of a workflow with the desired
+// tag names, whose tag IDs are in ids. Tags are compared by ID, so names
+// without an ID are always to be added. Both lists are free of duplicates.
+func workflowTagDiff(current []client.Tag, desired []string, ids map[string]string) (toAdd, toRemove []string) {
+	currentIDs := make(map[string]bool, len(current))
+	for _, tag := range current {
+		currentIDs[tag.ID] = true
+	}
+
+	desiredIDs := make(map[string]bool, len(desired))
+	toAdd = []string{}
+	for _, name := range desired {
+		id, ok := ids[name]
+		if ok {
+			desiredIDs[id] = true
+		}
+		if (!ok || !currentIDs[id]) && !containsString(toAdd, name) {
+			toAdd = append(toAdd, name)
+		}
+	}
+
+	toRemove = []string{}
+	for _, tag := range current {
+		if !desiredIDs[tag.ID] {
+			toRemove = append(toRemove, tag.Name)
+		}
+	}
+
+	return toAdd, toRemove
+}
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestWorkflowTagDiff(t *testing.T) {
+	ids := map[string]string{"production": "t1", "billing": "t2", "legacy": "t3"}
+
+	testCases := map[string]struct {
+		current      []client.Tag
+		desired      []string
+		wantToAdd    []string
+		wantToRemove []string
+	}{
+		"unchanged": {
+			current:      []client.Tag{{ID: "t1", Name: "production"}, {ID: "t2", Name: "billing"}},
+			desired:      []string{"billing", "production"},
+			wantToAdd:    []string{},
+			wantToRemove: []string{},
+		},
+		"add and remove": {
+			current:      []client.Tag{{ID: "t1", Name: "production"}, {ID: "t3", Name: "legacy"}},
+			desired:      []string{"production", "billing"},
+			wantToAdd:    []string{"billing"},
+			wantToRemove: []string{"legacy"},
+		},
+		"missing tag": {
+			current:      []client.Tag{{ID: "t1", Name: "production"}},
+			desired:      []string{"production", "staging", "staging"},
+			wantToAdd:    []string{"staging"},
+			wantToRemove: []string{},
+		},
+		"remove all": {
+			current:      []client.Tag{{ID: "t1", Name: "production"}},
+			desired:      []string{},
+			wantToAdd:    []string{},
+			wantToRemove: []string{"production"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			toAdd, toRemove := workflowTagDiff(tc.current, tc.desired, ids)
+
+			if !reflect.DeepEqual(toAdd, tc.wantToAdd) {
+				t.Errorf("expected to_add %v, got %v", tc.wantToAdd, toAdd)
+			}
+			if !reflect.DeepEqual(toRemove, tc.wantToRemove) {
+				t.Errorf("expected to_remove %v, got %v", tc.wantToRemove, toRemove)
+			}
+		})
+	}
+}
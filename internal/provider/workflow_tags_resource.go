@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowTagsResource{}
+var _ resource.ResourceWithImportState = &WorkflowTagsResource{}
+
+func NewWorkflowTagsResource() resource.Resource {
+	return &WorkflowTagsResource{}
+}
+
+// WorkflowTagsResource defines the resource implementation.
+type WorkflowTagsResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// WorkflowTagsResourceModel describes the resource data model.
+type WorkflowTagsResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	WorkflowID types.String `tfsdk:"workflow_id"`
+	TagIDs     []string     `tfsdk:"tag_ids"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *WorkflowTagsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_tags"
+}
+
+func (r *WorkflowTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow tags resource for managing the full set of tags attached to a workflow, for workflows whose content is not managed with `n8ncloud_workflow`. Tags attached outside Terraform are removed on apply, and destroying the resource detaches every tag. " +
+			"Do not use it together with the `tag_ids` attribute of an `n8ncloud_workflow` for the same workflow. Workflow tags can be imported using the workflow ID: `terraform import n8ncloud_workflow_tags.example 2tUt1wbLX592XDdX`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource, set to the workflow ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tag_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the tags attached to the workflow, such as the `id` of an `n8ncloud_tag`",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *WorkflowTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *WorkflowTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WorkflowTagsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	if !r.setTags(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	data.ID = data.WorkflowID
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_workflow_tags", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Created n8n workflow tags resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WorkflowTagsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	tags, err := r.client.GetWorkflowTags(ctx, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		// The workflow was deleted outside of Terraform
+		tflog.Warn(ctx, "n8n workflow not found, removing workflow tags from state", map[string]interface{}{
+			"workflow_id": data.WorkflowID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow tags", err)
+		return
+	}
+
+	data.ID = data.WorkflowID
+	data.TagIDs = workflowTagIDs(tags)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data WorkflowTagsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	if !r.setTags(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_workflow_tags", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Updated n8n workflow tags resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WorkflowTagsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.deleteTimeout())
+	defer cancel()
+
+	_, err := r.client.UpdateWorkflowTags(ctx, data.WorkflowID.ValueString(), []string{})
+	if err != nil && !client.IsNotFound(err) {
+		addClientError(&resp.Diagnostics, "detach workflow tags", err)
+		return
+	}
+
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_workflow_tags", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n workflow tags resource")
+}
+
+func (r *WorkflowTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workflow_id"), req.ID)...)
+}
+
+// setTags replaces the tags of the workflow in data with the configured set,
+// storing the tags now attached in data. It reports false after adding a
+// diagnostic if that failed.
+func (r *WorkflowTagsResource) setTags(ctx context.Context, data *WorkflowTagsResourceModel, diags *diag.Diagnostics) bool {
+	tagIDs := slices.Sorted(slices.Values(data.TagIDs))
+
+	tags, err := r.client.UpdateWorkflowTags(ctx, data.WorkflowID.ValueString(), tagIDs)
+	if err != nil {
+		addWorkflowTagsError(ctx, r.client, diags, data.WorkflowID.ValueString(), tagIDs, err)
+		return false
+	}
+
+	data.TagIDs = workflowTagIDs(tags)
+	return true
+}
+
+// addWorkflowTagsError adds a diagnostic for a failed attempt to attach
+// tagIDs to a workflow. The API does not say which reference is missing, so
+// the workflow and tags are looked up to point at tags deleted outside of
+// Terraform.
+func addWorkflowTagsError(ctx context.Context, c *client.Client, diags *diag.Diagnostics, workflowID string, tagIDs []string, err error) {
+	if _, lookupErr := c.GetWorkflow(ctx, workflowID); client.IsNotFound(lookupErr) {
+		diags.AddAttributeError(
+			path.Root("workflow_id"),
+			"Workflow Not Found",
+			fmt.Sprintf("Workflow with ID %q not found", workflowID),
+		)
+		return
+	}
+
+	var missing []string
+	for _, tagID := range tagIDs {
+		if _, lookupErr := c.GetTag(ctx, tagID); client.IsNotFound(lookupErr) {
+			missing = append(missing, tagID)
+		}
+	}
+
+	if len(missing) > 0 {
+		diags.AddAttributeError(
+			path.Root("tag_ids"),
+			"Tag Not Found",
+			fmt.Sprintf("Unable to attach tags to workflow %s because these tags do not exist, e.g. because they were deleted outside of Terraform: %s. "+
+				"Remove them from tag_ids or recreate them.", workflowID, strings.Join(missing, ", ")),
+		)
+		return
+	}
+
+	addClientError(diags, "update workflow tags", err)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+func TestAccWorkflowTagsResource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-workflow-tags-%d", time.Now().Unix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkflowTagsResourceConfig(name, "n8ncloud_tag.a.id"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow_tags.test",
+						tfjsonpath.New("tag_ids"),
+						knownvalue.SetSizeExact(1),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "n8ncloud_workflow_tags.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkflowTagsResourceConfig(name, "n8ncloud_tag.b.id", "n8ncloud_tag.a.id"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"n8ncloud_workflow_tags.test",
+						tfjsonpath.New("tag_ids"),
+						knownvalue.SetSizeExact(2),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccWorkflowTagsResourceConfig(name string, tagRefs ...string) string {
+	return fmt.Sprintf(`
+resource "n8ncloud_tag" "a" {
+  name = "%[1]s-a"
+}
+
+resource "n8ncloud_tag" "b" {
+  name = "%[1]s-b"
+}
+
+resource "n8ncloud_workflow" "test" {
+  name        = %[1]q
+  nodes       = jsonencode([])
+  connections = jsonencode({})
+
+  lifecycle {
+    ignore_changes = [tag_ids]
+  }
+}
+
+resource "n8ncloud_workflow_tags" "test" {
+  workflow_id = n8ncloud_workflow.test.id
+  tag_ids     = [%[2]s]
+}
+`, name, strings.Join(tagRefs, ", "))
+}
+
+// workflowTagsValue returns the tag_ids value for tagIDs.
+func workflowTagsValue(tagIDs ...string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		values = append(values, tftypes.NewValue(tftypes.String, id))
+	}
+
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+}
+
+// runWorkflowTagsCreate attaches tagIDs to workflowID through r and returns
+// the response.
+func runWorkflowTagsCreate(t *testing.T, r *WorkflowTagsResource, workflowID string, tagIDs ...string) *fwresource.CreateResponse {
+	t.Helper()
+
+	tagsSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"workflow_id": tftypes.NewValue(tftypes.String, workflowID),
+		"tag_ids":     workflowTagsValue(tagIDs...),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: tagsSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: tagsSchema, Raw: plan}}, resp)
+
+	return resp
+}
+
+func TestWorkflowTagsResourceCreate(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Tags = []client.Tag{{ID: "tag-manual", Name: "manual"}}
+	r := &WorkflowTagsResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowTagsCreate(t, r, workflow.ID, "tag-prod", "tag-billing")
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.hasRequest("PUT /workflows/" + workflow.ID) {
+		t.Error("expected the workflow content to be left alone")
+	}
+
+	got := workflowTagIDs(workflow.Tags)
+	if want := []string{"tag-billing", "tag-prod"}; !slices.Equal(got, want) {
+		t.Errorf("expected the workflow tags to be replaced with %v, got %v", want, got)
+	}
+
+	var state WorkflowTagsResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if state.ID.ValueString() != workflow.ID {
+		t.Errorf("expected id %s, got %s", workflow.ID, state.ID)
+	}
+}
+
+func TestWorkflowTagsResourceCreate_workflowNotFound(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	r := &WorkflowTagsResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowTagsCreate(t, r, "missing", "tag-prod")
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Workflow Not Found" {
+		t.Fatalf("expected a Workflow Not Found error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkflowTagsResourceCreate_tagNotFound(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	server.strictTags = true
+	server.tags = []client.Tag{{ID: "tag-prod", Name: "prod"}}
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	r := &WorkflowTagsResource{client: newTestClient(t, server.ServeHTTP)}
+
+	resp := runWorkflowTagsCreate(t, r, workflow.ID, "tag-prod", "tag-deleted")
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a deleted tag")
+	}
+
+	diagnostic := resp.Diagnostics.Errors()[0]
+	if diagnostic.Summary() != "Tag Not Found" {
+		t.Fatalf("expected a Tag Not Found error, got: %v", resp.Diagnostics)
+	}
+	if !strings.Contains(diagnostic.Detail(), "tag-deleted") || strings.Contains(diagnostic.Detail(), "tag-prod") {
+		t.Errorf("expected only the deleted tag to be named, got: %s", diagnostic.Detail())
+	}
+	if withPath, ok := diagnostic.(interface{ Path() path.Path }); !ok || !withPath.Path().Equal(path.Root("tag_ids")) {
+		t.Errorf("expected the error to point at tag_ids, got: %v", diagnostic)
+	}
+}
+
+func TestWorkflowTagsResourceRead(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Tags = []client.Tag{{ID: "tag-prod", Name: "prod"}, {ID: "tag-manual", Name: "manual"}}
+	r := &WorkflowTagsResource{client: newTestClient(t, server.ServeHTTP)}
+
+	tagsSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, workflow.ID),
+		"workflow_id": tftypes.NewValue(tftypes.String, workflow.ID),
+		"tag_ids":     workflowTagsValue("tag-prod"),
+	})
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: tagsSchema, Raw: state}}
+	r.Read(context.Background(), fwresource.ReadRequest{State: tfsdk.State{Schema: tagsSchema, Raw: state}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var got WorkflowTagsResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected error reading state: %v", diags)
+	}
+	if want := []string{"tag-manual", "tag-prod"}; !slices.Equal(got.TagIDs, want) {
+		t.Errorf("expected tag_ids %v, got %v", want, got.TagIDs)
+	}
+}
+
+func TestWorkflowTagsResourceDelete(t *testing.T) {
+	server := newFakeWorkflowServer(t)
+	workflow := server.add("Orders", testWorkflowNodes, testWorkflowConnections)
+	workflow.Tags = []client.Tag{{ID: "tag-prod", Name: "prod"}}
+	r := &WorkflowTagsResource{client: newTestClient(t, server.ServeHTTP)}
+
+	tagsSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, workflow.ID),
+		"workflow_id": tftypes.NewValue(tftypes.String, workflow.ID),
+		"tag_ids":     workflowTagsValue("tag-prod"),
+	})
+
+	resp := &fwresource.DeleteResponse{State: tfsdk.State{Schema: tagsSchema, Raw: state}}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: tfsdk.State{Schema: tagsSchema, Raw: state}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(workflow.Tags) != 0 {
+		t.Errorf("expected every tag to be detached, got %v", workflow.Tags)
+	}
+	if server.workflows[workflow.ID] == nil {
+		t.Error("expected the workflow to be kept")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WorkflowTransferResource{}
+
+func NewWorkflowTransferResource() resource.Resource {
+	return &WorkflowTransferResource{}
+}
+
+// WorkflowTransferResource defines the resource implementation.
+type WorkflowTransferResource struct {
+	client       *client.Client
+	changeReport *changeReport
+}
+
+// WorkflowTransferResourceModel describes the resource data model.
+type WorkflowTransferResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	WorkflowID           types.String `tfsdk:"workflow_id"`
+	DestinationProjectID types.String `tfsdk:"destination_project_id"`
+
+	Timeouts *timeoutsModel `tfsdk:"timeouts"`
+}
+
+func (r *WorkflowTransferResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_transfer"
+}
+
+func (r *WorkflowTransferResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflow transfer resource for moving a workflow to another project, e.g. when reorganizing workflows across teams. Creating the resource transfers the workflow and changing `destination_project_id` transfers it again, so the resource records the project that owns the workflow. " +
+			"A workflow already in the destination project counts as transferred. Destroying the resource leaves the workflow in its project. Transfers made outside Terraform are not detected. Projects require an Enterprise license.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the transfer, set to the workflow ID",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the workflow to transfer. Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination_project_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the project to move the workflow to",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(defaultOperationTimeout),
+		},
+	}
+}
+
+func (r *WorkflowTransferResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.changeReport = providerData.ChangeReport
+}
+
+func (r *WorkflowTransferResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data WorkflowTransferResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.createTimeout(defaultOperationTimeout))
+	defer cancel()
+
+	if !workflowTransfer.run(ctx, &resp.Diagnostics, r.client, data.WorkflowID.ValueString(), data.DestinationProjectID.ValueString()) {
+		return
+	}
+
+	data.ID = data.WorkflowID
+
+	r.changeReport.record(&resp.Diagnostics, changeCreated, "n8ncloud_workflow_transfer", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Created n8n workflow transfer resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowTransferResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data WorkflowTransferResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.readTimeout())
+	defer cancel()
+
+	// The API does not report the project owning a workflow, so only its
+	// existence is checked
+	err := workflowTransfer.get(ctx, r.client, data.WorkflowID.ValueString())
+	if client.IsNotFound(err) {
+		// The workflow was deleted outside of Terraform
+		tflog.Warn(ctx, "n8n workflow not found, removing transfer from state", map[string]interface{}{
+			"workflow_id": data.WorkflowID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read workflow", err)
+		return
+	}
+}
+
+func (r *WorkflowTransferResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state WorkflowTransferResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, data.Timeouts.updateTimeout())
+	defer cancel()
+
+	// Only the timeouts may have changed otherwise
+	if !data.DestinationProjectID.Equal(state.DestinationProjectID) {
+		if !workflowTransfer.run(ctx, &resp.Diagnostics, r.client, data.WorkflowID.ValueString(), data.DestinationProjectID.ValueString()) {
+			return
+		}
+
+		r.changeReport.record(&resp.Diagnostics, changeUpdated, "n8ncloud_workflow_transfer", data.ID.ValueString())
+	}
+
+	tflog.Trace(ctx, "Updated n8n workflow transfer resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WorkflowTransferResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data WorkflowTransferResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no project to move the workflow back to, so destroying the
+	// resource leaves it where it is
+	r.changeReport.record(&resp.Diagnostics, changeDeleted, "n8ncloud_workflow_transfer", data.ID.ValueString())
+
+	tflog.Trace(ctx, "Deleted n8n workflow transfer resource")
+}
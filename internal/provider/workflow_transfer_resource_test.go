@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeTransferServer serves the objects under resourcePath, e.g.
+// /workflows, owned by projects, and transfers them like n8n does.
+type fakeTransferServer struct {
+	t            *testing.T
+	resourcePath string
+	owners       map[string]string
+	projects     []string
+	transfers    []string
+	requests     []string
+}
+
+func (s *fakeTransferServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	s.requests = append(s.requests, r.Method+" "+path)
+
+	if r.Method == http.MethodGet && path == "/projects" {
+		var data []map[string]string
+		for _, id := range s.projects {
+			data = append(data, map[string]string{"id": id, "name": id})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "nextCursor": nil})
+		return
+	}
+
+	id, action, _ := strings.Cut(strings.TrimPrefix(path, s.resourcePath+"/"), "/")
+	owner, ok := s.owners[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && action == "":
+		_, _ = fmt.Fprintf(w, `{"id":%q,"name":"Orders"}`, id)
+	case r.Method == http.MethodPut && action == "transfer":
+		var body struct {
+			DestinationProjectID string `json:"destinationProjectId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		known := false
+		for _, project := range s.projects {
+			known = known || project == body.DestinationProjectID
+		}
+		switch {
+		case !known:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		case body.DestinationProjectID == owner:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"You can't transfer an object into the project that's already owning it."}`))
+		default:
+			s.owners[id] = body.DestinationProjectID
+			s.transfers = append(s.transfers, id+" to "+body.DestinationProjectID)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// runWorkflowTransferCreate transfers workflowID to projectID through r and
+// returns the response.
+func runWorkflowTransferCreate(t *testing.T, r *WorkflowTransferResource, workflowID, projectID string) *fwresource.CreateResponse {
+	t.Helper()
+
+	transferSchema, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"workflow_id":            tftypes.NewValue(tftypes.String, workflowID),
+		"destination_project_id": tftypes.NewValue(tftypes.String, projectID),
+	})
+
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: transferSchema, Raw: plan}}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: transferSchema, Raw: plan}}, resp)
+
+	return resp
+}
+
+func TestWorkflowTransferResourceCreate(t *testing.T) {
+	testCases := map[string]struct {
+		workflowID  string
+		projectID   string
+		wantError   string
+		wantOwner   string
+		wantRequest bool
+	}{
+		"transfer":           {workflowID: "wf1", projectID: "p2", wantOwner: "p2", wantRequest: true},
+		"already in project": {workflowID: "wf1", projectID: "p1", wantOwner: "p1"},
+		"workflow not found": {workflowID: "wf2", projectID: "p2", wantError: "Workflow Not Found"},
+		"project not found":  {workflowID: "wf1", projectID: "p3", wantError: "Project Not Found", wantOwner: "p1"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := &fakeTransferServer{t: t, resourcePath: "/workflows", owners: map[string]string{"wf1": "p1"}, projects: []string{"p1", "p2"}}
+			r := &WorkflowTransferResource{client: newTestClient(t, server.ServeHTTP)}
+
+			resp := runWorkflowTransferCreate(t, r, tc.workflowID, tc.projectID)
+
+			if tc.wantError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tc.wantError {
+					t.Fatalf("expected a %s error, got: %v", tc.wantError, resp.Diagnostics)
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if tc.wantOwner != "" && server.owners["wf1"] != tc.wantOwner {
+				t.Errorf("expected the workflow to be owned by %s, got %s", tc.wantOwner, server.owners["wf1"])
+			}
+			if got := len(server.transfers) == 1; got != tc.wantRequest {
+				t.Errorf("expected a transfer %t, got transfers %v", tc.wantRequest, server.transfers)
+			}
+		})
+	}
+}
+
+func TestWorkflowTransferResourceCreate_unlicensed(t *testing.T) {
+	r := &WorkflowTransferResource{client: newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Your license does not allow for feat:projectRole:admin."}`))
+	})}
+
+	resp := runWorkflowTransferCreate(t, r, "wf1", "p2")
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Projects Unavailable" {
+		t.Fatalf("expected a Projects Unavailable error, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestWorkflowTransferResourceUpdate(t *testing.T) {
+	server := &fakeTransferServer{t: t, resourcePath: "/workflows", owners: map[string]string{"wf1": "p1"}, projects: []string{"p1", "p2"}}
+	r := &WorkflowTransferResource{client: newTestClient(t, server.ServeHTTP)}
+
+	transferSchema, prior := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "wf1"),
+		"workflow_id":            tftypes.NewValue(tftypes.String, "wf1"),
+		"destination_project_id": tftypes.NewValue(tftypes.String, "p1"),
+	})
+	_, plan := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "wf1"),
+		"workflow_id":            tftypes.NewValue(tftypes.String, "wf1"),
+		"destination_project_id": tftypes.NewValue(tftypes.String, "p2"),
+	})
+
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: transferSchema, Raw: prior}}
+	r.Update(context.Background(), fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: transferSchema, Raw: plan},
+		State: tfsdk.State{Schema: transferSchema, Raw: prior},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if server.owners["wf1"] != "p2" {
+		t.Errorf("expected the workflow to be transferred again, got owner %s", server.owners["wf1"])
+	}
+}
+
+func TestWorkflowTransferResourceRead_workflowDeleted(t *testing.T) {
+	server := &fakeTransferServer{t: t, resourcePath: "/workflows", owners: map[string]string{}, projects: []string{"p1"}}
+	r := &WorkflowTransferResource{client: newTestClient(t, server.ServeHTTP)}
+
+	transferSchema, state := resourceTestValue(t, r, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "wf1"),
+		"workflow_id":            tftypes.NewValue(tftypes.String, "wf1"),
+		"destination_project_id": tftypes.NewValue(tftypes.String, "p1"),
+	})
+
+	resp := &fwresource.ReadResponse{State: tfsdk.State{Schema: transferSchema, Raw: state}}
+	r.Read(context.Background(), fwresource.ReadRequest{State: tfsdk.State{Schema: transferSchema, Raw: state}}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("expected the transfer to be removed from state when the workflow is deleted")
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowsByTagDataSource{}
+
+func NewWorkflowsByTagDataSource() datasource.DataSource {
+	return &WorkflowsByTagDataSource{}
+}
+
+// WorkflowsByTagDataSource defines the data source implementation.
+type WorkflowsByTagDataSource struct {
+	client *client.Client
+}
+
+// WorkflowsByTagDataSourceModel describes the data source data model.
+type WorkflowsByTagDataSourceModel struct {
+	ID         types.String          `tfsdk:"id"`
+	TagID      types.String          `tfsdk:"tag_id"`
+	TagName    types.String          `tfsdk:"tag_name"`
+	ProjectID  types.String          `tfsdk:"project_id"`
+	ExtraQuery map[string]string     `tfsdk:"extra_query"`
+	Workflows  []TaggedWorkflowModel `tfsdk:"workflows"`
+}
+
+// TaggedWorkflowModel describes a workflow returned by the data source.
+type TaggedWorkflowModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Active types.Bool   `tfsdk:"active"`
+}
+
+func (d *WorkflowsByTagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflows_by_tag"
+}
+
+func (d *WorkflowsByTagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflows by tag data source for finding the workflows that carry a tag, e.g. before retagging them. You must specify either `tag_id` or `tag_name` to identify the tag.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to the tag ID",
+				Computed:            true,
+			},
+			"tag_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the tag. Either tag_id or tag_name must be specified.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"tag_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the tag. Either tag_id or tag_name must be specified.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Restricts the workflows to those of a project, on instances with projects enabled. The project must exist.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"workflows": schema.ListNestedAttribute{
+				MarkdownDescription: "The workflows carrying the tag",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the workflow",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the workflow",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the workflow is active",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkflowsByTagDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *WorkflowsByTagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowsByTagDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that exactly one of tag ID or name is specified
+	if data.TagID.IsNull() == data.TagName.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Attribute Combination",
+			"Exactly one of 'tag_id' or 'tag_name' must be specified",
+		)
+		return
+	}
+
+	var tag *client.Tag
+	var err error
+
+	// The API filters workflows by tag name, so resolve the tag first
+	if !data.TagID.IsNull() {
+		tag, err = d.client.GetTag(ctx, data.TagID.ValueString())
+	} else {
+		tag, err = d.client.GetTagByName(ctx, data.TagName.ValueString())
+	}
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read tag", err)
+		return
+	}
+
+	if !checkWorkflowProject(ctx, &resp.Diagnostics, d.client, data.ProjectID) {
+		return
+	}
+
+	workflows, err := d.client.ListWorkflows(ctx, &client.ListWorkflowsOptions{
+		ListOptions: client.ListOptions{ExtraQuery: data.ExtraQuery},
+		Tags:        []string{tag.Name},
+		ProjectID:   data.ProjectID.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list workflows", err)
+		return
+	}
+
+	data.ID = types.StringValue(tag.ID)
+	data.TagID = types.StringValue(tag.ID)
+	data.TagName = types.StringValue(tag.Name)
+
+	data.Workflows = make([]TaggedWorkflowModel, 0, len(workflows))
+	for _, workflow := range workflows {
+		data.Workflows = append(data.Workflows, TaggedWorkflowModel{
+			ID:     types.StringValue(workflow.ID),
+			Name:   types.StringValue(workflow.Name),
+			Active: types.BoolValue(workflow.Active),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkWorkflowProject checks that the project workflows are listed for
+// exists, since the API silently returns nothing for an unknown project. It
+// reports false after adding a diagnostic if the project does not exist or
+// cannot be read. A null projectID is not checked.
+func checkWorkflowProject(ctx context.Context, diags *diag.Diagnostics, c *client.Client, projectID types.String) bool {
+	if projectID.IsNull() {
+		return true
+	}
+
+	_, err := c.GetProject(ctx, projectID.ValueString())
+	if client.IsNotFound(err) {
+		diags.AddAttributeError(
+			path.Root("project_id"),
+			"Project Not Found",
+			fmt.Sprintf("Project with ID %q not found", projectID.ValueString()),
+		)
+		return false
+	}
+	if err != nil {
+		addClientError(diags, "read project", err)
+		return false
+	}
+
+	return true
+}
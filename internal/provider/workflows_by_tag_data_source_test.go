@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkflowsByTagDataSource_invalidAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "n8ncloud_workflows_by_tag" "test" {}`,
+				ExpectError: regexp.MustCompile("Exactly one of 'tag_id' or 'tag_name' must be specified"),
+			},
+			{
+				Config: `
+data "n8ncloud_workflows_by_tag" "test" {
+  tag_id   = "abc"
+  tag_name = "production"
+}
+`,
+				ExpectError: regexp.MustCompile("Exactly one of 'tag_id' or 'tag_name' must be specified"),
+			},
+		},
+	})
+}
@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ka2n/terraform-provider-n8ncloud/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowsDataSource{}
+
+func NewWorkflowsDataSource() datasource.DataSource {
+	return &WorkflowsDataSource{}
+}
+
+// WorkflowsDataSource defines the data source implementation.
+type WorkflowsDataSource struct {
+	client *client.Client
+}
+
+// WorkflowsDataSourceModel describes the data source data model.
+type WorkflowsDataSourceModel struct {
+	ID         types.String          `tfsdk:"id"`
+	Active     types.Bool            `tfsdk:"active"`
+	Tags       []string              `tfsdk:"tags"`
+	Name       types.String          `tfsdk:"name"`
+	ProjectID  types.String          `tfsdk:"project_id"`
+	ExtraQuery map[string]string     `tfsdk:"extra_query"`
+	Workflows  []ListedWorkflowModel `tfsdk:"workflows"`
+}
+
+// ListedWorkflowModel describes a workflow returned by the data source.
+type ListedWorkflowModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Active    types.Bool   `tfsdk:"active"`
+	Tags      []string     `tfsdk:"tags"`
+	TagIDs    []string     `tfsdk:"tag_ids"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	ImportID  types.String `tfsdk:"import_id"`
+}
+
+func (d *WorkflowsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflows"
+}
+
+func (d *WorkflowsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflows data source for listing the workflows of the instance, e.g. to audit them or to act on all active workflows with a tag. Every page of workflows is read, and the optional filters are applied to the full list.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the data source, set to `workflows`",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Only return active workflows when `true`, or inactive workflows when `false`",
+				Optional:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "Only return workflows carrying all of these tags, each given by name or ID. The tags must exist.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only return workflows with exactly this name, matched case-sensitively",
+				Optional:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Restricts the workflows to those of a project, on instances with projects enabled. The project must exist.",
+				Optional:            true,
+			},
+			"extra_query": schema.MapAttribute{
+				MarkdownDescription: extraQueryDescription,
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"workflows": schema.ListNestedAttribute{
+				MarkdownDescription: "The workflows matching the filters, in the order returned by the API",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the workflow",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the workflow",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Whether the workflow is active",
+							Computed:            true,
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "The names of the tags of the workflow",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"tag_ids": schema.ListAttribute{
+							MarkdownDescription: "The IDs of the tags of the workflow, in the same order as `tags`",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the workflow was created",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the workflow was last updated",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "The ID to import the workflow into an `n8ncloud_workflow` resource with, its ID, e.g. for scripting `terraform import` commands",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *WorkflowsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*N8nCloudProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.N8nCloudProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *WorkflowsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkflowsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &client.ListWorkflowsOptions{
+		ListOptions: client.ListOptions{ExtraQuery: data.ExtraQuery},
+		ProjectID:   data.ProjectID.ValueString(),
+		Name:        data.Name.ValueString(),
+	}
+	if !data.Active.IsNull() {
+		active := data.Active.ValueBool()
+		opts.Active = &active
+	}
+
+	// The API filters workflows by tag name and returns nothing for an
+	// unknown tag, so the tags are resolved first
+	if len(data.Tags) > 0 {
+		tags, err := d.client.ListTags(ctx, nil)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "list tags", err)
+			return
+		}
+
+		for i, tag := range data.Tags {
+			name, ok := workflowTagName(tags, tag)
+			if !ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("tags").AtListIndex(i),
+					"Tag Not Found",
+					fmt.Sprintf("No tag with the name or ID %q exists", tag),
+				)
+				continue
+			}
+			opts.Tags = append(opts.Tags, name)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !checkWorkflowProject(ctx, &resp.Diagnostics, d.client, data.ProjectID) {
+		return
+	}
+
+	workflows, err := d.client.ListWorkflows(ctx, opts)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list workflows", err)
+		return
+	}
+
+	data.ID = types.StringValue("workflows")
+	data.Workflows = make([]ListedWorkflowModel, 0, len(workflows))
+	for _, workflow := range workflows {
+		// The API may match names partially
+		if !data.Name.IsNull() && workflow.Name != data.Name.ValueString() {
+			continue
+		}
+
+		listed := ListedWorkflowModel{
+			ID:        types.StringValue(workflow.ID),
+			Name:      types.StringValue(workflow.Name),
+			Active:    types.BoolValue(workflow.Active),
+			Tags:      make([]string, 0, len(workflow.Tags)),
+			TagIDs:    make([]string, 0, len(workflow.Tags)),
+			CreatedAt: types.StringValue(workflow.CreatedAt.Format(time.RFC3339Nano)),
+			UpdatedAt: types.StringValue(workflow.UpdatedAt.Format(time.RFC3339Nano)),
+			ImportID:  types.StringValue(workflow.ID),
+		}
+		for _, tag := range workflow.Tags {
+			listed.Tags = append(listed.Tags, tag.Name)
+			listed.TagIDs = append(listed.TagIDs, tag.ID)
+		}
+
+		data.Workflows = append(data.Workflows, listed)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// workflowTagName returns the name of the tag among tags with the ID or, if
+// none has it, the name nameOrID, and false if there is no such tag.
+func workflowTagName(tags []client.Tag, nameOrID string) (string, bool) {
+	for _, tag := range tags {
+		if tag.ID == nameOrID {
+			return tag.Name, true
+		}
+	}
+
+	for _, tag := range tags {
+		if tag.Name == nameOrID {
+			return tag.Name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readWorkflows reads d with the given configuration values, leaving the
+// others null, and returns the resulting state and diagnostics.
+func readWorkflows(t *testing.T, d *WorkflowsDataSource, values map[string]tftypes.Value) (WorkflowsDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range values {
+		attributes[name] = value
+	}
+	config := tftypes.NewValue(objectType, attributes)
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: config}}
+
+	d.Read(ctx, req, resp)
+
+	var data WorkflowsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &data); diags.HasError() {
+			t.Fatalf("unexpected error reading state: %v", diags)
+		}
+	}
+
+	return data, resp.Diagnostics
+}
+
+// workflowsTestServer serves two tags, one project and two pages of
+// workflows, recording the query of every workflows request.
+func workflowsTestServer(t *testing.T, queries *[]url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v1/tags":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","name":"production"},{"id":"t2","name":"billing"}],"nextCursor":null}`))
+		case "/api/v1/projects":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"Sales","type":"team"}],"nextCursor":null}`))
+		case "/api/v1/workflows":
+			*queries = append(*queries, r.URL.Query())
+			if r.URL.Query().Get("cursor") == "" {
+				_, _ = w.Write([]byte(`{"data":[{"id":"wf1","name":"Orders","active":true,"tags":[{"id":"t1","name":"production"}]},{"id":"wf2","name":"Orders (copy)","active":true,"tags":[]}],"nextCursor":"page2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"wf3","name":"Invoices","active":true,"tags":[{"id":"t1","name":"production"},{"id":"t2","name":"billing"}]}],"nextCursor":null}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestWorkflowsDataSourceRead(t *testing.T) {
+	tags := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	testCases := map[string]struct {
+		values     map[string]tftypes.Value
+		want       []string
+		wantQuery  url.Values
+		wantAbsent []string
+	}{
+		"all": {
+			want:       []string{"wf1", "wf2", "wf3"},
+			wantAbsent: []string{"active", "tags", "name", "projectId"},
+		},
+		"active": {
+			values:    map[string]tftypes.Value{"active": tftypes.NewValue(tftypes.Bool, true)},
+			want:      []string{"wf1", "wf2", "wf3"},
+			wantQuery: url.Values{"active": {"true"}},
+		},
+		"tags by name and ID": {
+			values:    map[string]tftypes.Value{"tags": tags("production", "t2")},
+			want:      []string{"wf1", "wf2", "wf3"},
+			wantQuery: url.Values{"tags": {"production,billing"}},
+		},
+		"exact name": {
+			values:    map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "Orders")},
+			want:      []string{"wf1"},
+			wantQuery: url.Values{"name": {"Orders"}},
+		},
+		"project": {
+			values:    map[string]tftypes.Value{"project_id": tftypes.NewValue(tftypes.String, "p1")},
+			want:      []string{"wf1", "wf2", "wf3"},
+			wantQuery: url.Values{"projectId": {"p1"}},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var queries []url.Values
+			d := &WorkflowsDataSource{client: newTestClient(t, workflowsTestServer(t, &queries))}
+
+			data, diags := readWorkflows(t, d, tc.values)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var ids []string
+			for _, workflow := range data.Workflows {
+				ids = append(ids, workflow.ID.ValueString())
+			}
+			if !slices.Equal(ids, tc.want) {
+				t.Errorf("expected workflows %v, got %v", tc.want, ids)
+			}
+
+			// The filters are sent with every page
+			if len(queries) != 2 {
+				t.Fatalf("expected every page to be read, got %d requests", len(queries))
+			}
+			for _, query := range queries {
+				for key, want := range tc.wantQuery {
+					if got := query[key]; !slices.Equal(got, want) {
+						t.Errorf("expected %s=%v, got %v", key, want, got)
+					}
+				}
+				for _, key := range tc.wantAbsent {
+					if query.Has(key) {
+						t.Errorf("expected no %s filter, got %v", key, query[key])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWorkflowsDataSourceRead_tags(t *testing.T) {
+	var queries []url.Values
+	d := &WorkflowsDataSource{client: newTestClient(t, workflowsTestServer(t, &queries))}
+
+	data, diags := readWorkflows(t, d, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	invoices := data.Workflows[2]
+	if !slices.Equal(invoices.Tags, []string{"production", "billing"}) || !slices.Equal(invoices.TagIDs, []string{"t1", "t2"}) {
+		t.Errorf("expected the tags of the workflow, got %v and %v", invoices.Tags, invoices.TagIDs)
+	}
+	if invoices.ImportID.ValueString() != "wf3" {
+		t.Errorf("expected the workflow ID as import ID, got %s", invoices.ImportID)
+	}
+	if data.Workflows[1].Tags == nil {
+		t.Error("expected an empty list for a workflow without tags")
+	}
+}
+
+func TestWorkflowsDataSourceRead_notFound(t *testing.T) {
+	testCases := map[string]struct {
+		values      map[string]tftypes.Value
+		wantSummary string
+	}{
+		"tag": {
+			values: map[string]tftypes.Value{
+				"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "staging")}),
+			},
+			wantSummary: "Tag Not Found",
+		},
+		"project": {
+			values:      map[string]tftypes.Value{"project_id": tftypes.NewValue(tftypes.String, "p2")},
+			wantSummary: "Project Not Found",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var queries []url.Values
+			d := &WorkflowsDataSource{client: newTestClient(t, workflowsTestServer(t, &queries))}
+
+			_, diags := readWorkflows(t, d, tc.values)
+
+			if !diags.HasError() || diags.Errors()[0].Summary() != tc.wantSummary {
+				t.Fatalf("expected a %q error, got: %v", tc.wantSummary, diags)
+			}
+			if len(queries) != 0 {
+				t.Errorf("expected no workflows to be listed, got %d requests", len(queries))
+			}
+		})
+	}
+}